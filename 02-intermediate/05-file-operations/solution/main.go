@@ -108,10 +108,18 @@ func main() {
 	}
 	
 	lines := []string{"Line 1", "Line 2", "Line 3"}
-	WriteLines("test.txt", lines)
+	if err := WriteLines("test.txt", lines); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
 	
-	read, _ := ReadLines("test.txt")
+	read, err := ReadLines("test.txt")
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
 	fmt.Println("Read lines:", read)
 	
-	os.Remove("test.txt")
+	if err := os.Remove("test.txt"); err != nil {
+		fmt.Println("Error:", err)
+	}
 }
@@ -2,59 +2,40 @@ package main
 
 import "fmt"
 
-// SendNumbers sends numbers 1 through n to the channel
-// TODO: Implement number generation
-func SendNumbers(ch chan<- int, n int) {
-	// TODO: Send numbers 1 to n
-	// TODO: Close channel when done
+// Ping sends msg on ping.
+func Ping(ping chan<- string, msg string) {
+	// TODO: Send msg on the ping channel
+	panic("TODO")
 }
 
-// ReceiveSum receives all numbers from channel and returns their sum
-// TODO: Implement sum calculation
-func ReceiveSum(ch <-chan int) int {
-	// TODO: Receive all values until channel closed
-	// TODO: Return sum
-	return 0
+// Pong receives a message from ping and sends it back on pong.
+func Pong(ping <-chan string, pong chan<- string) {
+	// TODO: Receive a message from ping and send it on pong
+	panic("TODO")
 }
 
-// BufferedPipeline demonstrates buffered channel usage
-// TODO: Implement a pipeline with buffered channels
-func BufferedPipeline(numbers []int) []int {
-	// TODO: Create buffered channel
-	// TODO: Send numbers in goroutine
-	// TODO: Transform numbers (e.g., square them)
-	// TODO: Collect results
-	return nil
+// BufferedWriter sends values on ch, then closes it.
+func BufferedWriter(ch chan<- int, values []int) {
+	// TODO: Send every value on ch
+	// TODO: Close ch when done, as only the sender should
+	panic("TODO")
 }
 
-// GenerateSquares creates a channel that emits squares of 1 to n
-// TODO: Implement generator pattern
-func GenerateSquares(n int) <-chan int {
-	// TODO: Create channel
-	// TODO: Launch goroutine to send values
-	// TODO: Close channel after sending all values
-	// TODO: Return channel
-	return nil
+// SafeReceiver receives from ch until it is closed and returns the values.
+func SafeReceiver(ch <-chan int) []int {
+	// TODO: Range over ch until it is closed and collect the values
+	panic("TODO")
 }
 
 func main() {
-	fmt.Println("Channel Basics Examples")
-
-	// Example 1: Simple send/receive
-	ch := make(chan int)
-	go SendNumbers(ch, 10)
-	sum := ReceiveSum(ch)
-	fmt.Printf("Sum of 1-10: %d\n", sum)
-
-	// Example 2: Buffered pipeline
-	numbers := []int{1, 2, 3, 4, 5}
-	squares := BufferedPipeline(numbers)
-	fmt.Printf("Squares: %v\n", squares)
-
-	// Example 3: Generator pattern
-	fmt.Println("Squares from generator:")
-	for square := range GenerateSquares(5) {
-		fmt.Printf("%d ", square)
-	}
-	fmt.Println()
+	fmt.Println("Channels Basics")
+
+	ping, pong := make(chan string), make(chan string)
+	go Ping(ping, "hello")
+	go Pong(ping, pong)
+	fmt.Println("Pong:", <-pong)
+
+	ch := make(chan int, 3)
+	go BufferedWriter(ch, []int{1, 2, 3})
+	fmt.Println("Received:", SafeReceiver(ch))
 }
@@ -2,15 +2,25 @@ package main
 
 import "fmt"
 
+// Ping sends msg on ping.
+//
+//starter:todo Send msg on the ping channel
 func Ping(ping chan<- string, msg string) {
 	ping <- msg
 }
 
+// Pong receives a message from ping and sends it back on pong.
+//
+//starter:todo Receive a message from ping and send it on pong
 func Pong(ping <-chan string, pong chan<- string) {
 	msg := <-ping
 	pong <- msg
 }
 
+// BufferedWriter sends values on ch, then closes it.
+//
+//starter:todo Send every value on ch
+//starter:todo Close ch when done, as only the sender should
 func BufferedWriter(ch chan<- int, values []int) {
 	for _, v := range values {
 		ch <- v
@@ -18,6 +28,9 @@ func BufferedWriter(ch chan<- int, values []int) {
 	close(ch)
 }
 
+// SafeReceiver receives from ch until it is closed and returns the values.
+//
+//starter:todo Range over ch until it is closed and collect the values
 func SafeReceiver(ch <-chan int) []int {
 	var results []int
 	for v := range ch {
@@ -27,5 +40,14 @@ func SafeReceiver(ch <-chan int) []int {
 }
 
 func main() {
-	fmt.Println("Channels Basics - Solution")
+	fmt.Println("Channels Basics")
+
+	ping, pong := make(chan string), make(chan string)
+	go Ping(ping, "hello")
+	go Pong(ping, pong)
+	fmt.Println("Pong:", <-pong)
+
+	ch := make(chan int, 3)
+	go BufferedWriter(ch, []int{1, 2, 3})
+	fmt.Println("Received:", SafeReceiver(ch))
 }
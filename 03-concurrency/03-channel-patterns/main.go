@@ -1,31 +1,55 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+)
 
-// Generator creates a channel and sends values
-func Generator(values ...int) <-chan int {
-	// TODO: Create channel, send values in goroutine, return channel
-	return nil
+// Pipeline squares numbers in a pipeline of stages and emits the even
+// squares.
+func Pipeline(numbers []int) <-chan int {
+	// TODO: Chain three stages, each a goroutine closing its output channel: generate the numbers, square them, keep the even squares
+	panic("TODO")
 }
 
-// Square receives from in, squares values, sends to returned channel
-func Square(in <-chan int) <-chan int {
-	// TODO: Implement pipeline stage
-	return nil
+// FanOut distributes the values of in over n workers, which emit them
+// doubled.
+func FanOut(in <-chan int, n int) []<-chan int {
+	// TODO: Start n workers that read from in and send each value doubled on their own channel, closed when in is drained
+	panic("TODO")
 }
 
-// FanOut distributes work from in to numWorkers workers
-func FanOut(in <-chan int, numWorkers int, work func(int) int) []<-chan int {
-	// TODO: Create numWorkers, each processing from in
-	return nil
+// FanIn merges channels into one.
+func FanIn(channels ...<-chan int) <-chan int {
+	// TODO: Merge the channels into one, closed once every input channel is drained (sync.WaitGroup)
+	panic("TODO")
 }
 
-// FanIn merges multiple channels into one
-func FanIn(channels ...<-chan int) <-chan int {
-	// TODO: Merge all channels into single output
-	return nil
+// WorkerPool processes jobs with numWorkers workers and emits their
+// results.
+func WorkerPool(jobs <-chan Job, numWorkers int) <-chan Result {
+	// TODO: Start numWorkers workers squaring the Data of each job into a Result, and close results once all are done
+	panic("TODO")
+}
+
+// Job is a unit of work for WorkerPool.
+type Job struct {
+	ID   int
+	Data int
+}
+
+// Result is the output of a Job.
+type Result struct {
+	JobID  int
+	Output int
 }
 
 func main() {
-	fmt.Println("Channel Patterns")
+	fmt.Println("Channel Patterns Demo")
+
+	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	fmt.Println("Pipeline results:")
+	for result := range Pipeline(numbers) {
+		fmt.Printf("%d ", result)
+	}
+	fmt.Println()
 }
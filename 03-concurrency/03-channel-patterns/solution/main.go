@@ -5,6 +5,10 @@ import (
 	"sync"
 )
 
+// Pipeline squares numbers in a pipeline of stages and emits the even
+// squares.
+//
+//starter:todo Chain three stages, each a goroutine closing its output channel: generate the numbers, square them, keep the even squares
 func Pipeline(numbers []int) <-chan int {
 	// Stage 1: Generate
 	gen := func() <-chan int {
@@ -47,6 +51,10 @@ func Pipeline(numbers []int) <-chan int {
 	return filter(sq(gen()))
 }
 
+// FanOut distributes the values of in over n workers, which emit them
+// doubled.
+//
+//starter:todo Start n workers that read from in and send each value doubled on their own channel, closed when in is drained
 func FanOut(in <-chan int, n int) []<-chan int {
 	channels := make([]<-chan int, n)
 	for i := 0; i < n; i++ {
@@ -63,6 +71,9 @@ func FanOut(in <-chan int, n int) []<-chan int {
 	return channels
 }
 
+// FanIn merges channels into one.
+//
+//starter:todo Merge the channels into one, closed once every input channel is drained (sync.WaitGroup)
 func FanIn(channels ...<-chan int) <-chan int {
 	out := make(chan int)
 	var wg sync.WaitGroup
@@ -86,6 +97,10 @@ func FanIn(channels ...<-chan int) <-chan int {
 	return out
 }
 
+// WorkerPool processes jobs with numWorkers workers and emits their
+// results.
+//
+//starter:todo Start numWorkers workers squaring the Data of each job into a Result, and close results once all are done
 func WorkerPool(jobs <-chan Job, numWorkers int) <-chan Result {
 	results := make(chan Result)
 	var wg sync.WaitGroup
@@ -111,11 +126,13 @@ func WorkerPool(jobs <-chan Job, numWorkers int) <-chan Result {
 	return results
 }
 
+// Job is a unit of work for WorkerPool.
 type Job struct {
 	ID   int
 	Data int
 }
 
+// Result is the output of a Job.
 type Result struct {
 	JobID  int
 	Output int
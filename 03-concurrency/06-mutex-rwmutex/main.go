@@ -5,22 +5,49 @@ import (
 	"sync"
 )
 
-// Counter with mutex protection
+// Counter is a counter safe for concurrent use, guarded by a mutex.
 type Counter struct {
 	mu    sync.Mutex
 	value int
 }
 
-// TODO: Implement Increment, Decrement, Value methods
+// Increment adds one to the counter.
+func (c *Counter) Increment() {
+	// TODO: Lock the mutex around the update (defer the Unlock)
+}
+
+// Decrement subtracts one from the counter.
+func (c *Counter) Decrement() {
+	// TODO: Lock the mutex around the update (defer the Unlock)
+}
+
+// Value returns the current count.
+func (c *Counter) Value() int {
+	// TODO: Lock the mutex to read the value, too
+	return 0
+}
 
-// ReadWriteCounter with RWMutex
+// ReadWriteCounter is a counter for read-heavy use: readers share an
+// RWMutex, writers hold it alone.
 type ReadWriteCounter struct {
 	mu    sync.RWMutex
 	value int
 }
 
-// TODO: Implement methods using RWMutex
+// Increment adds one to the counter.
+func (c *ReadWriteCounter) Increment() {
+	// TODO: Take the write lock around the update
+}
+
+// Value returns the current count.
+func (c *ReadWriteCounter) Value() int {
+	// TODO: Take the read lock, so readers do not block each other
+	return 0
+}
 
 func main() {
 	fmt.Println("Mutex examples")
+	c := &Counter{}
+	c.Increment()
+	fmt.Println("Counter:", c.Value())
 }
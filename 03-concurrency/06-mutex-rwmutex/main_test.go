@@ -18,6 +18,9 @@ func TestCounter(t *testing.T) {
 		}()
 	}
 	wg.Wait()
-	
+
+	if got := c.Value(); got != 1000 {
+		t.Errorf("Value() = %d, want 1000", got)
+	}
 	// Test with: go test -race
 }
@@ -5,40 +5,58 @@ import (
 	"sync"
 )
 
+// Counter is a counter safe for concurrent use, guarded by a mutex.
 type Counter struct {
 	mu    sync.Mutex
 	value int
 }
 
+// Increment adds one to the counter.
+//
+//starter:zero Lock the mutex around the update (defer the Unlock)
 func (c *Counter) Increment() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.value++
 }
 
+// Decrement subtracts one from the counter.
+//
+//starter:zero Lock the mutex around the update (defer the Unlock)
 func (c *Counter) Decrement() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.value--
 }
 
+// Value returns the current count.
+//
+//starter:zero Lock the mutex to read the value, too
 func (c *Counter) Value() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return c.value
 }
 
+// ReadWriteCounter is a counter for read-heavy use: readers share an
+// RWMutex, writers hold it alone.
 type ReadWriteCounter struct {
 	mu    sync.RWMutex
 	value int
 }
 
+// Increment adds one to the counter.
+//
+//starter:zero Take the write lock around the update
 func (c *ReadWriteCounter) Increment() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.value++
 }
 
+// Value returns the current count.
+//
+//starter:zero Take the read lock, so readers do not block each other
 func (c *ReadWriteCounter) Value() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
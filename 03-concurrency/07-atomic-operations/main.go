@@ -2,18 +2,32 @@ package main
 
 import (
 	"fmt"
-	// TODO: Uncomment for atomic operations
-	// "sync/atomic"
 )
 
-// AtomicCounter uses atomic operations
+// AtomicCounter is a counter safe for concurrent use without locks.
 type AtomicCounter struct {
 	value int64
 }
 
-// TODO: Implement atomic Increment, Decrement, Value
-// Use atomic.AddInt64, atomic.LoadInt64
+// Increment adds one to the counter.
+func (c *AtomicCounter) Increment() {
+	// TODO: Add 1 with atomic.AddInt64
+}
+
+// Decrement subtracts one from the counter.
+func (c *AtomicCounter) Decrement() {
+	// TODO: Add -1 with atomic.AddInt64
+}
+
+// Value returns the current count.
+func (c *AtomicCounter) Value() int64 {
+	// TODO: Read the value with atomic.LoadInt64
+	return 0
+}
 
 func main() {
 	fmt.Println("Atomic operations")
+	c := &AtomicCounter{}
+	c.Increment()
+	fmt.Println("Counter:", c.Value())
 }
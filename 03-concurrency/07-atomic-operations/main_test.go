@@ -17,4 +17,8 @@ func TestAtomicCounter(t *testing.T) {
 		}()
 	}
 	wg.Wait()
+
+	if got := c.Value(); got != 1000 {
+		t.Errorf("Value() = %d, want 1000", got)
+	}
 }
@@ -5,18 +5,28 @@ import (
 	"sync/atomic"
 )
 
+// AtomicCounter is a counter safe for concurrent use without locks.
 type AtomicCounter struct {
 	value int64
 }
 
+// Increment adds one to the counter.
+//
+//starter:zero Add 1 with atomic.AddInt64
 func (c *AtomicCounter) Increment() {
 	atomic.AddInt64(&c.value, 1)
 }
 
+// Decrement subtracts one from the counter.
+//
+//starter:zero Add -1 with atomic.AddInt64
 func (c *AtomicCounter) Decrement() {
 	atomic.AddInt64(&c.value, -1)
 }
 
+// Value returns the current count.
+//
+//starter:zero Read the value with atomic.LoadInt64
 func (c *AtomicCounter) Value() int64 {
 	return atomic.LoadInt64(&c.value)
 }
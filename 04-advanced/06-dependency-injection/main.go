@@ -0,0 +1,490 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Domain Models
+type User struct {
+	ID        int
+	Name      string
+	Email     string
+	CreatedAt time.Time
+}
+
+// Interfaces for dependencies
+type UserRepository interface {
+	FindByID(ctx context.Context, id int) (*User, error)
+	Save(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context) ([]*User, error)
+}
+
+type Logger interface {
+	Info(msg string, fields ...Field)
+	Error(msg string, err error)
+	Debug(msg string, fields ...Field)
+}
+
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration)
+	Delete(key string)
+	Clear()
+}
+
+type EmailSender interface {
+	Send(to, subject, body string) error
+}
+
+// InMemoryUserRepository - concrete implementation
+type InMemoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[int]*User
+	logger Logger
+}
+
+func NewInMemoryUserRepository(logger Logger) *InMemoryUserRepository {
+	return &InMemoryUserRepository{
+		users:  make(map[int]*User),
+		logger: logger,
+	}
+}
+
+func (r *InMemoryUserRepository) FindByID(ctx context.Context, id int) (*User, error) {
+	// TODO: Return the stored user, or an error when the ID is unknown
+	panic("TODO")
+}
+
+func (r *InMemoryUserRepository) Save(ctx context.Context, user *User) error {
+	// TODO: Assign the next ID to new users and set CreatedAt if it is zero
+	// TODO: Store the user under its ID
+	panic("TODO")
+}
+
+func (r *InMemoryUserRepository) Delete(ctx context.Context, id int) error {
+	// TODO: Remove the user, or return an error when the ID is unknown
+	panic("TODO")
+}
+
+func (r *InMemoryUserRepository) List(ctx context.Context) ([]*User, error) {
+	// TODO: Return all stored users
+	panic("TODO")
+}
+
+// SimpleLogger - concrete implementation
+type SimpleLogger struct {
+	mu     sync.Mutex
+	prefix string
+}
+
+func NewSimpleLogger(prefix string) *SimpleLogger {
+	return &SimpleLogger{prefix: prefix}
+}
+
+func (l *SimpleLogger) Info(msg string, fields ...Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Printf("[%s][INFO] %s %s\n", l.prefix, msg, formatFields(fields))
+}
+
+func (l *SimpleLogger) Error(msg string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Printf("[%s][ERROR] %s: %v\n", l.prefix, msg, err)
+}
+
+func (l *SimpleLogger) Debug(msg string, fields ...Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Printf("[%s][DEBUG] %s %s\n", l.prefix, msg, formatFields(fields))
+}
+
+func formatFields(fields []Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s=%v", f.Key, f.Value)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// NopLogger - logger that does nothing (useful for testing)
+type NopLogger struct{}
+
+func NewNopLogger() *NopLogger {
+	return &NopLogger{}
+}
+
+func (l *NopLogger) Info(msg string, fields ...Field)  {}
+func (l *NopLogger) Error(msg string, err error)       {}
+func (l *NopLogger) Debug(msg string, fields ...Field) {}
+
+// InMemoryCache - concrete implementation
+type InMemoryCache struct {
+	mu    sync.RWMutex
+	items map[string]cacheItem
+}
+
+type cacheItem struct {
+	value      interface{}
+	expiration time.Time
+}
+
+func NewInMemoryCache() *InMemoryCache {
+	return &InMemoryCache{
+		items: make(map[string]cacheItem),
+	}
+}
+
+func (c *InMemoryCache) Get(key string) (interface{}, bool) {
+	// TODO: Return the cached value unless it is missing or expired
+	panic("TODO")
+}
+
+func (c *InMemoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	// TODO: Store the value, expiring after ttl when ttl is positive
+	panic("TODO")
+}
+
+func (c *InMemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+}
+
+func (c *InMemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[string]cacheItem)
+}
+
+// MockEmailSender - concrete implementation for testing
+type MockEmailSender struct {
+	SentEmails []Email
+	mu         sync.Mutex
+}
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+func NewMockEmailSender() *MockEmailSender {
+	return &MockEmailSender{
+		SentEmails: make([]Email, 0),
+	}
+}
+
+func (m *MockEmailSender) Send(to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.SentEmails = append(m.SentEmails, Email{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	})
+	return nil
+}
+
+// UserService - service with constructor injection
+type UserService struct {
+	repo   UserRepository
+	logger Logger
+	cache  Cache
+	email  EmailSender
+	config *ServiceConfig
+}
+
+// ServiceConfig for functional options pattern
+type ServiceConfig struct {
+	CacheEnabled bool
+	CacheTTL     time.Duration
+	MaxRetries   int
+	Timeout      time.Duration
+}
+
+// Option is a functional option for UserService
+type Option func(*ServiceConfig)
+
+func WithCache(enabled bool) Option {
+	// TODO: Return an option that sets CacheEnabled
+	panic("TODO")
+}
+
+func WithCacheTTL(ttl time.Duration) Option {
+	// TODO: Return an option that sets CacheTTL
+	panic("TODO")
+}
+
+func WithMaxRetries(retries int) Option {
+	// TODO: Return an option that sets MaxRetries
+	panic("TODO")
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	// TODO: Return an option that sets Timeout
+	panic("TODO")
+}
+
+// NewUserService creates a new UserService with constructor injection
+func NewUserService(repo UserRepository, logger Logger, cache Cache, email EmailSender, opts ...Option) *UserService {
+	// TODO: Start from the defaults: cache enabled for 5 minutes, 3 retries, 30s timeout
+	// TODO: Apply the options, then store the injected dependencies
+	panic("TODO")
+}
+
+func (s *UserService) GetUser(ctx context.Context, id int) (*User, error) {
+	// TODO: Look the user up in the cache first when caching is enabled
+	// TODO: Fall back to the repository and cache the result
+	panic("TODO")
+}
+
+func (s *UserService) CreateUser(ctx context.Context, name, email string) (*User, error) {
+	// TODO: Save the new user through the repository
+	// TODO: Send a welcome email; a failed email does not fail the call
+	panic("TODO")
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, user *User) error {
+	// TODO: Save the user and invalidate its cache entry
+	panic("TODO")
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, id int) error {
+	// TODO: Delete the user and invalidate its cache entry
+	panic("TODO")
+}
+
+func (s *UserService) ListUsers(ctx context.Context) ([]*User, error) {
+	// TODO: Return the users from the repository
+	panic("TODO")
+}
+
+// Container - simple DI container
+type Container struct {
+	logger      Logger
+	cache       Cache
+	emailSender EmailSender
+	userRepo    UserRepository
+	userService *UserService
+}
+
+// NewContainer creates a new DI container with all dependencies
+func NewContainer() *Container {
+	// TODO: Wire the logger, cache, email sender, repository and user service
+	panic("TODO")
+}
+
+// NewTestContainer creates a container for testing
+func NewTestContainer() *Container {
+	// TODO: Wire the container like NewContainer, with a NopLogger and a 1 minute cache TTL
+	panic("TODO")
+}
+
+// Getters for services
+func (c *Container) UserService() *UserService {
+	return c.userService
+}
+
+func (c *Container) Logger() Logger {
+	return c.logger
+}
+
+func (c *Container) Cache() Cache {
+	return c.cache
+}
+
+func (c *Container) EmailSender() EmailSender {
+	return c.emailSender
+}
+
+// Provider pattern for dynamic dependency resolution
+type Provider func() (interface{}, error)
+
+type Registry struct {
+	providers map[string]Provider
+	instances map[string]interface{}
+	mu        sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: make(map[string]Provider),
+		instances: make(map[string]interface{}),
+	}
+}
+
+func (r *Registry) Register(name string, provider Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[name] = provider
+}
+
+func (r *Registry) Get(name string) (interface{}, error) {
+	// TODO: Return the cached instance, or create it once with the registered provider
+	// TODO: Return an error for names without a provider
+	panic("TODO")
+}
+
+func (r *Registry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.instances = make(map[string]interface{})
+}
+
+// Lifecycle interface for start/stop operations
+type Lifecycle interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// App demonstrates lifecycle management
+type App struct {
+	container  *Container
+	components []Lifecycle
+}
+
+func NewApp(container *Container) *App {
+	return &App{
+		container:  container,
+		components: make([]Lifecycle, 0),
+	}
+}
+
+func (a *App) AddComponent(c Lifecycle) {
+	a.components = append(a.components, c)
+}
+
+func (a *App) Start(ctx context.Context) error {
+	// TODO: Start the components in order, stopping at the first error
+	panic("TODO")
+}
+
+func (a *App) Stop(ctx context.Context) error {
+	// TODO: Stop the components in reverse order, stopping at the first error
+	panic("TODO")
+}
+
+// Wire-style provider pattern
+func ProvideLogger() Logger {
+	return NewSimpleLogger("wire")
+}
+
+func ProvideCache() Cache {
+	return NewInMemoryCache()
+}
+
+func ProvideEmailSender() EmailSender {
+	return NewMockEmailSender()
+}
+
+func ProvideUserRepository(logger Logger) UserRepository {
+	return NewInMemoryUserRepository(logger)
+}
+
+func ProvideUserService(repo UserRepository, logger Logger, cache Cache, email EmailSender) *UserService {
+	return NewUserService(repo, logger, cache, email)
+}
+
+// InitializeUserService demonstrates wire-style initialization
+func InitializeUserService() *UserService {
+	logger := ProvideLogger()
+	cache := ProvideCache()
+	email := ProvideEmailSender()
+	repo := ProvideUserRepository(logger)
+	service := ProvideUserService(repo, logger, cache, email)
+	return service
+}
+
+// ErrorWithStack demonstrates error handling with dependency injection
+type ErrorWithStack struct {
+	message string
+	cause   error
+	stack   string
+}
+
+func NewErrorWithStack(message string, cause error) *ErrorWithStack {
+	return &ErrorWithStack{
+		message: message,
+		cause:   cause,
+		stack:   captureStack(),
+	}
+}
+
+func (e *ErrorWithStack) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %v", e.message, e.cause)
+	}
+	return e.message
+}
+
+func (e *ErrorWithStack) Unwrap() error {
+	return e.cause
+}
+
+func (e *ErrorWithStack) StackTrace() string {
+	return e.stack
+}
+
+func captureStack() string {
+	buf := make([]byte, 4096)
+	n := runtime.Stack(buf, false)
+	return string(buf[:n])
+}
+
+// Validation errors
+var (
+	ErrInvalidInput = errors.New("invalid input")
+	ErrNotFound     = errors.New("not found")
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Create container
+	container := NewContainer()
+
+	// Use service
+	user, err := container.UserService().CreateUser(ctx, "Alice", "alice@example.com")
+	if err != nil {
+		fmt.Printf("Error creating user: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Created user: %+v\n", user)
+
+	// Retrieve user
+	retrieved, err := container.UserService().GetUser(ctx, user.ID)
+	if err != nil {
+		fmt.Printf("Error retrieving user: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Retrieved user: %+v\n", retrieved)
+
+	// List users
+	users, err := container.UserService().ListUsers(ctx)
+	if err != nil {
+		fmt.Printf("Error listing users: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Total users: %d\n", len(users))
+}
@@ -62,6 +62,7 @@ func NewInMemoryUserRepository(logger Logger) *InMemoryUserRepository {
 	}
 }
 
+//starter:todo Return the stored user, or an error when the ID is unknown
 func (r *InMemoryUserRepository) FindByID(ctx context.Context, id int) (*User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -74,6 +75,8 @@ func (r *InMemoryUserRepository) FindByID(ctx context.Context, id int) (*User, e
 	return nil, fmt.Errorf("user %d not found", id)
 }
 
+//starter:todo Assign the next ID to new users and set CreatedAt if it is zero
+//starter:todo Store the user under its ID
 func (r *InMemoryUserRepository) Save(ctx context.Context, user *User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -92,6 +95,7 @@ func (r *InMemoryUserRepository) Save(ctx context.Context, user *User) error {
 	return nil
 }
 
+//starter:todo Remove the user, or return an error when the ID is unknown
 func (r *InMemoryUserRepository) Delete(ctx context.Context, id int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -105,6 +109,7 @@ func (r *InMemoryUserRepository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+//starter:todo Return all stored users
 func (r *InMemoryUserRepository) List(ctx context.Context) ([]*User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -186,6 +191,7 @@ func NewInMemoryCache() *InMemoryCache {
 	}
 }
 
+//starter:todo Return the cached value unless it is missing or expired
 func (c *InMemoryCache) Get(key string) (interface{}, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -202,6 +208,7 @@ func (c *InMemoryCache) Get(key string) (interface{}, bool) {
 	return item.value, true
 }
 
+//starter:todo Store the value, expiring after ttl when ttl is positive
 func (c *InMemoryCache) Set(key string, value interface{}, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -279,24 +286,28 @@ type ServiceConfig struct {
 // Option is a functional option for UserService
 type Option func(*ServiceConfig)
 
+//starter:todo Return an option that sets CacheEnabled
 func WithCache(enabled bool) Option {
 	return func(c *ServiceConfig) {
 		c.CacheEnabled = enabled
 	}
 }
 
+//starter:todo Return an option that sets CacheTTL
 func WithCacheTTL(ttl time.Duration) Option {
 	return func(c *ServiceConfig) {
 		c.CacheTTL = ttl
 	}
 }
 
+//starter:todo Return an option that sets MaxRetries
 func WithMaxRetries(retries int) Option {
 	return func(c *ServiceConfig) {
 		c.MaxRetries = retries
 	}
 }
 
+//starter:todo Return an option that sets Timeout
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *ServiceConfig) {
 		c.Timeout = timeout
@@ -304,6 +315,9 @@ func WithTimeout(timeout time.Duration) Option {
 }
 
 // NewUserService creates a new UserService with constructor injection
+//
+//starter:todo Start from the defaults: cache enabled for 5 minutes, 3 retries, 30s timeout
+//starter:todo Apply the options, then store the injected dependencies
 func NewUserService(repo UserRepository, logger Logger, cache Cache, email EmailSender, opts ...Option) *UserService {
 	config := &ServiceConfig{
 		CacheEnabled: true,
@@ -325,6 +339,8 @@ func NewUserService(repo UserRepository, logger Logger, cache Cache, email Email
 	}
 }
 
+//starter:todo Look the user up in the cache first when caching is enabled
+//starter:todo Fall back to the repository and cache the result
 func (s *UserService) GetUser(ctx context.Context, id int) (*User, error) {
 	// Try cache first if enabled
 	if s.config.CacheEnabled {
@@ -351,6 +367,8 @@ func (s *UserService) GetUser(ctx context.Context, id int) (*User, error) {
 	return user, nil
 }
 
+//starter:todo Save the new user through the repository
+//starter:todo Send a welcome email; a failed email does not fail the call
 func (s *UserService) CreateUser(ctx context.Context, name, email string) (*User, error) {
 	user := &User{
 		Name:  name,
@@ -372,6 +390,7 @@ func (s *UserService) CreateUser(ctx context.Context, name, email string) (*User
 	return user, nil
 }
 
+//starter:todo Save the user and invalidate its cache entry
 func (s *UserService) UpdateUser(ctx context.Context, user *User) error {
 	if err := s.repo.Save(ctx, user); err != nil {
 		s.logger.Error("Failed to update user", err)
@@ -388,6 +407,7 @@ func (s *UserService) UpdateUser(ctx context.Context, user *User) error {
 	return nil
 }
 
+//starter:todo Delete the user and invalidate its cache entry
 func (s *UserService) DeleteUser(ctx context.Context, id int) error {
 	if err := s.repo.Delete(ctx, id); err != nil {
 		s.logger.Error("Failed to delete user", err)
@@ -404,6 +424,7 @@ func (s *UserService) DeleteUser(ctx context.Context, id int) error {
 	return nil
 }
 
+//starter:todo Return the users from the repository
 func (s *UserService) ListUsers(ctx context.Context) ([]*User, error) {
 	users, err := s.repo.List(ctx)
 	if err != nil {
@@ -425,6 +446,8 @@ type Container struct {
 }
 
 // NewContainer creates a new DI container with all dependencies
+//
+//starter:todo Wire the logger, cache, email sender, repository and user service
 func NewContainer() *Container {
 	c := &Container{}
 
@@ -439,6 +462,8 @@ func NewContainer() *Container {
 }
 
 // NewTestContainer creates a container for testing
+//
+//starter:todo Wire the container like NewContainer, with a NopLogger and a 1 minute cache TTL
 func NewTestContainer() *Container {
 	c := &Container{}
 
@@ -498,6 +523,8 @@ func (r *Registry) Register(name string, provider Provider) {
 	r.providers[name] = provider
 }
 
+//starter:todo Return the cached instance, or create it once with the registered provider
+//starter:todo Return an error for names without a provider
 func (r *Registry) Get(name string) (interface{}, error) {
 	// Check if already instantiated (singleton pattern)
 	r.mu.RLock()
@@ -564,6 +591,7 @@ func (a *App) AddComponent(c Lifecycle) {
 	a.components = append(a.components, c)
 }
 
+//starter:todo Start the components in order, stopping at the first error
 func (a *App) Start(ctx context.Context) error {
 	for _, c := range a.components {
 		if err := c.Start(ctx); err != nil {
@@ -573,6 +601,7 @@ func (a *App) Start(ctx context.Context) error {
 	return nil
 }
 
+//starter:todo Stop the components in reverse order, stopping at the first error
 func (a *App) Stop(ctx context.Context) error {
 	// Stop in reverse order
 	for i := len(a.components) - 1; i >= 0; i-- {
@@ -9,16 +9,14 @@ import (
 
 	pb "github.com/alyxpink/go-training/advanced/10-grpc-basics/pb"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // UserServer implements the UserService gRPC interface
 type UserServer struct {
 	pb.UnimplementedUserServiceServer
-	users   map[int64]*pb.User
-	nextID  int64
-	mu      sync.RWMutex
+	users  map[int64]*pb.User
+	nextID int64
+	mu     sync.RWMutex
 }
 
 // NewUserServer creates a new UserServer instance with sample data
@@ -39,74 +37,21 @@ func NewUserServer() *UserServer {
 
 // GetUser implements the GetUser RPC method
 func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
-	if req.Id <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "user ID must be positive")
-	}
-
-	s.mu.RLock()
-	user, exists := s.users[req.Id]
-	s.mu.RUnlock()
-
-	if !exists {
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("user with ID %d not found", req.Id))
-	}
-
-	return user, nil
+	// TODO: Reject IDs that are not positive with codes.InvalidArgument and unknown ones with codes.NotFound
+	// TODO: Read the users map under the read lock
+	return nil, nil
 }
 
 // CreateUser implements the CreateUser RPC method
 func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
-	// Validate request
-	if req.Name == "" {
-		return nil, status.Error(codes.InvalidArgument, "name cannot be empty")
-	}
-	if req.Email == "" {
-		return nil, status.Error(codes.InvalidArgument, "email cannot be empty")
-	}
-	if req.Age < 0 {
-		return nil, status.Error(codes.InvalidArgument, "age must be non-negative")
-	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	// Create new user
-	user := &pb.User{
-		Id:    s.nextID,
-		Name:  req.Name,
-		Email: req.Email,
-		Age:   req.Age,
-	}
-
-	s.users[s.nextID] = user
-	s.nextID++
-
-	return user, nil
+	// TODO: Reject an empty name or email and a negative age with codes.InvalidArgument
+	// TODO: Store the user under the next ID, holding the write lock
+	return nil, nil
 }
 
 // ListUsers implements the ListUsers RPC method (server streaming)
 func (s *UserServer) ListUsers(req *pb.ListUsersRequest, stream pb.UserService_ListUsersServer) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	limit := req.Limit
-	if limit <= 0 {
-		limit = int32(len(s.users)) // Return all users if limit is 0 or negative
-	}
-
-	count := int32(0)
-	for _, user := range s.users {
-		if count >= limit {
-			break
-		}
-
-		if err := stream.Send(user); err != nil {
-			return status.Error(codes.Internal, fmt.Sprintf("failed to send user: %v", err))
-		}
-
-		count++
-	}
-
+	// TODO: Send up to req.Limit users on the stream, all of them when the limit is not positive
 	return nil
 }
 
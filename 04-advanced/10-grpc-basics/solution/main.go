@@ -38,6 +38,9 @@ func NewUserServer() *UserServer {
 }
 
 // GetUser implements the GetUser RPC method
+//
+//starter:zero Reject IDs that are not positive with codes.InvalidArgument and unknown ones with codes.NotFound
+//starter:zero Read the users map under the read lock
 func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
 	if req.Id <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "user ID must be positive")
@@ -55,6 +58,9 @@ func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.U
 }
 
 // CreateUser implements the CreateUser RPC method
+//
+//starter:zero Reject an empty name or email and a negative age with codes.InvalidArgument
+//starter:zero Store the user under the next ID, holding the write lock
 func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
 	// Validate request
 	if req.Name == "" {
@@ -85,6 +91,8 @@ func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 }
 
 // ListUsers implements the ListUsers RPC method (server streaming)
+//
+//starter:zero Send up to req.Limit users on the stream, all of them when the limit is not positive
 func (s *UserServer) ListUsers(req *pb.ListUsersRequest, stream pb.UserService_ListUsersServer) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -19,6 +19,7 @@ func (f *JSONFormatter) Format(data interface{}) (string, error) {
 	// Hint: Use json.MarshalIndent for pretty, json.Marshal for compact
 	var b bytes.Buffer
 	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false) // jq prints "<b>", not "\u003cb\u003e"
 	
 	if !f.Compact {
 		enc.SetIndent("", "  ")
@@ -40,10 +41,12 @@ func (f *RawFormatter) Format(data interface{}) (string, error) {
 		return s + "\n", nil
 	}
 	
-	b, err := json.Marshal(data)
-	if err != nil {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
 		return "", err
 	}
 	
-	return string(b) + "\n", nil
+	return b.String(), nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -14,10 +15,12 @@ var (
 	compact = flag.Bool("c", false, "compact output")
 	table   = flag.Bool("t", false, "table output")
 	raw     = flag.Bool("r", false, "raw output (no quotes)")
+	slurp   = flag.Bool("slurp", false, "run the query once on an array of all input values")
 	showVer = flag.Bool("v", false, "show version")
 )
 
 func main() {
+	flag.BoolVar(slurp, "s", false, "run the query once on an array of all input values")
 	flag.Usage = usage
 	flag.Parse()
 
@@ -41,8 +44,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	// With -s the values of every input are gathered into one array, which
+	// the query runs on once all inputs are read
+	slurped := []interface{}{}
+	process := func(r io.Reader, filename string) error {
+		if !*slurp {
+			return processInput(r, q, filename)
+		}
+		var err error
+		slurped, err = appendValues(slurped, json.NewDecoder(r))
+		return err
+	}
+
 	if len(files) == 0 {
-		if err := processInput(os.Stdin, q, "stdin"); err != nil {
+		if err := process(os.Stdin, "stdin"); err != nil {
 			fmt.Fprintf(os.Stderr, "error: %v\n", err)
 			os.Exit(1)
 		}
@@ -53,7 +68,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "error opening %s: %v\n", filename, err)
 				os.Exit(1)
 			}
-			if err := processInput(f, q, filename); err != nil {
+			if err := process(f, filename); err != nil {
 				f.Close()
 				fmt.Fprintf(os.Stderr, "error processing %s: %v\n", filename, err)
 				os.Exit(1)
@@ -61,14 +76,33 @@ func main() {
 			f.Close()
 		}
 	}
+
+	if *slurp {
+		if err := runQuery(q, []interface{}{slurped}); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
 }
 
 func processInput(r io.Reader, q *query.Query, filename string) error {
-	panic("not implemented")
+	// TODO: Run the query on every JSON value of the input, streaming its results unless -t needs all values first
+	panic("TODO")
+}
+
+func appendValues(values []interface{}, dec *json.Decoder) ([]interface{}, error) {
+	// TODO: Decode every JSON value left in dec and append it to values
+	panic("TODO")
+}
+
+func runQuery(q *query.Query, values []interface{}) error {
+	// TODO: Run the query on each value and print the results, as one table with -t
+	panic("TODO")
 }
 
 func outputResult(data interface{}) error {
-	panic("not implemented")
+	// TODO: Pick the formatter from the -c, -t and -r flags and print the formatted data
+	panic("TODO")
 }
 
 func usage() {
@@ -81,6 +115,8 @@ Flags:
   -c    compact output
   -t    table output
   -r    raw output (no quotes)
+  -s, --slurp
+        read the JSON values of all inputs into one array
   -v    show version
   -h    show help
 
@@ -88,5 +124,7 @@ Examples:
   jq '.name' data.json
   echo '{"name": "Alice"}' | jq '.name'
   jq -t '.users[]' data.json
+  jq -c '.events[] | select(.level == "error")' huge.json
+  jq -s 'map(.id)' records.ndjson
 `)
 }
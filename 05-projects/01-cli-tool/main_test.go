@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alyxpink/go-training/jq/formatter"
 	"github.com/alyxpink/go-training/jq/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -122,7 +123,7 @@ func TestProcessInput(t *testing.T) {
 		{
 			name:     "length operation",
 			input:    `{"items": [1, 2, 3, 4, 5]}`,
-			queryStr: ".items length",
+			queryStr: ".items | length",
 			wantErr:  false,
 			setupFlag: func() {
 				*compact = false
@@ -348,6 +349,27 @@ func TestOutputResult(t *testing.T) {
 	}
 }
 
+func TestFormatKeepsHTML(t *testing.T) {
+	data := map[string]interface{}{"a": "<b>&</b>"}
+	tests := []struct {
+		name string
+		f    formatter.Formatter
+		want string
+	}{
+		{"compact", &formatter.JSONFormatter{Compact: true}, `{"a":"<b>&</b>"}` + "\n"},
+		{"pretty", &formatter.JSONFormatter{}, "{\n  \"a\": \"<b>&</b>\"\n}\n"},
+		{"raw", &formatter.RawFormatter{}, `{"a":"<b>&</b>"}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.Format(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestProcessInputErrorHandling(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -387,3 +409,19 @@ func TestProcessInputErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendValues(t *testing.T) {
+	// -s gathers the values of every input into one array
+	values, err := appendValues(nil, json.NewDecoder(strings.NewReader(`{"id": 1} {"id": 2}`)))
+	require.NoError(t, err)
+	values, err = appendValues(values, json.NewDecoder(strings.NewReader(`[3]`)))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": 1.0},
+		map[string]interface{}{"id": 2.0},
+		[]interface{}{3.0},
+	}, values)
+
+	_, err = appendValues(values, json.NewDecoder(strings.NewReader(`{"id": `)))
+	assert.ErrorContains(t, err, "parsing JSON")
+}
@@ -0,0 +1,241 @@
+package query
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// builtin is a function queries can call by name. Its arguments are
+// filters, run by the function on whatever input it chooses.
+type builtin struct {
+	arity int
+	fn    func(data interface{}, args []QueryNode) ([]interface{}, error)
+}
+
+// builtins holds the functions of the query language, by name.
+var builtins = map[string]builtin{
+	"length":       {0, length},
+	"not":          {0, not},
+	"select":       {1, selectFn},
+	"map":          {1, mapFn},
+	"sort_by":      {1, sortBy},
+	"group_by":     {1, groupBy},
+	"unique_by":    {1, uniqueBy},
+	"min_by":       {1, minBy},
+	"max_by":       {1, maxBy},
+	"keys":         {0, keys},
+	"values":       {0, values},
+	"has":          {1, has},
+	"add":          {0, add},
+	"to_entries":   {0, toEntries},
+	"from_entries": {0, fromEntries},
+	"tostring":     {0, tostring},
+	"tonumber":     {0, tonumber},
+}
+
+// Execute runs the builtin function named by the call.
+func (c *Call) Execute(data interface{}) ([]interface{}, error) {
+	b, ok := builtins[c.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q", c.Name)
+	}
+	return b.fn(data, c.Args)
+}
+
+func length(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Return the number of array elements, object keys or string runes; null has length 0
+	panic("TODO")
+}
+
+func not(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Return whether data is falsy
+	panic("TODO")
+}
+
+// selectFn emits data for each output of the condition that holds, so
+// nothing when it does not.
+func selectFn(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Emit data once for every truthy output of the condition
+	panic("TODO")
+}
+
+// mapFn collects the outputs of f on each element into an array.
+func mapFn(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Run the filter on every element of an array and collect the outputs
+	panic("TODO")
+}
+
+// keyed is an array element with its sort key: all the outputs of the key
+// filter on it.
+type keyed struct {
+	key   []interface{}
+	value interface{}
+}
+
+// sortedByKey returns the elements of the array data sorted by the outputs
+// of f on them. The sort is stable.
+func sortedByKey(name string, data interface{}, f QueryNode) ([]keyed, error) {
+	arr, ok := data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s requires an array, got %T", name, data)
+	}
+
+	elems := make([]keyed, len(arr))
+	for i, v := range arr {
+		key, err := f.Execute(v)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = keyed{key: key, value: v}
+	}
+	slices.SortStableFunc(elems, func(a, b keyed) int {
+		return compare(a.key, b.key)
+	})
+	return elems, nil
+}
+
+// groupByKey returns the sorted elements of the array data, grouped by
+// equal keys.
+func groupByKey(name string, data interface{}, f QueryNode) ([][]keyed, error) {
+	elems, err := sortedByKey(name, data, f)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups [][]keyed
+	for i, elem := range elems {
+		if i == 0 || compare(elem.key, elems[i-1].key) != 0 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], elem)
+	}
+	return groups, nil
+}
+
+func sortBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Sort an array by the outputs of the key filter, keeping equal elements in order
+	panic("TODO")
+}
+
+func groupBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Group the elements of an array by key, in key order
+	panic("TODO")
+}
+
+// uniqueBy keeps the first element of each group of equal keys, sorted by
+// key.
+func uniqueBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Keep the first element of each group of equal keys, in key order
+	panic("TODO")
+}
+
+// minBy returns the first element with the smallest key, or null for an
+// empty array.
+func minBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Return the element with the smallest key, null for an empty array
+	panic("TODO")
+}
+
+// maxBy returns the last element with the largest key, or null for an
+// empty array.
+func maxBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Return the element with the largest key, null for an empty array
+	panic("TODO")
+}
+
+// keys returns the sorted keys of an object or the indices of an array.
+func keys(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Return the sorted keys of an object or the indexes of an array
+	panic("TODO")
+}
+
+// values emits its input unless it is null, like jq's.
+func values(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Emit the input unless it is null
+	panic("TODO")
+}
+
+// has reports whether an object has a key, or an array an index.
+func has(data interface{}, args []QueryNode) ([]interface{}, error) {
+	// TODO: Report whether an object has a key or an array has an index
+	panic("TODO")
+}
+
+// add sums the elements of an array: numbers are added, strings and arrays
+// concatenated and objects merged. Nulls are skipped; an empty array adds up
+// to null.
+func add(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Add up the elements of an array with plus, null for an empty array
+	panic("TODO")
+}
+
+// plus adds two values of the same type, or anything to null.
+func plus(a, b interface{}) (interface{}, error) {
+	if a == nil {
+		return b, nil
+	}
+	if b == nil {
+		return a, nil
+	}
+
+	switch a := a.(type) {
+	case float64, int:
+		switch b.(type) {
+		case float64, int:
+			return toFloat(a) + toFloat(b), nil
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return a + b, nil
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			return append(slices.Clip(a), b...), nil
+		}
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			merged := maps.Clone(a)
+			maps.Copy(merged, b)
+			return merged, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot add %T and %T", a, b)
+}
+
+// toEntries turns an object into an array of {"key", "value"} objects,
+// sorted by key.
+func toEntries(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Turn an object into an array of {"key", "value"} objects, in key order
+	panic("TODO")
+}
+
+// fromEntries turns an array of {"key", "value"} objects into an object.
+// Like jq, it also accepts "k", "name" and "v" as names.
+func fromEntries(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Build an object from {"key", "value"} entries (k, name, v are accepted too)
+	panic("TODO")
+}
+
+// firstOf returns the value of the first of the keys the object has.
+func firstOf(m map[string]interface{}, keys ...string) interface{} {
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// tostring returns strings as they are and anything else as JSON, with
+// <, > and & left as they are, as jq does.
+func tostring(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Return strings as they are and anything else as JSON text, without escaping HTML
+	panic("TODO")
+}
+
+// tonumber parses strings and returns numbers as they are.
+func tonumber(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	// TODO: Return numbers as they are and parse strings as numbers
+	panic("TODO")
+}
@@ -4,81 +4,186 @@ import (
 	"fmt"
 )
 
-func (f *FieldSelect) Execute(data interface{}) (interface{}, error) {
+func (f *FieldSelect) Execute(data interface{}) ([]interface{}, error) {
 	// TODO: Implement field selection
-	// Hint: Type assert to map[string]interface{}, return field value
-
-	// Special case: handle .length on arrays
-	if f.Field == "length" {
-		switch v := data.(type) {
-		case []interface{}:
-			return len(v), nil
-		case string:
-			return len(v), nil
-		}
-	}
+	// TODO: Type assert to map[string]interface{}, return field value; fields of null are null, and .length counts array elements and string runes
+	panic("TODO")
+}
 
-	m, ok := data.(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("cannot select field from non-object (got %T)", data)
-	}
+func (a *ArrayIndex) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Implement array indexing
+	// TODO: Type assert to []interface{}, count negative indices from the end, check bounds, return element
+	panic("TODO")
+}
 
-	val, exists := m[f.Field]
-	if !exists {
-		return nil, nil
-	}
+func indexError(index, length int) error {
+	return fmt.Errorf("array index out of bounds: %d (length: %d)", index, length)
+}
 
-	return val, nil
+func (a *ArrayIterate) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Implement array iteration
+	// TODO: Emit each element of an array, or each value of an object, in a new slice
+	panic("TODO")
 }
 
-func (a *ArrayIndex) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement array indexing
-	// Hint: Type assert to []interface{}, check bounds, return element
-	arr, ok := data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("cannot index non-array (got %T)", data)
-	}
+func (p *Pipe) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Implement pipe operation
+	// TODO: Execute left, then execute right on each of its outputs
+	panic("TODO")
+}
+
+func (c *Comma) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Return the outputs of the left side followed by those of the right side, in a new slice
+	panic("TODO")
+}
+
+func (c *Collect) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Gather every output of the inner query into one new array
+	panic("TODO")
+}
+
+// Execute builds an object for every combination of the outputs of the
+// keys and values, so {name: .users[].name} emits one object per user.
+func (o *ObjectConstruct) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Build an object for every combination of key and value outputs
+	panic("TODO")
+}
+
+func (i *Identity) Execute(data interface{}) ([]interface{}, error) {
+	return one(data)
+}
+
+func (l *Literal) Execute(data interface{}) ([]interface{}, error) {
+	return one(l.Value)
+}
+
+func (n *Negate) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Negate every number Expr outputs; other values cannot be negated
+	panic("TODO")
+}
+
+// Execute compares every output of Left with every output of Right.
+func (c *Comparison) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Compare every pair of left and right outputs with compare
+	panic("TODO")
+}
+
+// Execute emits false for each false output of Left, and the truth of
+// Right's outputs for each true one.
+func (a *And) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Return false unless both sides are truthy, skipping the right side when the left is false
+	panic("TODO")
+}
+
+// Execute emits true for each true output of Left, and the truth of
+// Right's outputs for each false one.
+func (o *Or) Execute(data interface{}) ([]interface{}, error) {
+	// TODO: Return true if either side is truthy, skipping the right side when the left is true
+	panic("TODO")
+}
+
+// one returns v as the only output.
+func one(v interface{}) ([]interface{}, error) {
+	return []interface{}{v}, nil
+}
 
-	if a.Index < 0 || a.Index >= len(arr) {
-		return nil, fmt.Errorf("array index out of bounds: %d (length: %d)", a.Index, len(arr))
+// product applies op to the outputs of left and right on data, for every
+// output of right and then every output of left, as jq does.
+func product(data interface{}, left, right QueryNode, op func(l, r interface{}) (interface{}, error)) ([]interface{}, error) {
+	lefts, err := left.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := right.Execute(data)
+	if err != nil {
+		return nil, err
 	}
 
-	return arr[a.Index], nil
+	results := make([]interface{}, 0, len(lefts)*len(rights))
+	for _, r := range rights {
+		for _, l := range lefts {
+			result, err := op(l, r)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
 }
 
-func (a *ArrayIterate) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement array iteration
-	// Hint: Type assert to []interface{}, return as-is or process each element
-	arr, ok := data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("cannot iterate over non-array (got %T)", data)
+// shortCircuit runs and (stop false) and or (stop true): an output of left
+// with the truth stop is emitted as is, right is only run for the others.
+func shortCircuit(data interface{}, left, right QueryNode, stop bool) ([]interface{}, error) {
+	lefts, err := left.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	for _, l := range lefts {
+		if truthy(l) == stop {
+			results = append(results, stop)
+			continue
+		}
+		rights, err := right.Execute(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rights {
+			results = append(results, truthy(r))
+		}
 	}
+	return results, nil
+}
 
-	return arr, nil
+// truthy reports whether v counts as true in a condition: everything but
+// false and null does.
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
 }
 
-func (l *LengthOp) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement length operation
-	// Hint: Handle arrays, maps, strings
-	switch v := data.(type) {
-	case []interface{}:
-		return len(v), nil
-	case map[string]interface{}:
-		return len(v), nil
+// typeOrder ranks JSON values by type, the order jq sorts them in.
+func typeOrder(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if !v {
+			return 1
+		}
+		return 2
+	case float64, int:
+		return 3
 	case string:
-		return len(v), nil
+		return 4
+	case []interface{}:
+		return 5
 	default:
-		return nil, fmt.Errorf("cannot get length of %T", data)
+		return 6
 	}
 }
 
-func (p *Pipe) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement pipe operation
-	// Hint: Execute left, then execute right on result
-	result, err := p.Left.Execute(data)
-	if err != nil {
-		return nil, err
-	}
+// compare returns -1, 0 or 1 as a is less than, equal to or greater than b.
+// Values of different types compare by type: null < false < true < numbers
+// < strings < arrays < objects. Arrays compare element by element, objects
+// by their sorted keys and then their values.
+func compare(a, b interface{}) int {
+	// TODO: Order values by type first, then numbers by value, strings, arrays and objects by content
+	panic("TODO")
+}
 
-	return p.Right.Execute(result)
+// toFloat converts a number, decoded as float64 or counted as int, to float64.
+func toFloat(v interface{}) float64 {
+	if n, ok := v.(int); ok {
+		return float64(n)
+	}
+	return v.(float64)
 }
@@ -0,0 +1,170 @@
+package query
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+type TokenType int
+
+const (
+	EOF       TokenType = iota
+	DOT                 // .
+	FIELD               // .name
+	IDENT               // length, select, and, or, true, ...
+	NUMBER              // 42, 1.5
+	STRING              // "text"
+	LBRACKET            // [
+	RBRACKET            // ]
+	LPAREN              // (
+	RPAREN              // )
+	LBRACE              // {
+	RBRACE              // }
+	COLON               // :
+	COMMA               // ,
+	PIPE                // |
+	SEMICOLON           // ;
+	EQ                  // ==
+	NEQ                 // !=
+	LT                  // <
+	LTE                 // <=
+	GT                  // >
+	GTE                 // >=
+	MINUS               // -
+)
+
+var tokenNames = map[TokenType]string{
+	EOF:       "end of query",
+	DOT:       "'.'",
+	FIELD:     "field",
+	IDENT:     "identifier",
+	NUMBER:    "number",
+	STRING:    "string",
+	LBRACKET:  "'['",
+	RBRACKET:  "']'",
+	LPAREN:    "'('",
+	RPAREN:    "')'",
+	LBRACE:    "'{'",
+	RBRACE:    "'}'",
+	COLON:     "':'",
+	COMMA:     "','",
+	PIPE:      "'|'",
+	SEMICOLON: "';'",
+	EQ:        "'=='",
+	NEQ:       "'!='",
+	LT:        "'<'",
+	LTE:       "'<='",
+	GT:        "'>'",
+	GTE:       "'>='",
+	MINUS:     "'-'",
+}
+
+func (t TokenType) String() string {
+	return tokenNames[t]
+}
+
+// Token is a lexical token of a query. Pos is its byte offset in the query;
+// Value holds the field name, identifier, number or decoded string.
+type Token struct {
+	Type  TokenType
+	Value string
+	Pos   int
+}
+
+func (t Token) String() string {
+	switch t.Type {
+	case FIELD:
+		return fmt.Sprintf("field %q", "."+t.Value)
+	case IDENT, NUMBER:
+		return fmt.Sprintf("%s %q", t.Type, t.Value)
+	case STRING:
+		return fmt.Sprintf("string %q", t.Value)
+	default:
+		return t.Type.String()
+	}
+}
+
+// SyntaxError describes an invalid query and where in it the problem is.
+type SyntaxError struct {
+	Msg    string
+	Column int // 1-based, in characters
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Msg, e.Column)
+}
+
+// errorAt returns a SyntaxError for the byte offset pos of query.
+func errorAt(query string, pos int, format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{
+		Msg:    fmt.Sprintf(format, args...),
+		Column: utf8.RuneCountInString(query[:pos]) + 1,
+	}
+}
+
+// Lex splits a query into tokens, ending with an EOF token.
+func Lex(query string) ([]Token, error) {
+	// TODO: Scan the query byte by byte into tokens: skip spaces, read numbers, strings, identifiers and operators
+	// TODO: Report a SyntaxError at the column of anything else, and end with an EOF token
+	panic("TODO")
+}
+
+// operator returns the operator or punctuation s starts with and its length,
+// or a length of 0 if there is none.
+func operator(s string) (TokenType, int) {
+	if len(s) >= 2 {
+		switch s[:2] {
+		case "==":
+			return EQ, 2
+		case "!=":
+			return NEQ, 2
+		case "<=":
+			return LTE, 2
+		case ">=":
+			return GTE, 2
+		}
+	}
+	switch s[0] {
+	case '[':
+		return LBRACKET, 1
+	case ']':
+		return RBRACKET, 1
+	case '(':
+		return LPAREN, 1
+	case ')':
+		return RPAREN, 1
+	case '{':
+		return LBRACE, 1
+	case '}':
+		return RBRACE, 1
+	case ':':
+		return COLON, 1
+	case ',':
+		return COMMA, 1
+	case '|':
+		return PIPE, 1
+	case ';':
+		return SEMICOLON, 1
+	case '<':
+		return LT, 1
+	case '>':
+		return GT, 1
+	case '-':
+		return MINUS, 1
+	}
+	return EOF, 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentChar reports whether c can be part of an identifier; digits cannot
+// start one.
+func isIdentChar(c byte, first bool) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || !first && isDigit(c)
+}
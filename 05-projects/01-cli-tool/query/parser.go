@@ -2,25 +2,27 @@ package query
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
-	"unicode"
 )
 
 type Query struct {
-	nodes []QueryNode
+	root QueryNode
 }
 
+// QueryNode is a filter: it turns one input into zero or more outputs, each
+// of which the next filter of a pipe runs on.
 type QueryNode interface {
-	Execute(data interface{}) (interface{}, error)
+	Execute(data interface{}) ([]interface{}, error)
 }
 
+// Identity represents .
+type Identity struct{}
+
 // FieldSelect represents .field
 type FieldSelect struct {
 	Field string
 }
 
-// ArrayIndex represents [0]
+// ArrayIndex represents [0]; a negative index counts from the end, as in [-1]
 type ArrayIndex struct {
 	Index int
 }
@@ -33,94 +35,216 @@ type Pipe struct {
 	Left, Right QueryNode
 }
 
-// LengthOp represents length
-type LengthOp struct{}
+// Comma represents , : the outputs of Left, then those of Right
+type Comma struct {
+	Left, Right QueryNode
+}
+
+// Negate represents -expr, the negation of every output of Expr
+type Negate struct {
+	Expr QueryNode
+}
+
+// Literal represents a number, string, true, false or null
+type Literal struct {
+	Value interface{}
+}
+
+// Comparison represents ==, !=, <, <=, > and >=
+type Comparison struct {
+	Op          TokenType
+	Left, Right QueryNode
+}
+
+// And represents and
+type And struct {
+	Left, Right QueryNode
+}
+
+// Or represents or
+type Or struct {
+	Left, Right QueryNode
+}
+
+// Collect represents [expr], an array of all the outputs of Expr. Expr is
+// nil for [].
+type Collect struct {
+	Expr QueryNode
+}
+
+// ObjectEntry is a key: value pair of an object construction. Key is a
+// Literal for name: and "name":, any expression for (expr):.
+type ObjectEntry struct {
+	Key, Value QueryNode
+}
+
+// ObjectConstruct represents {key: value, ...}
+type ObjectConstruct struct {
+	Entries []ObjectEntry
+}
+
+// Call represents a builtin function call: length, select(cond), ...
+type Call struct {
+	Name string
+	Args []QueryNode
+}
 
 func Parse(queryStr string) (*Query, error) {
-	queryStr = strings.TrimSpace(queryStr)
-	if queryStr == "" {
-		return nil, fmt.Errorf("empty query")
+	// TODO: Lex the query, parse one expression and reject any tokens left before EOF
+	panic("TODO")
+}
+
+// parser is a Pratt parser: parseExpression handles the binary operators by
+// binding power, parsePostfix the paths they combine.
+type parser struct {
+	query  string
+	tokens []Token
+	pos    int
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	tok := p.tokens[p.pos]
+	if tok.Type != EOF {
+		p.pos++
 	}
+	return tok
+}
 
-	// TODO: Implement query parser
-	// Hint: Tokenize, then build AST
-	// For now, handle basic cases
-	
-	nodes, err := parseExpression(queryStr)
-	if err != nil {
-		return nil, err
+func (p *parser) expect(typ TokenType) (Token, error) {
+	tok := p.next()
+	if tok.Type != typ {
+		return tok, p.errorAt(tok, "expected %s, got %s", typ, tok)
 	}
+	return tok, nil
+}
 
-	return &Query{nodes: nodes}, nil
-}
-
-func parseExpression(expr string) ([]QueryNode, error) {
-	// TODO: Implement full parser
-	// This is a simplified version for basic cases
-	
-	var nodes []QueryNode
-	i := 0
-
-	for i < len(expr) {
-		if expr[i] == '.' {
-			i++
-			// Parse field name
-			end := i
-			for end < len(expr) && (unicode.IsLetter(rune(expr[end])) || unicode.IsDigit(rune(expr[end])) || expr[end] == '_') {
-				end++
-			}
-			if end > i {
-				nodes = append(nodes, &FieldSelect{Field: expr[i:end]})
-				i = end
-			}
-		} else if expr[i] == '[' {
-			i++
-			if i < len(expr) && expr[i] == ']' {
-				// Array iterate
-				nodes = append(nodes, &ArrayIterate{})
-				i++
-			} else {
-				// Array index
-				end := i
-				for end < len(expr) && unicode.IsDigit(rune(expr[end])) {
-					end++
-				}
-				if end > i {
-					index, err := strconv.Atoi(expr[i:end])
-					if err != nil {
-						return nil, fmt.Errorf("invalid array index: %s", expr[i:end])
-					}
-					nodes = append(nodes, &ArrayIndex{Index: index})
-					i = end
-					if i < len(expr) && expr[i] == ']' {
-						i++
-					}
-				}
-			}
-		} else if expr[i] == ' ' {
-			i++
-		} else {
-			// Check for "length"
-			if strings.HasPrefix(expr[i:], "length") {
-				nodes = append(nodes, &LengthOp{})
-				i += 6
-			} else {
-				return nil, fmt.Errorf("unexpected character at position %d: %c", i, expr[i])
-			}
+func (p *parser) errorAt(tok Token, format string, args ...interface{}) error {
+	return errorAt(p.query, tok.Pos, format, args...)
+}
+
+func (p *parser) unexpected(tok Token) error {
+	return p.errorAt(tok, "unexpected %s", tok)
+}
+
+// Binding powers of the binary operators: higher binds tighter.
+const (
+	pipePower = iota + 1
+	commaPower
+	orPower
+	andPower
+	comparePower
+)
+
+// infix returns the binding power of the binary operator tok, or 0 if tok
+// is not one. Pipes are right-associative and comparisons cannot be
+// chained.
+func infix(tok Token) int {
+	switch tok.Type {
+	case PIPE:
+		return pipePower
+	case COMMA:
+		return commaPower
+	case IDENT:
+		switch tok.Value {
+		case "or":
+			return orPower
+		case "and":
+			return andPower
 		}
+	case EQ, NEQ, LT, LTE, GT, GTE:
+		return comparePower
 	}
+	return 0
+}
+
+// parseExpression parses operators binding tighter than minPower.
+func (p *parser) parseExpression(minPower int) (QueryNode, error) {
+	// TODO: Parse a postfix path, then fold in infix operators while they bind tighter than minPower
+	panic("TODO")
+}
+
+// parsePostfix parses a term followed by any number of .field, [n] and []
+// suffixes, each piped into the next.
+func (p *parser) parsePostfix() (QueryNode, error) {
+	// TODO: Parse a term, then pipe it into each .field, [n] or [] suffix that follows
+	panic("TODO")
+}
+
+// parseTerm parses a path start, literal, function call, construction or
+// parenthesized expression.
+func (p *parser) parseTerm() (QueryNode, error) {
+	// TODO: Switch on the next token: a path start, a literal, a function, [...], {...} or (...)
+	panic("TODO")
+}
+
+// parseObject parses the entries of an object construction after its {.
+// Values bind tighter than commas, which separate the entries: {a: .b | .c}
+// needs parentheses around the pipe.
+func (p *parser) parseObject() (QueryNode, error) {
+	// TODO: Parse entries separated by commas up to the closing }
+	panic("TODO")
+}
+
+// parseAfterDot parses what may follow a lone dot: "key" or brackets.
+func (p *parser) parseAfterDot() (QueryNode, error) {
+	// TODO: A lone dot is followed by a "key", brackets or nothing (the identity)
+	panic("TODO")
+}
 
-	return nodes, nil
+// parseBrackets parses [], [n], [-n] and ["key"].
+func (p *parser) parseBrackets() (QueryNode, error) {
+	// TODO: Return [] as is, otherwise parse the expression up to the ] and require an integer or string literal
+	panic("TODO")
 }
 
+// parseFunction parses a literal name or a call of a builtin function, with
+// its arguments separated by semicolons.
+func (p *parser) parseFunction(name Token) (QueryNode, error) {
+	// TODO: Look the name up in builtins, then parse its arguments, separated by semicolons, and check their number
+	panic("TODO")
+}
+
+// plural returns "" for one and "s" otherwise.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// parseObjectEntry parses name: value, "name": value, (key): value or the
+// shorthand name.
+func (p *parser) parseObjectEntry() (ObjectEntry, error) {
+	// TODO: Parse the key, then an optional colon and value; a bare name is short for name: .name
+	panic("TODO")
+}
+
+// pipe chains two nodes, dropping identities.
+func pipe(left, right QueryNode) QueryNode {
+	if _, ok := left.(*Identity); ok {
+		return right
+	}
+	return &Pipe{Left: left, Right: right}
+}
+
+// Run returns every output of the query on data, in order.
+func (q *Query) Run(data interface{}) ([]interface{}, error) {
+	return q.root.Execute(data)
+}
+
+// Execute runs a query that produces a single value, such as a path without
+// [], and returns that value.
 func (q *Query) Execute(data interface{}) (interface{}, error) {
-	result := data
-	for _, node := range q.nodes {
-		var err error
-		result, err = node.Execute(result)
-		if err != nil {
-			return nil, err
-		}
+	results, err := q.Run(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("query produced %d results, expected 1", len(results))
 	}
-	return result, nil
+	return results[0], nil
 }
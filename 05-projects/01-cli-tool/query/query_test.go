@@ -0,0 +1,621 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  QueryNode
+	}{
+		{
+			name:  "identity",
+			query: ".",
+			want:  &Identity{},
+		},
+		{
+			name:  "path",
+			query: ".users[0].name",
+			want: &Pipe{
+				Left:  &Pipe{Left: &FieldSelect{Field: "users"}, Right: &ArrayIndex{Index: 0}},
+				Right: &FieldSelect{Field: "name"},
+			},
+		},
+		{
+			name:  "quoted keys",
+			query: `."first name" | .["last name"]`,
+			want:  &Pipe{Left: &FieldSelect{Field: "first name"}, Right: &FieldSelect{Field: "last name"}},
+		},
+		{
+			name:  "pipes group to the right",
+			query: ".a | .b | length",
+			want: &Pipe{
+				Left:  &FieldSelect{Field: "a"},
+				Right: &Pipe{Left: &FieldSelect{Field: "b"}, Right: &Call{Name: "length"}},
+			},
+		},
+		{
+			name:  "select",
+			query: ".users[] | select(.age > 18)",
+			want: &Pipe{
+				Left: &Pipe{Left: &FieldSelect{Field: "users"}, Right: &ArrayIterate{}},
+				Right: &Call{Name: "select", Args: []QueryNode{&Comparison{
+					Op:    GT,
+					Left:  &FieldSelect{Field: "age"},
+					Right: &Literal{Value: 18.0},
+				}}},
+			},
+		},
+		{
+			name:  "and binds tighter than or",
+			query: `.a or .b and .c == "x"`,
+			want: &Or{
+				Left: &FieldSelect{Field: "a"},
+				Right: &And{
+					Left:  &FieldSelect{Field: "b"},
+					Right: &Comparison{Op: EQ, Left: &FieldSelect{Field: "c"}, Right: &Literal{Value: "x"}},
+				},
+			},
+		},
+		{
+			name:  "parentheses",
+			query: "(.a or .b) and (.c | not)",
+			want: &And{
+				Left:  &Or{Left: &FieldSelect{Field: "a"}, Right: &FieldSelect{Field: "b"}},
+				Right: &Pipe{Left: &FieldSelect{Field: "c"}, Right: &Call{Name: "not"}},
+			},
+		},
+		{
+			name:  "comma binds tighter than pipe",
+			query: ".a, .b | length",
+			want: &Pipe{
+				Left:  &Comma{Left: &FieldSelect{Field: "a"}, Right: &FieldSelect{Field: "b"}},
+				Right: &Call{Name: "length"},
+			},
+		},
+		{
+			name:  "array construction",
+			query: "[.users[].email][0]",
+			want: &Pipe{
+				Left: &Collect{Expr: &Pipe{
+					Left:  &Pipe{Left: &FieldSelect{Field: "users"}, Right: &ArrayIterate{}},
+					Right: &FieldSelect{Field: "email"},
+				}},
+				Right: &ArrayIndex{Index: 0},
+			},
+		},
+		{
+			name:  "empty array",
+			query: "[]",
+			want:  &Collect{},
+		},
+		{
+			name:  "object construction",
+			query: `{name, "full name": .first, (.key): .a == 1, age: .age}`,
+			want: &ObjectConstruct{Entries: []ObjectEntry{
+				{Key: &Literal{Value: "name"}, Value: &FieldSelect{Field: "name"}},
+				{Key: &Literal{Value: "full name"}, Value: &FieldSelect{Field: "first"}},
+				{Key: &FieldSelect{Field: "key"}, Value: &Comparison{Op: EQ, Left: &FieldSelect{Field: "a"}, Right: &Literal{Value: 1.0}}},
+				{Key: &Literal{Value: "age"}, Value: &FieldSelect{Field: "age"}},
+			}},
+		},
+		{
+			name:  "empty object",
+			query: "{}",
+			want:  &ObjectConstruct{},
+		},
+		{
+			name:  "negative index",
+			query: ".a[-1]",
+			want:  &Pipe{Left: &FieldSelect{Field: "a"}, Right: &ArrayIndex{Index: -1}},
+		},
+		{
+			name:  "negation binds tighter than comparisons",
+			query: "-.a < - 2",
+			want:  &Comparison{Op: LT, Left: &Negate{Expr: &FieldSelect{Field: "a"}}, Right: &Literal{Value: -2.0}},
+		},
+		{
+			name:  "JSON escapes",
+			query: `"\/\u00e9\n"`,
+			want:  &Literal{Value: "/é\n"},
+		},
+		{
+			name:  "literals",
+			query: "null != -1.5e2",
+			want:  &Comparison{Op: NEQ, Left: &Literal{Value: nil}, Right: &Literal{Value: -150.0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, q.root)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		want   string
+		column int
+	}{
+		{"juxtaposed", ".items length", `unexpected identifier "length"`, 8},
+		{"unclosed bracket", ".users[0", "expected ']', got end of query", 9},
+		{"bad index", ".users[.x]", "index must be a number or a string", 8},
+		{"fractional index", ".users[-1.5]", "invalid array index -1.5", 8},
+		{"Go escape", `.a == "\x41"`, `invalid string "\x41"`, 7},
+		{"missing operand", ".age > ", "unexpected end of query", 7},
+		{"chained comparison", ".a < .b < .c", "comparisons cannot be chained, use and", 9},
+		{"unknown function", ".a | frobnicate", `unknown function "frobnicate"`, 6},
+		{"missing argument", ".users | select .a", "select takes 1 argument, got 0", 10},
+		{"extra argument", "keys(.a)", "keys takes 0 arguments, got 1", 1},
+		{"unclosed arguments", "map(.a; .b", "expected ')', got end of query", 11},
+		{"unexpected character", ".a = 1", "unexpected character '='", 4},
+		{"unterminated string", `.a == "x`, "unterminated string", 7},
+		{"object without colon", "{name .name}", `expected ':', got field ".name"`, 7},
+		{"object with a number key", "{1: .a}", `expected an object key, got number "1"`, 2},
+		{"object value with a pipe", "{a: .b | .c}", `expected ',' or '}', got '|'`, 8},
+		{"unclosed array", "[.a", "expected ']', got end of query", 4},
+		{"column in characters", `"é" == .a)`, "unexpected ')'", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.want, syntaxErr.Msg)
+			assert.Equal(t, tt.column, syntaxErr.Column)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	input := `{"users": [{"name": "Alice", "age": 30, "admin": true}, {"name": "Bob", "age": 17}], "count": 2}`
+	alice := map[string]interface{}{"name": "Alice", "age": 30.0, "admin": true}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []interface{}
+	}{
+		{"path", ".users[1].name", []interface{}{"Bob"}},
+		{"iterate", ".users[].name", []interface{}{"Alice", "Bob"}},
+		{"iterate an object", ".users[1][]", []interface{}{17.0, "Bob"}},
+		{"select keeps matches", ".users[] | select(.age > 18)", []interface{}{alice}},
+		{"select drops the rest", ".users[] | select(.age > 40)", nil},
+		{"select per output", ".users | select(.[].age > 10) | length", []interface{}{2, 2}},
+		{"comparisons run per output", ".users[].age > 18", []interface{}{true, false}},
+		{"both sides iterate", ".users[].age < .users[].age", []interface{}{false, true, false, false}},
+		{"length compares with numbers", "(.users | length) == .count", []interface{}{true}},
+		{"strings", `.users[1].name < "Carol"`, []interface{}{true}},
+		{"types order", `null < false and false < 0 and 0 < "" and "" < .users | not`, []interface{}{false}},
+		{"missing fields are null", ".users[1].admin == null", []interface{}{true}},
+		{"fields of null are null", ".missing.x", []interface{}{nil}},
+		{"fields of missing objects are null", ".users[].address.city", []interface{}{nil, nil}},
+		{"and", ".users[] | .admin and .age >= 30", []interface{}{true, false}},
+		{"or short-circuits", ".count or .users[5]", []interface{}{true}},
+		{"or per output", ".users[].admin or .count", []interface{}{true, true}},
+		{"not", ".users[1].admin | not", []interface{}{true}},
+		{"negative index", ".users[-1].name", []interface{}{"Bob"}},
+		{"negative index from the start", `.users[-2]["name"]`, []interface{}{"Alice"}},
+		{"negation", "-.users[].age", []interface{}{-30.0, -17.0}},
+	}
+
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(input), &data))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			got, err := q.Run(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	data := map[string]interface{}{"users": []interface{}{"Alice", "Bob"}}
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{".users[-3]", "array index out of bounds: -3 (length: 2)"},
+		{"-.users[0]", "cannot negate string"},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		require.NoError(t, err)
+		_, err = q.Run(data)
+		assert.EqualError(t, err, tt.want, tt.query)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	data := map[string]interface{}{"users": []interface{}{"Alice", "Bob"}}
+
+	q, err := Parse(".users[0]")
+	require.NoError(t, err)
+	got, err := q.Execute(data)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", got)
+
+	q, err = Parse(".users[]")
+	require.NoError(t, err)
+	_, err = q.Execute(data)
+	assert.EqualError(t, err, "query produced 2 results, expected 1")
+}
+
+func TestConstruction(t *testing.T) {
+	input := `{"users": [{"name": "Alice", "email": "a@example.com"}, {"name": "Bob", "email": "b@example.com"}], "key": "id", "id": 7}`
+
+	tests := []struct {
+		name  string
+		query string
+		want  []interface{}
+	}{
+		{"comma", ".id, .key", []interface{}{7.0, "id"}},
+		{"collect", "[.users[].email]", []interface{}{[]interface{}{"a@example.com", "b@example.com"}}},
+		{"collect nothing", "[.users[] | select(false)]", []interface{}{[]interface{}{}}},
+		{"empty array", "[]", []interface{}{[]interface{}{}}},
+		{"collect several", "[.id, .key]", []interface{}{[]interface{}{7.0, "id"}}},
+		{"object", `.users[0] | {name: .name, "mail": .email}`, []interface{}{
+			map[string]interface{}{"name": "Alice", "mail": "a@example.com"},
+		}},
+		{"shorthand", ".users[] | {name}", []interface{}{
+			map[string]interface{}{"name": "Alice"},
+			map[string]interface{}{"name": "Bob"},
+		}},
+		{"computed key", "{(.key): .id}", []interface{}{map[string]interface{}{"id": 7.0}}},
+		{"one object per value", "{name: .users[].name}", []interface{}{
+			map[string]interface{}{"name": "Alice"},
+			map[string]interface{}{"name": "Bob"},
+		}},
+		{"one object per key", "{(.key, \"n\"): 1}", []interface{}{
+			map[string]interface{}{"id": 1.0},
+			map[string]interface{}{"n": 1.0},
+		}},
+		{"nested", "{people: [.users[] | {name}], count: (.users | length)}", []interface{}{
+			map[string]interface{}{
+				"people": []interface{}{map[string]interface{}{"name": "Alice"}, map[string]interface{}{"name": "Bob"}},
+				"count":  2,
+			},
+		}},
+		{"sort by several keys", "[(.users[] | {name}), {name: \"Ann\"}] | sort_by(.name, .id) | map(.name)", []interface{}{
+			[]interface{}{"Alice", "Ann", "Bob"},
+		}},
+	}
+
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(input), &data))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			got, err := q.Run(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	q, err := Parse("{(.id): 1}")
+	require.NoError(t, err)
+	_, err = q.Run(data)
+	assert.EqualError(t, err, "object keys must be strings, got float64")
+}
+
+func TestOutputsDoNotShareInput(t *testing.T) {
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(`[1, 2, 3]`), &data))
+
+	// Appending to the outputs of .[] must not overwrite the input array
+	q, err := Parse("[(.[], 10), (.[], 20)]")
+	require.NoError(t, err)
+	got, err := q.Run(data)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{1.0, 2.0, 3.0, 10.0, 1.0, 2.0, 3.0, 20.0}}, got)
+
+	q, err = Parse("[.[]], .")
+	require.NoError(t, err)
+	got, err = q.Run(data)
+	require.NoError(t, err)
+	got[0].([]interface{})[0] = "changed"
+	assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, data)
+}
+
+func TestBuiltins(t *testing.T) {
+	input := `{"users": [
+		{"name": "Carol", "age": 35, "team": "a"},
+		{"name": "Alice", "age": 30, "team": "b"},
+		{"name": "Bob", "age": 30, "team": "a"}
+	], "tags": ["x", null, "y"], "scores": [3, 4.5], "id": "42", "html": {"a": "<b>&</b>"}, "city": "Zürich"}`
+
+	tests := []struct {
+		name  string
+		query string
+		want  []interface{}
+	}{
+		{"length of null", ".missing | length", []interface{}{0}},
+		{"map", ".users | map(.name)", []interface{}{[]interface{}{"Carol", "Alice", "Bob"}}},
+		{"map an object", ".users[0] | map(tostring)", []interface{}{[]interface{}{"35", "Carol", "a"}}},
+		{"sort_by is stable", ".users | sort_by(.age) | map(.name)", []interface{}{[]interface{}{"Alice", "Bob", "Carol"}}},
+		{"group_by", ".users | group_by(.team) | map(map(.name))", []interface{}{[]interface{}{
+			[]interface{}{"Carol", "Bob"},
+			[]interface{}{"Alice"},
+		}}},
+		{"unique_by", ".users | unique_by(.age) | map(.name)", []interface{}{[]interface{}{"Alice", "Carol"}}},
+		{"min_by", ".users | min_by(.age) | .name", []interface{}{"Alice"}},
+		{"max_by", ".users | max_by(.age) | .name", []interface{}{"Carol"}},
+		{"min_by of nothing", ".tags | map(select(false)) | min_by(.)", []interface{}{nil}},
+		{"keys", ".users[0] | keys", []interface{}{[]interface{}{"age", "name", "team"}}},
+		{"keys of an array", ".scores | keys", []interface{}{[]interface{}{0, 1}}},
+		{"values", ".tags[] | values", []interface{}{"x", "y"}},
+		{"has a key", `.users[0] | has("age")`, []interface{}{true}},
+		{"has an index", ".scores | has(2)", []interface{}{false}},
+		{"add numbers", ".scores | add", []interface{}{7.5}},
+		{"add strings", ".tags | add", []interface{}{"xy"}},
+		{"add arrays", ".users | map(keys) | add | length", []interface{}{9}},
+		{"add nothing", ".tags | map(select(false)) | add", []interface{}{nil}},
+		{"to_entries", `.users[0] | to_entries | map(.key)`, []interface{}{[]interface{}{"age", "name", "team"}}},
+		{"from_entries", `.users[0] | to_entries | from_entries | .name`, []interface{}{"Carol"}},
+		{"tostring", ".scores | tostring", []interface{}{"[3,4.5]"}},
+		{"tostring keeps strings", ".id | tostring", []interface{}{"42"}},
+		{"tostring does not escape HTML", ".html | tostring", []interface{}{`{"a":"<b>&</b>"}`}},
+		{"length of a string counts runes", ".city | length", []interface{}{6}},
+		{".length of a string counts runes", ".city.length", []interface{}{6}},
+		{"tonumber", ".id | tonumber", []interface{}{42.0}},
+		{"tonumber keeps numbers", ".scores[1] | tonumber", []interface{}{4.5}},
+	}
+
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(input), &data))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			got, err := q.Run(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHasTruncatesIndex(t *testing.T) {
+	// jq truncates fractional indices toward zero
+	tests := []struct {
+		index float64
+		want  bool
+	}{
+		{-1, false},
+		{-0.5, true},
+		{1.5, true},
+		{2, false},
+		{2.5, false},
+	}
+	for _, tt := range tests {
+		call := &Call{Name: "has", Args: []QueryNode{&Literal{Value: tt.index}}}
+		got, err := call.Execute([]interface{}{"a", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{tt.want}, got, "has(%v)", tt.index)
+	}
+}
+
+func TestBuiltinErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		input interface{}
+		want  string
+	}{
+		{"sort_by(.a)", "text", "sort_by requires an array, got string"},
+		{"keys", 1.0, "float64 has no keys"},
+		{`has("a")`, []interface{}{}, "cannot check whether an array has a string key"},
+		{"add", []interface{}{1.0, "a"}, "cannot add float64 and string"},
+		{"tonumber", "4x", `cannot parse "4x" as a number`},
+		{"from_entries", []interface{}{map[string]interface{}{"value": 1.0}}, "cannot use <nil> as an object key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			_, err = q.Run(tt.input)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+// stream runs a query with Stream on every value of input.
+func stream(q *Query, input string) ([]interface{}, error) {
+	var results []interface{}
+	dec := json.NewDecoder(strings.NewReader(input))
+	for {
+		err := q.Stream(dec, func(result interface{}) error {
+			results = append(results, result)
+			return nil
+		})
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	values := []string{
+		`{"users": [{"name": "Alice", "age": 30, "tags": ["a"]}, {"name": "Bob", "age": 17, "tags": []}], "meta": {"b": 2, "a": 1}}`,
+		`{"users": [], "meta": {}}`,
+		`{"meta": {"users": 1}, "users": [{"name": "Carol", "age": 41, "tags": ["b", "c"]}]}`,
+	}
+	input := strings.Join(values, "\n")
+
+	// Streaming must give the results of running the query on each value
+	queries := []string{
+		".",
+		".users",
+		".users[]",
+		".users[].name",
+		".meta.a",
+		".users[].tags[]",
+		".meta[]",
+		".missing",
+		".missing.x",
+		".users[].address.city",
+		".users[] | select(.age > 18) | {name}",
+		".users | length",
+		".users[].tags.length",
+		"[.users[].age] | add",
+		".meta | keys",
+		"-.users[].age",
+	}
+
+	for _, queryStr := range queries {
+		t.Run(queryStr, func(t *testing.T) {
+			q, err := Parse(queryStr)
+			require.NoError(t, err)
+
+			var want []interface{}
+			for _, v := range values {
+				var data interface{}
+				require.NoError(t, json.Unmarshal([]byte(v), &data))
+				results, err := q.Run(data)
+				require.NoError(t, err)
+				want = append(want, results...)
+			}
+
+			got, err := stream(q, input)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestStreamNegativeIndex(t *testing.T) {
+	q, err := Parse(".users[-1].name")
+	require.NoError(t, err)
+	got, err := stream(q, `{"users": [{"name": "Alice"}, {"name": "Bob"}]} {"users": [{"name": "Carol"}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"Bob", "Carol"}, got)
+}
+
+func TestStreamErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		input string
+		want  string
+	}{
+		{"index out of bounds", ".items[5]", `{"items": [1, 2]}`, "array index out of bounds: 5 (length: 2)"},
+		{"field of an array", ".name", `[1, 2, 3]`, "cannot select field from non-object (got []interface {})"},
+		{"index of an object", ".[0]", `{"name": "Alice"}`, "cannot index non-array (got map[string]interface {})"},
+		{"iterate a number", ".a[]", `{"a": 1}`, "cannot iterate over float64"},
+		{"field of a number", ".a.b.c", `{"a": 1}`, "cannot select field from non-object (got float64)"},
+		{"truncated", ".a[]", `{"a": [1,`, "unexpected end of JSON input"},
+		{"truncated skipped value", ".b", `{"a": {"x": 1`, io.ErrUnexpectedEOF.Error()},
+		{"invalid", ".a", `{"a" 1}`, "invalid character '1' after object key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			_, err = stream(q, tt.input)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestStreamOutputsEarly(t *testing.T) {
+	q, err := Parse(".[] | select(. > 1)")
+	require.NoError(t, err)
+
+	// The error comes after the first results, which are already out
+	got, err := stream(q, `[1, 2, 3, {]`)
+	assert.Equal(t, []interface{}{2.0, 3.0}, got)
+	assert.Error(t, err)
+}
+
+func TestStreamRepeatedKeys(t *testing.T) {
+	input := `{"a": {"b": 1}, "a": {"b": 2, "c": [true, null, "x", {"d": []}]}, "e": 3}`
+	queries := []string{".a.b", ".a", ".a.c[3].d", ".a.c[]", ".e"}
+
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(input), &data))
+
+	// A small limit moves the copied values to a temporary file
+	defer func(limit int) { spoolLimit = limit }(spoolLimit)
+	for _, limit := range []int{spoolLimit, 8} {
+		spoolLimit = limit
+
+		for _, queryStr := range queries {
+			t.Run(fmt.Sprintf("%s/%d", queryStr, limit), func(t *testing.T) {
+				q, err := Parse(queryStr)
+				require.NoError(t, err)
+
+				// The last value of a key counts, as with json.Unmarshal
+				want, err := q.Run(data)
+				require.NoError(t, err)
+				got, err := stream(q, input)
+				require.NoError(t, err)
+				assert.Equal(t, want, got)
+			})
+		}
+	}
+
+	q, err := Parse(".a.b")
+	require.NoError(t, err)
+	got, err := stream(q, `{"a":{"b":1},"a":{"b":2}}`)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2.0}, got)
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []interface{}{
+		nil,
+		false,
+		true,
+		-1.0,
+		2,
+		"a",
+		"b",
+		[]interface{}{1.0},
+		[]interface{}{1.0, 2.0},
+		map[string]interface{}{"a": 2.0},
+		map[string]interface{}{"b": 1.0},
+	}
+
+	for i, a := range ordered {
+		for j, b := range ordered {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			assert.Equal(t, want, compare(a, b), "compare(%v, %v)", a, b)
+		}
+	}
+	assert.Equal(t, 0, compare(3, 3.0))
+}
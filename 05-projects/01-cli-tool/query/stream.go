@@ -0,0 +1,232 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// Stream runs the query on the next JSON value of dec and calls emit with
+// each result. The path the query starts with, such as .users[] in
+// .users[] | select(.age > 18), is followed token by token: values off the
+// path are skipped without being decoded and the values on it are decoded
+// one at a time, so a huge document never has to fit in memory. The rest of
+// the query runs on each of them. Stream returns io.EOF when dec has no more
+// values.
+//
+// Iterating over an object decodes the whole object, as its values are
+// emitted in key order. The value of a key on the path is only followed once
+// its object ends, as a later repeat of the key replaces it, like with
+// json.Unmarshal: until then it is kept as JSON text, in a temporary file
+// once it outgrows spoolLimit.
+func (q *Query) Stream(dec *json.Decoder, emit func(result interface{}) error) error {
+	steps, rest := splitPath(q.root)
+	s := &streamer{dec: dec, rest: rest, emit: emit}
+	return s.walk(steps)
+}
+
+// splitPath splits a query into the .field, [n] and [] steps it starts with
+// and the rest, nil if there is none.
+func splitPath(root QueryNode) (steps []QueryNode, rest QueryNode) {
+	stages := flatten(root)
+	for i, stage := range stages {
+		switch step := stage.(type) {
+		case *Identity:
+			continue
+		case *FieldSelect:
+			if step.Field == "length" {
+				// .length measures arrays, which the stream never decodes
+				return steps, chain(stages[i:])
+			}
+		case *ArrayIndex:
+			if step.Index < 0 {
+				// Counting from the end needs the length of the array
+				return steps, chain(stages[i:])
+			}
+		case *ArrayIterate:
+		default:
+			return steps, chain(stages[i:])
+		}
+		steps = append(steps, stage)
+	}
+	return steps, nil
+}
+
+// flatten returns the filters a pipe runs one after the other.
+func flatten(node QueryNode) []QueryNode {
+	if p, ok := node.(*Pipe); ok {
+		return append(flatten(p.Left), flatten(p.Right)...)
+	}
+	return []QueryNode{node}
+}
+
+// chain pipes filters one after the other, nil for none.
+func chain(nodes []QueryNode) QueryNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	node := nodes[len(nodes)-1]
+	for i := len(nodes) - 2; i >= 0; i-- {
+		node = &Pipe{Left: nodes[i], Right: node}
+	}
+	return node
+}
+
+// streamer follows a path through the tokens of one JSON value.
+type streamer struct {
+	dec     *json.Decoder
+	rest    QueryNode
+	emit    func(interface{}) error
+	started bool // whether a token of the value was read
+}
+
+// token reads the next token. The end of the input is only io.EOF before
+// the value starts.
+func (s *streamer) token() (json.Token, error) {
+	tok, err := s.dec.Token()
+	if errors.Is(err, io.EOF) && s.started {
+		err = io.ErrUnexpectedEOF
+	}
+	s.started = true
+	return tok, err
+}
+
+// decode reads the next whole value.
+func (s *streamer) decode() (interface{}, error) {
+	var v interface{}
+	err := s.dec.Decode(&v)
+	if errors.Is(err, io.EOF) && s.started {
+		err = io.ErrUnexpectedEOF
+	}
+	s.started = true
+	return v, err
+}
+
+// walk follows steps from the next value of the decoder.
+func (s *streamer) walk(steps []QueryNode) error {
+	// TODO: Decode the value when no steps are left, otherwise follow the first step token by token
+	panic("TODO")
+}
+
+// field follows steps from the value of a key, after the { of an object. A
+// missing key is null, and a repeated key has its last value.
+func (s *streamer) field(name string, steps []QueryNode) error {
+	// TODO: Spool the value of the key, replaced by any later repeat, and walk it once the object ends
+	panic("TODO")
+}
+
+// index follows steps from an element, after the [ of an array.
+func (s *streamer) index(index int, steps []QueryNode) error {
+	// TODO: Skip the elements before the index, walk the element at it and skip the rest
+	panic("TODO")
+}
+
+// iterate follows steps from every element, after the [ of an array.
+func (s *streamer) iterate(steps []QueryNode) error {
+	// TODO: Walk every element until the closing ]
+	panic("TODO")
+}
+
+// iterateObject decodes an object after its { and runs steps on its values,
+// in the order [] emits them.
+func (s *streamer) iterateObject(steps []QueryNode) error {
+	// TODO: Decode the rest of the object and run the steps on its values in key order
+	panic("TODO")
+}
+
+// copy writes the next value to w as JSON text, reading it token by token.
+func (s *streamer) copy(w io.Writer) error {
+	// TODO: Re-encode the tokens of the next value, keeping track of commas and colons
+	panic("TODO")
+}
+
+// spoolLimit is the size up to which a spool keeps its text in memory. It
+// is a variable for the tests.
+var spoolLimit = 1 << 20
+
+// spool holds the JSON text of a value in memory, or in a temporary file
+// once it outgrows spoolLimit, so a huge value need not fit in memory.
+type spool struct {
+	buf  bytes.Buffer
+	file *os.File
+}
+
+func (sp *spool) Write(p []byte) (int, error) {
+	if sp.file == nil && sp.buf.Len()+len(p) > spoolLimit {
+		file, err := os.CreateTemp("", "jq-stream-*.json")
+		if err != nil {
+			return 0, err
+		}
+		sp.file = file
+		if _, err := sp.buf.WriteTo(file); err != nil {
+			return 0, err
+		}
+	}
+	if sp.file != nil {
+		return sp.file.Write(p)
+	}
+	return sp.buf.Write(p)
+}
+
+// reader returns a reader of the text written to the spool.
+func (sp *spool) reader() (io.Reader, error) {
+	if sp.file == nil {
+		return &sp.buf, nil
+	}
+	if _, err := sp.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return sp.file, nil
+}
+
+// Close removes the temporary file, if any.
+func (sp *spool) Close() error {
+	if sp.file == nil {
+		return nil
+	}
+	sp.file.Close()
+	return os.Remove(sp.file.Name())
+}
+
+// skip reads past the next value without decoding it.
+func (s *streamer) skip() error {
+	// TODO: Read tokens until the value that started ends
+	panic("TODO")
+}
+
+// run executes steps on a decoded value and outputs the results.
+func (s *streamer) run(v interface{}, steps []QueryNode) error {
+	results := []interface{}{v}
+	if node := chain(steps); node != nil {
+		var err error
+		if results, err = node.Execute(v); err != nil {
+			return err
+		}
+	}
+	for _, result := range results {
+		if err := s.output(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// output runs the rest of the query on a value the path selected and emits
+// the results.
+func (s *streamer) output(v interface{}) error {
+	if s.rest == nil {
+		return s.emit(v)
+	}
+	results, err := s.rest.Execute(v)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := s.emit(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
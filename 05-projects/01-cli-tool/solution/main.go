@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -16,10 +17,12 @@ var (
 	compact = flag.Bool("c", false, "compact output")
 	table   = flag.Bool("t", false, "table output")
 	raw     = flag.Bool("r", false, "raw output (no quotes)")
+	slurp   = flag.Bool("slurp", false, "run the query once on an array of all input values")
 	showVer = flag.Bool("v", false, "show version")
 )
 
 func main() {
+	flag.BoolVar(slurp, "s", false, "run the query once on an array of all input values")
 	flag.Usage = usage
 	flag.Parse()
 
@@ -43,8 +46,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	// With -s the values of every input are gathered into one array, which
+	// the query runs on once all inputs are read
+	slurped := []interface{}{}
+	process := func(r io.Reader, filename string) error {
+		if !*slurp {
+			return processInput(r, q, filename)
+		}
+		var err error
+		slurped, err = appendValues(slurped, json.NewDecoder(r))
+		return err
+	}
+
 	if len(files) == 0 {
-		if err := processInput(os.Stdin, q, "stdin"); err != nil {
+		if err := process(os.Stdin, "stdin"); err != nil {
 			fmt.Fprintf(os.Stderr, "error: %v\n", err)
 			os.Exit(1)
 		}
@@ -55,7 +70,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "error opening %s: %v\n", filename, err)
 				os.Exit(1)
 			}
-			if err := processInput(f, q, filename); err != nil {
+			if err := process(f, filename); err != nil {
 				f.Close()
 				fmt.Fprintf(os.Stderr, "error processing %s: %v\n", filename, err)
 				os.Exit(1)
@@ -63,22 +78,88 @@ func main() {
 			f.Close()
 		}
 	}
+
+	if *slurp {
+		if err := runQuery(q, []interface{}{slurped}); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
 }
 
+//starter:todo Run the query on every JSON value of the input, streaming its results unless -t needs all values first
 func processInput(r io.Reader, q *query.Query, filename string) error {
-	var data interface{}
-	if err := json.NewDecoder(r).Decode(&data); err != nil {
-		return fmt.Errorf("parsing JSON: %w", err)
+	dec := json.NewDecoder(r)
+
+	// Output each result as soon as it is found, value after value
+	if !*table {
+		for {
+			err := q.Stream(dec, outputResult)
+			if err == io.EOF {
+				return nil
+			}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("parsing JSON: %w", err)
+			}
+			if err != nil {
+				return fmt.Errorf("executing query: %w", err)
+			}
+		}
 	}
 
-	result, err := q.Execute(data)
+	values, err := appendValues(nil, dec)
 	if err != nil {
-		return fmt.Errorf("executing query: %w", err)
+		return err
+	}
+	return runQuery(q, values)
+}
+
+//starter:todo Decode every JSON value left in dec and append it to values
+func appendValues(values []interface{}, dec *json.Decoder) ([]interface{}, error) {
+	for {
+		var data interface{}
+		err := dec.Decode(&data)
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return values, fmt.Errorf("parsing JSON: %w", err)
+		}
+		values = append(values, data)
+	}
+}
+
+//starter:todo Run the query on each value and print the results, as one table with -t
+func runQuery(q *query.Query, values []interface{}) error {
+	var results []interface{}
+	for _, data := range values {
+		outputs, err := q.Run(data)
+		if err != nil {
+			return fmt.Errorf("executing query: %w", err)
+		}
+		results = append(results, outputs...)
 	}
 
-	return outputResult(result)
+	// A table has a row per result, unless the only result is a whole array
+	if *table {
+		if len(results) == 1 {
+			if _, ok := results[0].([]interface{}); ok {
+				return outputResult(results[0])
+			}
+		}
+		return outputResult(results)
+	}
+
+	for _, result := range results {
+		if err := outputResult(result); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+//starter:todo Pick the formatter from the -c, -t and -r flags and print the formatted data
 func outputResult(data interface{}) error {
 	var f formatter.Formatter
 
@@ -111,6 +192,8 @@ Flags:
   -c    compact output
   -t    table output
   -r    raw output (no quotes)
+  -s, --slurp
+        read the JSON values of all inputs into one array
   -v    show version
   -h    show help
 
@@ -118,5 +201,7 @@ Examples:
   jq '.name' data.json
   echo '{"name": "Alice"}' | jq '.name'
   jq -t '.users[]' data.json
+  jq -c '.events[] | select(.level == "error")' huge.json
+  jq -s 'map(.id)' records.ndjson
 `)
 }
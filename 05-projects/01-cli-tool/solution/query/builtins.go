@@ -0,0 +1,442 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// builtin is a function queries can call by name. Its arguments are
+// filters, run by the function on whatever input it chooses.
+type builtin struct {
+	arity int
+	fn    func(data interface{}, args []QueryNode) ([]interface{}, error)
+}
+
+// builtins holds the functions of the query language, by name.
+var builtins = map[string]builtin{
+	"length":       {0, length},
+	"not":          {0, not},
+	"select":       {1, selectFn},
+	"map":          {1, mapFn},
+	"sort_by":      {1, sortBy},
+	"group_by":     {1, groupBy},
+	"unique_by":    {1, uniqueBy},
+	"min_by":       {1, minBy},
+	"max_by":       {1, maxBy},
+	"keys":         {0, keys},
+	"values":       {0, values},
+	"has":          {1, has},
+	"add":          {0, add},
+	"to_entries":   {0, toEntries},
+	"from_entries": {0, fromEntries},
+	"tostring":     {0, tostring},
+	"tonumber":     {0, tonumber},
+}
+
+// Execute runs the builtin function named by the call.
+func (c *Call) Execute(data interface{}) ([]interface{}, error) {
+	b, ok := builtins[c.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q", c.Name)
+	}
+	return b.fn(data, c.Args)
+}
+
+//starter:todo Return the number of array elements, object keys or string runes; null has length 0
+func length(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	switch v := data.(type) {
+	case []interface{}:
+		return one(len(v))
+	case map[string]interface{}:
+		return one(len(v))
+	case string:
+		return one(utf8.RuneCountInString(v))
+	case nil:
+		return one(0)
+	default:
+		return nil, fmt.Errorf("cannot get length of %T", data)
+	}
+}
+
+//starter:todo Return whether data is falsy
+func not(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	return one(!truthy(data))
+}
+
+// selectFn emits data for each output of the condition that holds, so
+// nothing when it does not.
+//
+//starter:todo Emit data once for every truthy output of the condition
+func selectFn(data interface{}, args []QueryNode) ([]interface{}, error) {
+	conds, err := args[0].Execute(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	for _, cond := range conds {
+		if truthy(cond) {
+			results = append(results, data)
+		}
+	}
+	return results, nil
+}
+
+// mapFn collects the outputs of f on each element into an array.
+//
+//starter:todo Run the filter on every element of an array and collect the outputs
+func mapFn(data interface{}, args []QueryNode) ([]interface{}, error) {
+	elems, err := (&ArrayIterate{}).Execute(data)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		outputs, err := args[0].Execute(elem)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, outputs...)
+	}
+	return one(results)
+}
+
+// keyed is an array element with its sort key: all the outputs of the key
+// filter on it.
+type keyed struct {
+	key   []interface{}
+	value interface{}
+}
+
+// sortedByKey returns the elements of the array data sorted by the outputs
+// of f on them. The sort is stable.
+func sortedByKey(name string, data interface{}, f QueryNode) ([]keyed, error) {
+	arr, ok := data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s requires an array, got %T", name, data)
+	}
+
+	elems := make([]keyed, len(arr))
+	for i, v := range arr {
+		key, err := f.Execute(v)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = keyed{key: key, value: v}
+	}
+	slices.SortStableFunc(elems, func(a, b keyed) int {
+		return compare(a.key, b.key)
+	})
+	return elems, nil
+}
+
+// groupByKey returns the sorted elements of the array data, grouped by
+// equal keys.
+func groupByKey(name string, data interface{}, f QueryNode) ([][]keyed, error) {
+	elems, err := sortedByKey(name, data, f)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups [][]keyed
+	for i, elem := range elems {
+		if i == 0 || compare(elem.key, elems[i-1].key) != 0 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], elem)
+	}
+	return groups, nil
+}
+
+//starter:todo Sort an array by the outputs of the key filter, keeping equal elements in order
+func sortBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	elems, err := sortedByKey("sort_by", data, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]interface{}, len(elems))
+	for i, elem := range elems {
+		sorted[i] = elem.value
+	}
+	return one(sorted)
+}
+
+//starter:todo Group the elements of an array by key, in key order
+func groupBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	groups, err := groupByKey("group_by", data, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(groups))
+	for i, group := range groups {
+		values := make([]interface{}, len(group))
+		for j, elem := range group {
+			values[j] = elem.value
+		}
+		results[i] = values
+	}
+	return one(results)
+}
+
+// uniqueBy keeps the first element of each group of equal keys, sorted by
+// key.
+//
+//starter:todo Keep the first element of each group of equal keys, in key order
+func uniqueBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	groups, err := groupByKey("unique_by", data, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(groups))
+	for i, group := range groups {
+		results[i] = group[0].value
+	}
+	return one(results)
+}
+
+// minBy returns the first element with the smallest key, or null for an
+// empty array.
+//
+//starter:todo Return the element with the smallest key, null for an empty array
+func minBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	elems, err := sortedByKey("min_by", data, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) == 0 {
+		return one(nil)
+	}
+	return one(elems[0].value)
+}
+
+// maxBy returns the last element with the largest key, or null for an
+// empty array.
+//
+//starter:todo Return the element with the largest key, null for an empty array
+func maxBy(data interface{}, args []QueryNode) ([]interface{}, error) {
+	elems, err := sortedByKey("max_by", data, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) == 0 {
+		return one(nil)
+	}
+	return one(elems[len(elems)-1].value)
+}
+
+// keys returns the sorted keys of an object or the indices of an array.
+//
+//starter:todo Return the sorted keys of an object or the indexes of an array
+func keys(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		results := make([]interface{}, 0, len(v))
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			results = append(results, k)
+		}
+		return one(results)
+	case []interface{}:
+		results := make([]interface{}, len(v))
+		for i := range v {
+			results[i] = i
+		}
+		return one(results)
+	default:
+		return nil, fmt.Errorf("%T has no keys", data)
+	}
+}
+
+// values emits its input unless it is null, like jq's.
+//
+//starter:todo Emit the input unless it is null
+func values(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	return one(data)
+}
+
+// has reports whether an object has a key, or an array an index.
+//
+//starter:todo Report whether an object has a key or an array has an index
+func has(data interface{}, args []QueryNode) ([]interface{}, error) {
+	return product(data, &Identity{}, args[0], func(input, key interface{}) (interface{}, error) {
+		switch v := input.(type) {
+		case map[string]interface{}:
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("cannot check whether an object has a %T key", key)
+			}
+			_, exists := v[k]
+			return exists, nil
+		case []interface{}:
+			switch k := key.(type) {
+			case float64, int:
+				// Like jq, has(1.5) checks index 1
+				i := math.Trunc(toFloat(k))
+				return i >= 0 && i < float64(len(v)), nil
+			}
+			return nil, fmt.Errorf("cannot check whether an array has a %T key", key)
+		default:
+			return nil, fmt.Errorf("cannot check whether %T has a key", input)
+		}
+	})
+}
+
+// add sums the elements of an array: numbers are added, strings and arrays
+// concatenated and objects merged. Nulls are skipped; an empty array adds up
+// to null.
+//
+//starter:todo Add up the elements of an array with plus, null for an empty array
+func add(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	arr, ok := data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("add requires an array, got %T", data)
+	}
+
+	var sum interface{}
+	for _, v := range arr {
+		var err error
+		if sum, err = plus(sum, v); err != nil {
+			return nil, err
+		}
+	}
+	return one(sum)
+}
+
+// plus adds two values of the same type, or anything to null.
+func plus(a, b interface{}) (interface{}, error) {
+	if a == nil {
+		return b, nil
+	}
+	if b == nil {
+		return a, nil
+	}
+
+	switch a := a.(type) {
+	case float64, int:
+		switch b.(type) {
+		case float64, int:
+			return toFloat(a) + toFloat(b), nil
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return a + b, nil
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			return append(slices.Clip(a), b...), nil
+		}
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			merged := maps.Clone(a)
+			maps.Copy(merged, b)
+			return merged, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot add %T and %T", a, b)
+}
+
+// toEntries turns an object into an array of {"key", "value"} objects,
+// sorted by key.
+//
+//starter:todo Turn an object into an array of {"key", "value"} objects, in key order
+func toEntries(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("to_entries requires an object, got %T", data)
+	}
+
+	entries := make([]interface{}, 0, len(m))
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		entries = append(entries, map[string]interface{}{"key": k, "value": m[k]})
+	}
+	return one(entries)
+}
+
+// fromEntries turns an array of {"key", "value"} objects into an object.
+// Like jq, it also accepts "k", "name" and "v" as names.
+//
+//starter:todo Build an object from {"key", "value"} entries (k, name, v are accepted too)
+func fromEntries(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	arr, ok := data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("from_entries requires an array, got %T", data)
+	}
+
+	result := make(map[string]interface{}, len(arr))
+	for _, v := range arr {
+		entry, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("from_entries requires objects, got %T", v)
+		}
+
+		key := firstOf(entry, "key", "k", "name")
+		var name string
+		switch k := key.(type) {
+		case string:
+			name = k
+		case float64, int, bool:
+			name = fmt.Sprint(k)
+		default:
+			return nil, fmt.Errorf("cannot use %T as an object key", key)
+		}
+		result[name] = firstOf(entry, "value", "v")
+	}
+	return one(result)
+}
+
+// firstOf returns the value of the first of the keys the object has.
+func firstOf(m map[string]interface{}, keys ...string) interface{} {
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// tostring returns strings as they are and anything else as JSON, with
+// <, > and & left as they are, as jq does.
+//
+//starter:todo Return strings as they are and anything else as JSON text, without escaping HTML
+func tostring(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	if s, ok := data.(string); ok {
+		return one(s)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return nil, err
+	}
+	return one(string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))))
+}
+
+// tonumber parses strings and returns numbers as they are.
+//
+//starter:todo Return numbers as they are and parse strings as numbers
+func tonumber(data interface{}, _ []QueryNode) ([]interface{}, error) {
+	switch v := data.(type) {
+	case float64, int:
+		return one(v)
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as a number", v)
+		}
+		return one(n)
+	default:
+		return nil, fmt.Errorf("cannot convert %T to a number", data)
+	}
+}
@@ -1,23 +1,32 @@
 package query
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
 )
 
-func (f *FieldSelect) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement field selection
-	// Hint: Type assert to map[string]interface{}, return field value
-
+//starter:todo Implement field selection
+//starter:todo Type assert to map[string]interface{}, return field value; fields of null are null, and .length counts array elements and string runes
+func (f *FieldSelect) Execute(data interface{}) ([]interface{}, error) {
 	// Special case: handle .length on arrays
 	if f.Field == "length" {
 		switch v := data.(type) {
 		case []interface{}:
-			return len(v), nil
+			return one(len(v))
 		case string:
-			return len(v), nil
+			return one(utf8.RuneCountInString(v))
 		}
 	}
 
+	// Fields of null are null, as with jq, so .missing.x is null too
+	if data == nil {
+		return one(nil)
+	}
+
 	m, ok := data.(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("cannot select field from non-object (got %T)", data)
@@ -25,60 +34,342 @@ func (f *FieldSelect) Execute(data interface{}) (interface{}, error) {
 
 	val, exists := m[f.Field]
 	if !exists {
-		return nil, nil
+		return one(nil)
 	}
 
-	return val, nil
+	return one(val)
 }
 
-func (a *ArrayIndex) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement array indexing
-	// Hint: Type assert to []interface{}, check bounds, return element
+//starter:todo Implement array indexing
+//starter:todo Type assert to []interface{}, count negative indices from the end, check bounds, return element
+func (a *ArrayIndex) Execute(data interface{}) ([]interface{}, error) {
 	arr, ok := data.([]interface{})
 	if !ok {
 		return nil, fmt.Errorf("cannot index non-array (got %T)", data)
 	}
 
-	if a.Index < 0 || a.Index >= len(arr) {
-		return nil, fmt.Errorf("array index out of bounds: %d (length: %d)", a.Index, len(arr))
+	index := a.Index
+	if index < 0 {
+		index += len(arr)
+	}
+	if index < 0 || index >= len(arr) {
+		return nil, indexError(a.Index, len(arr))
 	}
 
-	return arr[a.Index], nil
+	return one(arr[index])
 }
 
-func (a *ArrayIterate) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement array iteration
-	// Hint: Type assert to []interface{}, return as-is or process each element
-	arr, ok := data.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("cannot iterate over non-array (got %T)", data)
-	}
-
-	return arr, nil
+func indexError(index, length int) error {
+	return fmt.Errorf("array index out of bounds: %d (length: %d)", index, length)
 }
 
-func (l *LengthOp) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement length operation
-	// Hint: Handle arrays, maps, strings
+//starter:todo Implement array iteration
+//starter:todo Emit each element of an array, or each value of an object, in a new slice
+func (a *ArrayIterate) Execute(data interface{}) ([]interface{}, error) {
 	switch v := data.(type) {
 	case []interface{}:
-		return len(v), nil
+		// A copy, so appending to the outputs cannot overwrite the input
+		return slices.Clone(v), nil
 	case map[string]interface{}:
-		return len(v), nil
-	case string:
-		return len(v), nil
+		values := make([]interface{}, 0, len(v))
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			values = append(values, v[k])
+		}
+		return values, nil
 	default:
-		return nil, fmt.Errorf("cannot get length of %T", data)
+		return nil, fmt.Errorf("cannot iterate over %T", data)
+	}
+}
+
+//starter:todo Implement pipe operation
+//starter:todo Execute left, then execute right on each of its outputs
+func (p *Pipe) Execute(data interface{}) ([]interface{}, error) {
+	inputs, err := p.Left.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []interface{}
+	for _, input := range inputs {
+		outputs, err := p.Right.Execute(input)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, outputs...)
+	}
+	return results, nil
+}
+
+//starter:todo Return the outputs of the left side followed by those of the right side, in a new slice
+func (c *Comma) Execute(data interface{}) ([]interface{}, error) {
+	left, err := c.Left.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.Right.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]interface{}, 0, len(left)+len(right))
+	results = append(results, left...)
+	return append(results, right...), nil
+}
+
+//starter:todo Gather every output of the inner query into one new array
+func (c *Collect) Execute(data interface{}) ([]interface{}, error) {
+	if c.Expr == nil {
+		return one([]interface{}{})
+	}
+	results, err := c.Expr.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+	// A new array, never one the expression's outputs share with the input
+	array := make([]interface{}, len(results))
+	copy(array, results)
+	return one(array)
+}
+
+// Execute builds an object for every combination of the outputs of the
+// keys and values, so {name: .users[].name} emits one object per user.
+//
+//starter:todo Build an object for every combination of key and value outputs
+func (o *ObjectConstruct) Execute(data interface{}) ([]interface{}, error) {
+	objects := []map[string]interface{}{{}}
+	for _, entry := range o.Entries {
+		keys, err := entry.Key.Execute(data)
+		if err != nil {
+			return nil, err
+		}
+		values, err := entry.Value.Execute(data)
+		if err != nil {
+			return nil, err
+		}
+
+		next := make([]map[string]interface{}, 0, len(objects)*len(keys)*len(values))
+		for _, object := range objects {
+			for _, key := range keys {
+				name, ok := key.(string)
+				if !ok {
+					return nil, fmt.Errorf("object keys must be strings, got %T", key)
+				}
+				for _, value := range values {
+					extended := maps.Clone(object)
+					extended[name] = value
+					next = append(next, extended)
+				}
+			}
+		}
+		objects = next
+	}
+
+	results := make([]interface{}, len(objects))
+	for i, object := range objects {
+		results[i] = object
+	}
+	return results, nil
+}
+
+func (i *Identity) Execute(data interface{}) ([]interface{}, error) {
+	return one(data)
+}
+
+func (l *Literal) Execute(data interface{}) ([]interface{}, error) {
+	return one(l.Value)
+}
+
+//starter:todo Negate every number Expr outputs; other values cannot be negated
+func (n *Negate) Execute(data interface{}) ([]interface{}, error) {
+	values, err := n.Expr.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, len(values))
+	for i, v := range values {
+		switch v.(type) {
+		case float64, int:
+			results[i] = -toFloat(v)
+		default:
+			return nil, fmt.Errorf("cannot negate %T", v)
+		}
+	}
+	return results, nil
+}
+
+// Execute compares every output of Left with every output of Right.
+//
+//starter:todo Compare every pair of left and right outputs with compare
+func (c *Comparison) Execute(data interface{}) ([]interface{}, error) {
+	return product(data, c.Left, c.Right, func(left, right interface{}) (interface{}, error) {
+		order := compare(left, right)
+		switch c.Op {
+		case EQ:
+			return order == 0, nil
+		case NEQ:
+			return order != 0, nil
+		case LT:
+			return order < 0, nil
+		case LTE:
+			return order <= 0, nil
+		case GT:
+			return order > 0, nil
+		case GTE:
+			return order >= 0, nil
+		default:
+			return nil, fmt.Errorf("unknown comparison %s", c.Op)
+		}
+	})
+}
+
+// Execute emits false for each false output of Left, and the truth of
+// Right's outputs for each true one.
+//
+//starter:todo Return false unless both sides are truthy, skipping the right side when the left is false
+func (a *And) Execute(data interface{}) ([]interface{}, error) {
+	return shortCircuit(data, a.Left, a.Right, false)
+}
+
+// Execute emits true for each true output of Left, and the truth of
+// Right's outputs for each false one.
+//
+//starter:todo Return true if either side is truthy, skipping the right side when the left is true
+func (o *Or) Execute(data interface{}) ([]interface{}, error) {
+	return shortCircuit(data, o.Left, o.Right, true)
+}
+
+// one returns v as the only output.
+func one(v interface{}) ([]interface{}, error) {
+	return []interface{}{v}, nil
+}
+
+// product applies op to the outputs of left and right on data, for every
+// output of right and then every output of left, as jq does.
+func product(data interface{}, left, right QueryNode, op func(l, r interface{}) (interface{}, error)) ([]interface{}, error) {
+	lefts, err := left.Execute(data)
+	if err != nil {
+		return nil, err
 	}
+	rights, err := right.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interface{}, 0, len(lefts)*len(rights))
+	for _, r := range rights {
+		for _, l := range lefts {
+			result, err := op(l, r)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
 }
 
-func (p *Pipe) Execute(data interface{}) (interface{}, error) {
-	// TODO: Implement pipe operation
-	// Hint: Execute left, then execute right on result
-	result, err := p.Left.Execute(data)
+// shortCircuit runs and (stop false) and or (stop true): an output of left
+// with the truth stop is emitted as is, right is only run for the others.
+func shortCircuit(data interface{}, left, right QueryNode, stop bool) ([]interface{}, error) {
+	lefts, err := left.Execute(data)
 	if err != nil {
 		return nil, err
 	}
 
-	return p.Right.Execute(result)
+	var results []interface{}
+	for _, l := range lefts {
+		if truthy(l) == stop {
+			results = append(results, stop)
+			continue
+		}
+		rights, err := right.Execute(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rights {
+			results = append(results, truthy(r))
+		}
+	}
+	return results, nil
+}
+
+// truthy reports whether v counts as true in a condition: everything but
+// false and null does.
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
+}
+
+// typeOrder ranks JSON values by type, the order jq sorts them in.
+func typeOrder(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if !v {
+			return 1
+		}
+		return 2
+	case float64, int:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// compare returns -1, 0 or 1 as a is less than, equal to or greater than b.
+// Values of different types compare by type: null < false < true < numbers
+// < strings < arrays < objects. Arrays compare element by element, objects
+// by their sorted keys and then their values.
+//
+//starter:todo Order values by type first, then numbers by value, strings, arrays and objects by content
+func compare(a, b interface{}) int {
+	if ta, tb := typeOrder(a), typeOrder(b); ta != tb {
+		return cmp.Compare(ta, tb)
+	}
+
+	switch a := a.(type) {
+	case float64, int:
+		return cmp.Compare(toFloat(a), toFloat(b))
+	case string:
+		return strings.Compare(a, b.(string))
+	case []interface{}:
+		b := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(a), len(b))
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		keysA, keysB := slices.Sorted(maps.Keys(a)), slices.Sorted(maps.Keys(b))
+		if c := slices.Compare(keysA, keysB); c != 0 {
+			return c
+		}
+		for _, k := range keysA {
+			if c := compare(a[k], b[k]); c != 0 {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// toFloat converts a number, decoded as float64 or counted as int, to float64.
+func toFloat(v interface{}) float64 {
+	if n, ok := v.(int); ok {
+		return float64(n)
+	}
+	return v.(float64)
 }
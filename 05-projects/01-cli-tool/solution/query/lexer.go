@@ -0,0 +1,252 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+type TokenType int
+
+const (
+	EOF       TokenType = iota
+	DOT                 // .
+	FIELD               // .name
+	IDENT               // length, select, and, or, true, ...
+	NUMBER              // 42, 1.5
+	STRING              // "text"
+	LBRACKET            // [
+	RBRACKET            // ]
+	LPAREN              // (
+	RPAREN              // )
+	LBRACE              // {
+	RBRACE              // }
+	COLON               // :
+	COMMA               // ,
+	PIPE                // |
+	SEMICOLON           // ;
+	EQ                  // ==
+	NEQ                 // !=
+	LT                  // <
+	LTE                 // <=
+	GT                  // >
+	GTE                 // >=
+	MINUS               // -
+)
+
+var tokenNames = map[TokenType]string{
+	EOF:       "end of query",
+	DOT:       "'.'",
+	FIELD:     "field",
+	IDENT:     "identifier",
+	NUMBER:    "number",
+	STRING:    "string",
+	LBRACKET:  "'['",
+	RBRACKET:  "']'",
+	LPAREN:    "'('",
+	RPAREN:    "')'",
+	LBRACE:    "'{'",
+	RBRACE:    "'}'",
+	COLON:     "':'",
+	COMMA:     "','",
+	PIPE:      "'|'",
+	SEMICOLON: "';'",
+	EQ:        "'=='",
+	NEQ:       "'!='",
+	LT:        "'<'",
+	LTE:       "'<='",
+	GT:        "'>'",
+	GTE:       "'>='",
+	MINUS:     "'-'",
+}
+
+func (t TokenType) String() string {
+	return tokenNames[t]
+}
+
+// Token is a lexical token of a query. Pos is its byte offset in the query;
+// Value holds the field name, identifier, number or decoded string.
+type Token struct {
+	Type  TokenType
+	Value string
+	Pos   int
+}
+
+func (t Token) String() string {
+	switch t.Type {
+	case FIELD:
+		return fmt.Sprintf("field %q", "."+t.Value)
+	case IDENT, NUMBER:
+		return fmt.Sprintf("%s %q", t.Type, t.Value)
+	case STRING:
+		return fmt.Sprintf("string %q", t.Value)
+	default:
+		return t.Type.String()
+	}
+}
+
+// SyntaxError describes an invalid query and where in it the problem is.
+type SyntaxError struct {
+	Msg    string
+	Column int // 1-based, in characters
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Msg, e.Column)
+}
+
+// errorAt returns a SyntaxError for the byte offset pos of query.
+func errorAt(query string, pos int, format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{
+		Msg:    fmt.Sprintf(format, args...),
+		Column: utf8.RuneCountInString(query[:pos]) + 1,
+	}
+}
+
+// Lex splits a query into tokens, ending with an EOF token.
+//
+//starter:todo Scan the query byte by byte into tokens: skip spaces, read numbers, strings, identifiers and operators
+//starter:todo Report a SyntaxError at the column of anything else, and end with an EOF token
+func Lex(query string) ([]Token, error) {
+	var tokens []Token
+	i := 0
+	for {
+		for i < len(query) && isSpace(query[i]) {
+			i++
+		}
+		if i == len(query) {
+			return append(tokens, Token{Type: EOF, Pos: i}), nil
+		}
+
+		start := i
+		c := query[i]
+		switch {
+		case c == '.':
+			i++
+			end := i
+			for end < len(query) && isIdentChar(query[end], end == i) {
+				end++
+			}
+			if end > i {
+				tokens = append(tokens, Token{Type: FIELD, Value: query[i:end], Pos: start})
+				i = end
+			} else {
+				tokens = append(tokens, Token{Type: DOT, Pos: start})
+			}
+
+		case isIdentChar(c, true):
+			for i < len(query) && isIdentChar(query[i], false) {
+				i++
+			}
+			tokens = append(tokens, Token{Type: IDENT, Value: query[start:i], Pos: start})
+
+		case isDigit(c):
+			i++
+			for i < len(query) && (isDigit(query[i]) || query[i] == '.') {
+				i++
+			}
+			if i < len(query) && (query[i] == 'e' || query[i] == 'E') {
+				i++
+				if i < len(query) && (query[i] == '+' || query[i] == '-') {
+					i++
+				}
+				for i < len(query) && isDigit(query[i]) {
+					i++
+				}
+			}
+			if _, err := strconv.ParseFloat(query[start:i], 64); err != nil {
+				return nil, errorAt(query, start, "invalid number %q", query[start:i])
+			}
+			tokens = append(tokens, Token{Type: NUMBER, Value: query[start:i], Pos: start})
+
+		case c == '"':
+			i++
+			for i < len(query) && query[i] != '"' {
+				if query[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(query) {
+				return nil, errorAt(query, start, "unterminated string")
+			}
+			i++
+			// String literals are JSON strings: "\/" is valid, "\x41" is not
+			var value string
+			if err := json.Unmarshal([]byte(query[start:i]), &value); err != nil {
+				return nil, errorAt(query, start, "invalid string %s", query[start:i])
+			}
+			tokens = append(tokens, Token{Type: STRING, Value: value, Pos: start})
+
+		default:
+			typ, n := operator(query[i:])
+			if n == 0 {
+				r, _ := utf8.DecodeRuneInString(query[i:])
+				return nil, errorAt(query, start, "unexpected character %q", r)
+			}
+			i += n
+			tokens = append(tokens, Token{Type: typ, Pos: start})
+		}
+	}
+}
+
+// operator returns the operator or punctuation s starts with and its length,
+// or a length of 0 if there is none.
+func operator(s string) (TokenType, int) {
+	if len(s) >= 2 {
+		switch s[:2] {
+		case "==":
+			return EQ, 2
+		case "!=":
+			return NEQ, 2
+		case "<=":
+			return LTE, 2
+		case ">=":
+			return GTE, 2
+		}
+	}
+	switch s[0] {
+	case '[':
+		return LBRACKET, 1
+	case ']':
+		return RBRACKET, 1
+	case '(':
+		return LPAREN, 1
+	case ')':
+		return RPAREN, 1
+	case '{':
+		return LBRACE, 1
+	case '}':
+		return RBRACE, 1
+	case ':':
+		return COLON, 1
+	case ',':
+		return COMMA, 1
+	case '|':
+		return PIPE, 1
+	case ';':
+		return SEMICOLON, 1
+	case '<':
+		return LT, 1
+	case '>':
+		return GT, 1
+	case '-':
+		return MINUS, 1
+	}
+	return EOF, 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentChar reports whether c can be part of an identifier; digits cannot
+// start one.
+func isIdentChar(c byte, first bool) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || !first && isDigit(c)
+}
@@ -2,25 +2,30 @@ package query
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 type Query struct {
-	nodes []QueryNode
+	root QueryNode
 }
 
+// QueryNode is a filter: it turns one input into zero or more outputs, each
+// of which the next filter of a pipe runs on.
 type QueryNode interface {
-	Execute(data interface{}) (interface{}, error)
+	Execute(data interface{}) ([]interface{}, error)
 }
 
+// Identity represents .
+type Identity struct{}
+
 // FieldSelect represents .field
 type FieldSelect struct {
 	Field string
 }
 
-// ArrayIndex represents [0]
+// ArrayIndex represents [0]; a negative index counts from the end, as in [-1]
 type ArrayIndex struct {
 	Index int
 }
@@ -33,94 +38,498 @@ type Pipe struct {
 	Left, Right QueryNode
 }
 
-// LengthOp represents length
-type LengthOp struct{}
+// Comma represents , : the outputs of Left, then those of Right
+type Comma struct {
+	Left, Right QueryNode
+}
+
+// Negate represents -expr, the negation of every output of Expr
+type Negate struct {
+	Expr QueryNode
+}
+
+// Literal represents a number, string, true, false or null
+type Literal struct {
+	Value interface{}
+}
+
+// Comparison represents ==, !=, <, <=, > and >=
+type Comparison struct {
+	Op          TokenType
+	Left, Right QueryNode
+}
+
+// And represents and
+type And struct {
+	Left, Right QueryNode
+}
+
+// Or represents or
+type Or struct {
+	Left, Right QueryNode
+}
+
+// Collect represents [expr], an array of all the outputs of Expr. Expr is
+// nil for [].
+type Collect struct {
+	Expr QueryNode
+}
+
+// ObjectEntry is a key: value pair of an object construction. Key is a
+// Literal for name: and "name":, any expression for (expr):.
+type ObjectEntry struct {
+	Key, Value QueryNode
+}
+
+// ObjectConstruct represents {key: value, ...}
+type ObjectConstruct struct {
+	Entries []ObjectEntry
+}
 
+// Call represents a builtin function call: length, select(cond), ...
+type Call struct {
+	Name string
+	Args []QueryNode
+}
+
+//starter:todo Lex the query, parse one expression and reject any tokens left before EOF
 func Parse(queryStr string) (*Query, error) {
 	queryStr = strings.TrimSpace(queryStr)
 	if queryStr == "" {
 		return nil, fmt.Errorf("empty query")
 	}
 
-	// TODO: Implement query parser
-	// Hint: Tokenize, then build AST
-	// For now, handle basic cases
-	
-	nodes, err := parseExpression(queryStr)
+	tokens, err := Lex(queryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{query: queryStr, tokens: tokens}
+	root, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.Type != EOF {
+		return nil, p.unexpected(tok)
+	}
+
+	return &Query{root: root}, nil
+}
+
+// parser is a Pratt parser: parseExpression handles the binary operators by
+// binding power, parsePostfix the paths they combine.
+type parser struct {
+	query  string
+	tokens []Token
+	pos    int
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	tok := p.tokens[p.pos]
+	if tok.Type != EOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(typ TokenType) (Token, error) {
+	tok := p.next()
+	if tok.Type != typ {
+		return tok, p.errorAt(tok, "expected %s, got %s", typ, tok)
+	}
+	return tok, nil
+}
+
+func (p *parser) errorAt(tok Token, format string, args ...interface{}) error {
+	return errorAt(p.query, tok.Pos, format, args...)
+}
+
+func (p *parser) unexpected(tok Token) error {
+	return p.errorAt(tok, "unexpected %s", tok)
+}
+
+// Binding powers of the binary operators: higher binds tighter.
+const (
+	pipePower = iota + 1
+	commaPower
+	orPower
+	andPower
+	comparePower
+)
+
+// infix returns the binding power of the binary operator tok, or 0 if tok
+// is not one. Pipes are right-associative and comparisons cannot be
+// chained.
+func infix(tok Token) int {
+	switch tok.Type {
+	case PIPE:
+		return pipePower
+	case COMMA:
+		return commaPower
+	case IDENT:
+		switch tok.Value {
+		case "or":
+			return orPower
+		case "and":
+			return andPower
+		}
+	case EQ, NEQ, LT, LTE, GT, GTE:
+		return comparePower
+	}
+	return 0
+}
+
+// parseExpression parses operators binding tighter than minPower.
+//
+//starter:todo Parse a postfix path, then fold in infix operators while they bind tighter than minPower
+func (p *parser) parseExpression(minPower int) (QueryNode, error) {
+	left, err := p.parsePostfix()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Query{nodes: nodes}, nil
+	compared := false
+	for {
+		op := p.peek()
+		power := infix(op)
+		if power <= minPower {
+			return left, nil
+		}
+		p.next()
+
+		// Pipes group to the right: a | b | c is a | (b | c)
+		rightPower := power
+		if op.Type == PIPE {
+			rightPower--
+		}
+		right, err := p.parseExpression(rightPower)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case op.Type == PIPE:
+			left = &Pipe{Left: left, Right: right}
+		case op.Type == COMMA:
+			left = &Comma{Left: left, Right: right}
+		case op.Value == "or":
+			left = &Or{Left: left, Right: right}
+		case op.Value == "and":
+			left = &And{Left: left, Right: right}
+		case compared:
+			return nil, p.errorAt(op, "comparisons cannot be chained, use and")
+		default:
+			left = &Comparison{Op: op.Type, Left: left, Right: right}
+			compared = true
+		}
+	}
 }
 
-func parseExpression(expr string) ([]QueryNode, error) {
-	// TODO: Implement full parser
-	// This is a simplified version for basic cases
-	
-	var nodes []QueryNode
-	i := 0
+// parsePostfix parses a term followed by any number of .field, [n] and []
+// suffixes, each piped into the next.
+//
+//starter:todo Parse a term, then pipe it into each .field, [n] or [] suffix that follows
+func (p *parser) parsePostfix() (QueryNode, error) {
+	node, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
 
-	for i < len(expr) {
-		if expr[i] == '.' {
-			i++
-			// Parse field name
-			end := i
-			for end < len(expr) && (unicode.IsLetter(rune(expr[end])) || unicode.IsDigit(rune(expr[end])) || expr[end] == '_') {
-				end++
+	for {
+		var suffix QueryNode
+		switch tok := p.peek(); tok.Type {
+		case FIELD:
+			p.next()
+			suffix = &FieldSelect{Field: tok.Value}
+		case LBRACKET:
+			if suffix, err = p.parseBrackets(); err != nil {
+				return nil, err
+			}
+		case DOT:
+			// ."key" and .[n] after a path
+			p.next()
+			if suffix, err = p.parseAfterDot(); err != nil {
+				return nil, err
 			}
-			if end > i {
-				nodes = append(nodes, &FieldSelect{Field: expr[i:end]})
-				i = end
+		default:
+			return node, nil
+		}
+		node = pipe(node, suffix)
+	}
+}
+
+// parseTerm parses a path start, literal, function call, construction or
+// parenthesized expression.
+//
+//starter:todo Switch on the next token: a path start, a literal, a function, [...], {...} or (...)
+func (p *parser) parseTerm() (QueryNode, error) {
+	tok := p.next()
+	switch tok.Type {
+	case DOT:
+		switch p.peek().Type {
+		case STRING, LBRACKET:
+			return p.parseAfterDot()
+		}
+		return &Identity{}, nil
+
+	case FIELD:
+		return &FieldSelect{Field: tok.Value}, nil
+
+	case NUMBER:
+		n, err := strconv.ParseFloat(tok.Value, 64)
+		if err != nil {
+			return nil, p.errorAt(tok, "invalid number %q", tok.Value)
+		}
+		return &Literal{Value: n}, nil
+
+	case STRING:
+		return &Literal{Value: tok.Value}, nil
+
+	case MINUS:
+		// Negation binds tighter than any infix operator: -.a > 1 compares -.a
+		operand, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		if lit, ok := operand.(*Literal); ok {
+			if n, ok := lit.Value.(float64); ok {
+				return &Literal{Value: -n}, nil
 			}
-		} else if expr[i] == '[' {
-			i++
-			if i < len(expr) && expr[i] == ']' {
-				// Array iterate
-				nodes = append(nodes, &ArrayIterate{})
-				i++
-			} else {
-				// Array index
-				end := i
-				for end < len(expr) && unicode.IsDigit(rune(expr[end])) {
-					end++
-				}
-				if end > i {
-					index, err := strconv.Atoi(expr[i:end])
-					if err != nil {
-						return nil, fmt.Errorf("invalid array index: %s", expr[i:end])
-					}
-					nodes = append(nodes, &ArrayIndex{Index: index})
-					i = end
-					if i < len(expr) && expr[i] == ']' {
-						i++
-					}
-				}
+		}
+		return &Negate{Expr: operand}, nil
+
+	case LPAREN:
+		node, err := p.parseExpression(0)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(RPAREN); err != nil {
+			return nil, err
+		}
+		return node, nil
+
+	case LBRACKET:
+		if p.peek().Type == RBRACKET {
+			p.next()
+			return &Collect{}, nil
+		}
+		expr, err := p.parseExpression(0)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(RBRACKET); err != nil {
+			return nil, err
+		}
+		return &Collect{Expr: expr}, nil
+
+	case LBRACE:
+		return p.parseObject()
+
+	case IDENT:
+		return p.parseFunction(tok)
+	}
+	return nil, p.unexpected(tok)
+}
+
+// parseObject parses the entries of an object construction after its {.
+// Values bind tighter than commas, which separate the entries: {a: .b | .c}
+// needs parentheses around the pipe.
+//
+//starter:todo Parse entries separated by commas up to the closing }
+func (p *parser) parseObject() (QueryNode, error) {
+	object := &ObjectConstruct{}
+	if p.peek().Type == RBRACE {
+		p.next()
+		return object, nil
+	}
+
+	for {
+		entry, err := p.parseObjectEntry()
+		if err != nil {
+			return nil, err
+		}
+		object.Entries = append(object.Entries, entry)
+
+		switch tok := p.next(); tok.Type {
+		case COMMA:
+		case RBRACE:
+			return object, nil
+		default:
+			return nil, p.errorAt(tok, "expected ',' or '}', got %s", tok)
+		}
+	}
+}
+
+// parseAfterDot parses what may follow a lone dot: "key" or brackets.
+//
+//starter:todo A lone dot is followed by a "key", brackets or nothing (the identity)
+func (p *parser) parseAfterDot() (QueryNode, error) {
+	tok := p.peek()
+	switch tok.Type {
+	case STRING:
+		p.next()
+		return &FieldSelect{Field: tok.Value}, nil
+	case LBRACKET:
+		return p.parseBrackets()
+	}
+	return nil, p.unexpected(tok)
+}
+
+// parseBrackets parses [], [n], [-n] and ["key"].
+//
+//starter:todo Return [] as is, otherwise parse the expression up to the ] and require an integer or string literal
+func (p *parser) parseBrackets() (QueryNode, error) {
+	if _, err := p.expect(LBRACKET); err != nil {
+		return nil, err
+	}
+	if p.peek().Type == RBRACKET {
+		p.next()
+		return &ArrayIterate{}, nil
+	}
+
+	// The index is an expression, so that a prefix - makes [-1]
+	tok := p.peek()
+	expr, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(RBRACKET); err != nil {
+		return nil, err
+	}
+
+	lit, ok := expr.(*Literal)
+	if !ok {
+		return nil, p.errorAt(tok, "index must be a number or a string")
+	}
+	switch v := lit.Value.(type) {
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > math.MaxInt32 {
+			return nil, p.errorAt(tok, "invalid array index %v", v)
+		}
+		return &ArrayIndex{Index: int(v)}, nil
+	case string:
+		return &FieldSelect{Field: v}, nil
+	}
+	return nil, p.errorAt(tok, "index must be a number or a string")
+}
+
+// parseFunction parses a literal name or a call of a builtin function, with
+// its arguments separated by semicolons.
+//
+//starter:todo Look the name up in builtins, then parse its arguments, separated by semicolons, and check their number
+func (p *parser) parseFunction(name Token) (QueryNode, error) {
+	switch name.Value {
+	case "true":
+		return &Literal{Value: true}, nil
+	case "false":
+		return &Literal{Value: false}, nil
+	case "null":
+		return &Literal{Value: nil}, nil
+	}
+
+	b, ok := builtins[name.Value]
+	if !ok {
+		if infix(name) > 0 {
+			return nil, p.unexpected(name)
+		}
+		return nil, p.errorAt(name, "unknown function %q", name.Value)
+	}
+
+	var args []QueryNode
+	if p.peek().Type == LPAREN {
+		p.next()
+		for {
+			arg, err := p.parseExpression(0)
+			if err != nil {
+				return nil, err
 			}
-		} else if expr[i] == ' ' {
-			i++
-		} else {
-			// Check for "length"
-			if strings.HasPrefix(expr[i:], "length") {
-				nodes = append(nodes, &LengthOp{})
-				i += 6
-			} else {
-				return nil, fmt.Errorf("unexpected character at position %d: %c", i, expr[i])
+			args = append(args, arg)
+			if p.peek().Type != SEMICOLON {
+				break
 			}
+			p.next()
+		}
+		if _, err := p.expect(RPAREN); err != nil {
+			return nil, err
 		}
 	}
 
-	return nodes, nil
+	if len(args) != b.arity {
+		return nil, p.errorAt(name, "%s takes %d argument%s, got %d", name.Value, b.arity, plural(b.arity), len(args))
+	}
+	return &Call{Name: name.Value, Args: args}, nil
 }
 
-func (q *Query) Execute(data interface{}) (interface{}, error) {
-	result := data
-	for _, node := range q.nodes {
-		var err error
-		result, err = node.Execute(result)
+// plural returns "" for one and "s" otherwise.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// parseObjectEntry parses name: value, "name": value, (key): value or the
+// shorthand name.
+//
+//starter:todo Parse the key, then an optional colon and value; a bare name is short for name: .name
+func (p *parser) parseObjectEntry() (ObjectEntry, error) {
+	var key QueryNode
+	switch tok := p.next(); tok.Type {
+	case IDENT, STRING:
+		key = &Literal{Value: tok.Value}
+		// {name} is short for {name: .name}
+		if next := p.peek().Type; next == COMMA || next == RBRACE {
+			return ObjectEntry{Key: key, Value: &FieldSelect{Field: tok.Value}}, nil
+		}
+	case LPAREN:
+		expr, err := p.parseExpression(0)
 		if err != nil {
-			return nil, err
+			return ObjectEntry{}, err
 		}
+		if _, err := p.expect(RPAREN); err != nil {
+			return ObjectEntry{}, err
+		}
+		key = expr
+	default:
+		return ObjectEntry{}, p.errorAt(tok, "expected an object key, got %s", tok)
+	}
+
+	if _, err := p.expect(COLON); err != nil {
+		return ObjectEntry{}, err
+	}
+	value, err := p.parseExpression(commaPower)
+	if err != nil {
+		return ObjectEntry{}, err
+	}
+	return ObjectEntry{Key: key, Value: value}, nil
+}
+
+// pipe chains two nodes, dropping identities.
+func pipe(left, right QueryNode) QueryNode {
+	if _, ok := left.(*Identity); ok {
+		return right
+	}
+	return &Pipe{Left: left, Right: right}
+}
+
+// Run returns every output of the query on data, in order.
+func (q *Query) Run(data interface{}) ([]interface{}, error) {
+	return q.root.Execute(data)
+}
+
+// Execute runs a query that produces a single value, such as a path without
+// [], and returns that value.
+func (q *Query) Execute(data interface{}) (interface{}, error) {
+	results, err := q.Run(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("query produced %d results, expected 1", len(results))
 	}
-	return result, nil
+	return results[0], nil
 }
@@ -0,0 +1,431 @@
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// Stream runs the query on the next JSON value of dec and calls emit with
+// each result. The path the query starts with, such as .users[] in
+// .users[] | select(.age > 18), is followed token by token: values off the
+// path are skipped without being decoded and the values on it are decoded
+// one at a time, so a huge document never has to fit in memory. The rest of
+// the query runs on each of them. Stream returns io.EOF when dec has no more
+// values.
+//
+// Iterating over an object decodes the whole object, as its values are
+// emitted in key order. The value of a key on the path is only followed once
+// its object ends, as a later repeat of the key replaces it, like with
+// json.Unmarshal: until then it is kept as JSON text, in a temporary file
+// once it outgrows spoolLimit.
+func (q *Query) Stream(dec *json.Decoder, emit func(result interface{}) error) error {
+	steps, rest := splitPath(q.root)
+	s := &streamer{dec: dec, rest: rest, emit: emit}
+	return s.walk(steps)
+}
+
+// splitPath splits a query into the .field, [n] and [] steps it starts with
+// and the rest, nil if there is none.
+func splitPath(root QueryNode) (steps []QueryNode, rest QueryNode) {
+	stages := flatten(root)
+	for i, stage := range stages {
+		switch step := stage.(type) {
+		case *Identity:
+			continue
+		case *FieldSelect:
+			if step.Field == "length" {
+				// .length measures arrays, which the stream never decodes
+				return steps, chain(stages[i:])
+			}
+		case *ArrayIndex:
+			if step.Index < 0 {
+				// Counting from the end needs the length of the array
+				return steps, chain(stages[i:])
+			}
+		case *ArrayIterate:
+		default:
+			return steps, chain(stages[i:])
+		}
+		steps = append(steps, stage)
+	}
+	return steps, nil
+}
+
+// flatten returns the filters a pipe runs one after the other.
+func flatten(node QueryNode) []QueryNode {
+	if p, ok := node.(*Pipe); ok {
+		return append(flatten(p.Left), flatten(p.Right)...)
+	}
+	return []QueryNode{node}
+}
+
+// chain pipes filters one after the other, nil for none.
+func chain(nodes []QueryNode) QueryNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	node := nodes[len(nodes)-1]
+	for i := len(nodes) - 2; i >= 0; i-- {
+		node = &Pipe{Left: nodes[i], Right: node}
+	}
+	return node
+}
+
+// streamer follows a path through the tokens of one JSON value.
+type streamer struct {
+	dec     *json.Decoder
+	rest    QueryNode
+	emit    func(interface{}) error
+	started bool // whether a token of the value was read
+}
+
+// token reads the next token. The end of the input is only io.EOF before
+// the value starts.
+func (s *streamer) token() (json.Token, error) {
+	tok, err := s.dec.Token()
+	if errors.Is(err, io.EOF) && s.started {
+		err = io.ErrUnexpectedEOF
+	}
+	s.started = true
+	return tok, err
+}
+
+// decode reads the next whole value.
+func (s *streamer) decode() (interface{}, error) {
+	var v interface{}
+	err := s.dec.Decode(&v)
+	if errors.Is(err, io.EOF) && s.started {
+		err = io.ErrUnexpectedEOF
+	}
+	s.started = true
+	return v, err
+}
+
+// walk follows steps from the next value of the decoder.
+//
+//starter:todo Decode the value when no steps are left, otherwise follow the first step token by token
+func (s *streamer) walk(steps []QueryNode) error {
+	if len(steps) == 0 {
+		v, err := s.decode()
+		if err != nil {
+			return err
+		}
+		return s.output(v)
+	}
+
+	tok, err := s.token()
+	if err != nil {
+		return err
+	}
+	switch step := steps[0].(type) {
+	case *FieldSelect:
+		if tok == json.Delim('{') {
+			return s.field(step.Field, steps[1:])
+		}
+	case *ArrayIndex:
+		if tok == json.Delim('[') {
+			return s.index(step.Index, steps[1:])
+		}
+	case *ArrayIterate:
+		switch tok {
+		case json.Delim('['):
+			return s.iterate(steps[1:])
+		case json.Delim('{'):
+			return s.iterateObject(steps[1:])
+		}
+	}
+
+	// The step does not descend into the value: a scalar token is the whole
+	// value, such as null whose fields are null, and a step that cannot
+	// apply to an object or array reports why, as it would on the value
+	v := interface{}(tok)
+	switch tok {
+	case json.Delim('{'):
+		v = map[string]interface{}(nil)
+	case json.Delim('['):
+		v = []interface{}(nil)
+	}
+	results, err := steps[0].Execute(v)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := s.run(result, steps[1:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field follows steps from the value of a key, after the { of an object. A
+// missing key is null, and a repeated key has its last value.
+//
+//starter:todo Spool the value of the key, replaced by any later repeat, and walk it once the object ends
+func (s *streamer) field(name string, steps []QueryNode) error {
+	var value *spool // the key's last value
+	defer func() {
+		if value != nil {
+			value.Close()
+		}
+	}()
+	for s.dec.More() {
+		key, err := s.token()
+		if err != nil {
+			return err
+		}
+		if key != name {
+			if err := s.skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if value != nil {
+			value.Close()
+		}
+		value = &spool{}
+		if err := s.copy(value); err != nil {
+			return err
+		}
+	}
+	if _, err := s.token(); err != nil {
+		return err
+	}
+
+	if value == nil {
+		return s.run(nil, steps)
+	}
+	r, err := value.reader()
+	if err != nil {
+		return err
+	}
+	sub := &streamer{dec: json.NewDecoder(r), rest: s.rest, emit: s.emit, started: true}
+	return sub.walk(steps)
+}
+
+// index follows steps from an element, after the [ of an array.
+//
+//starter:todo Skip the elements before the index, walk the element at it and skip the rest
+func (s *streamer) index(index int, steps []QueryNode) error {
+	n := 0
+	for ; s.dec.More(); n++ {
+		var err error
+		if n == index {
+			err = s.walk(steps)
+		} else {
+			err = s.skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := s.token(); err != nil {
+		return err
+	}
+
+	if index < 0 || index >= n {
+		return indexError(index, n)
+	}
+	return nil
+}
+
+// iterate follows steps from every element, after the [ of an array.
+//
+//starter:todo Walk every element until the closing ]
+func (s *streamer) iterate(steps []QueryNode) error {
+	for s.dec.More() {
+		if err := s.walk(steps); err != nil {
+			return err
+		}
+	}
+	_, err := s.token()
+	return err
+}
+
+// iterateObject decodes an object after its { and runs steps on its values,
+// in the order [] emits them.
+//
+//starter:todo Decode the rest of the object and run the steps on its values in key order
+func (s *streamer) iterateObject(steps []QueryNode) error {
+	object := make(map[string]interface{})
+	for s.dec.More() {
+		key, err := s.token()
+		if err != nil {
+			return err
+		}
+		value, err := s.decode()
+		if err != nil {
+			return err
+		}
+		object[key.(string)] = value
+	}
+	if _, err := s.token(); err != nil {
+		return err
+	}
+
+	values, err := (&ArrayIterate{}).Execute(object)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if err := s.run(v, steps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copy writes the next value to w as JSON text, reading it token by token.
+//
+//starter:todo Re-encode the tokens of the next value, keeping track of commas and colons
+func (s *streamer) copy(w io.Writer) error {
+	type container struct {
+		object  bool
+		keyNext bool // whether the next token of an object is a key
+	}
+	var open []*container
+	bw := bufio.NewWriter(w)
+	for {
+		tok, err := s.token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			open = append(open, &container{object: tok == json.Delim('{'), keyNext: tok == json.Delim('{')})
+			bw.WriteString(tok.(json.Delim).String())
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			open = open[:len(open)-1]
+			bw.WriteString(tok.(json.Delim).String())
+		default:
+			text, err := json.Marshal(tok)
+			if err != nil {
+				return err
+			}
+			bw.Write(text)
+			if n := len(open); n > 0 && open[n-1].keyNext {
+				open[n-1].keyNext = false
+				bw.WriteByte(':')
+				continue
+			}
+		}
+
+		// A value ended
+		if len(open) == 0 {
+			return bw.Flush()
+		}
+		if s.dec.More() {
+			bw.WriteByte(',')
+			open[len(open)-1].keyNext = open[len(open)-1].object
+		}
+	}
+}
+
+// spoolLimit is the size up to which a spool keeps its text in memory. It
+// is a variable for the tests.
+var spoolLimit = 1 << 20
+
+// spool holds the JSON text of a value in memory, or in a temporary file
+// once it outgrows spoolLimit, so a huge value need not fit in memory.
+type spool struct {
+	buf  bytes.Buffer
+	file *os.File
+}
+
+func (sp *spool) Write(p []byte) (int, error) {
+	if sp.file == nil && sp.buf.Len()+len(p) > spoolLimit {
+		file, err := os.CreateTemp("", "jq-stream-*.json")
+		if err != nil {
+			return 0, err
+		}
+		sp.file = file
+		if _, err := sp.buf.WriteTo(file); err != nil {
+			return 0, err
+		}
+	}
+	if sp.file != nil {
+		return sp.file.Write(p)
+	}
+	return sp.buf.Write(p)
+}
+
+// reader returns a reader of the text written to the spool.
+func (sp *spool) reader() (io.Reader, error) {
+	if sp.file == nil {
+		return &sp.buf, nil
+	}
+	if _, err := sp.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return sp.file, nil
+}
+
+// Close removes the temporary file, if any.
+func (sp *spool) Close() error {
+	if sp.file == nil {
+		return nil
+	}
+	sp.file.Close()
+	return os.Remove(sp.file.Name())
+}
+
+// skip reads past the next value without decoding it.
+//
+//starter:todo Read tokens until the value that started ends
+func (s *streamer) skip() error {
+	depth := 0
+	for {
+		tok, err := s.token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// run executes steps on a decoded value and outputs the results.
+func (s *streamer) run(v interface{}, steps []QueryNode) error {
+	results := []interface{}{v}
+	if node := chain(steps); node != nil {
+		var err error
+		if results, err = node.Execute(v); err != nil {
+			return err
+		}
+	}
+	for _, result := range results {
+		if err := s.output(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// output runs the rest of the query on a value the path selected and emits
+// the results.
+func (s *streamer) output(v interface{}) error {
+	if s.rest == nil {
+		return s.emit(v)
+	}
+	results, err := s.rest.Execute(v)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := s.emit(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
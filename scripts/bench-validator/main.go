@@ -0,0 +1,311 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/bench"
+	"exercise/console"
+	"exercise/gotest"
+	"exercise/report"
+	"exercise/sandbox"
+)
+
+// Command-line flags
+var (
+	verbose        bool
+	outputFile     string
+	noColor        bool
+	parallel       int
+	reportFormat   string
+	reportFile     string
+	benchTime      string
+	benchCount     int
+	benchTimeout   time.Duration
+	baselineFile   string
+	updateBaseline bool
+	maxSlowdown    float64
+)
+
+type TestResult struct {
+	console.Result
+	Results     map[string]bench.Result // nil when the benchmarks did not run
+	Comparisons []bench.Comparison
+	Regressions []string // benchmarks slower than their baseline
+}
+
+type BenchValidator struct {
+	printer   console.Printer
+	baselines bench.Baselines
+}
+
+func NewBenchValidator(verbose bool, baselines bench.Baselines) *BenchValidator {
+	return &BenchValidator{printer: console.Printer{Verbose: verbose}, baselines: baselines}
+}
+
+func (bv *BenchValidator) printResult(result TestResult) {
+	bv.printer.Print(result.Result, result.Message)
+}
+
+// benchPattern returns a -bench pattern matching exactly the benchmarks of
+// the exercise's pairs.
+func benchPattern(pairs []exercise.BenchmarkPair) string {
+	names := make([]string, 0, 2*len(pairs))
+	for _, p := range pairs {
+		for _, name := range []string{p.Baseline, p.Optimized} {
+			if name = regexp.QuoteMeta(name); !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return "^(" + strings.Join(names, "|") + ")$"
+}
+
+// benchExercise runs the exercise's paired benchmarks against its reference
+// solution and checks every optimized benchmark beats its baseline.
+func (bv *BenchValidator) benchExercise(ex exercise.Exercise) TestResult {
+	start := time.Now()
+	result := TestResult{Result: console.Result{
+		Exercise: ex.Path,
+		Logs:     []string{fmt.Sprintf("🔄 Benchmarking %s...", ex.Path)},
+	}}
+	fail := func(message string, logs ...string) TestResult {
+		result.Status = "fail"
+		result.Message = message
+		result.Logs = append(result.Logs, "  ❌ "+message)
+		result.Logs = append(result.Logs, logs...)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if info, err := os.Stat(ex.SolutionDir()); err != nil || !info.IsDir() {
+		return fail("No solution/ to benchmark")
+	}
+
+	// Benchmarks run in a staged copy of the exercise holding the solution
+	tmpDir, err := os.MkdirTemp("", "bench-validator")
+	if err != nil {
+		return fail("Staging failed", err.Error())
+	}
+	defer os.RemoveAll(tmpDir)
+	staged := filepath.Join(tmpDir, filepath.Base(ex.Dir))
+	if err := ex.StageSolution(staged); err != nil {
+		return fail("Staging failed", err.Error())
+	}
+
+	result.Logs = append(result.Logs, fmt.Sprintf("  ⏱️  Running %d benchmark pairs (-count %d, -benchtime %s)...",
+		len(ex.Benchmarks), benchCount, benchTime))
+	args := []string{
+		"-run", "^$", "-bench", benchPattern(ex.Benchmarks), "-benchmem",
+		"-benchtime", benchTime, "-count", fmt.Sprintf("%d", benchCount),
+		"-timeout", benchTimeout.String(), "./...",
+	}
+	run, err := gotest.TestWithLimits(staged, sandbox.Limits{Timeout: benchTimeout + time.Minute}, args...)
+	if err != nil {
+		return fail("Benchmarks failed", run.Output)
+	}
+	result.Results = bench.Parse(run.Output)
+
+	failed := 0
+	for _, pair := range ex.Benchmarks {
+		c, err := bench.Compare(pair, result.Results)
+		if err != nil {
+			failed++
+			result.Logs = append(result.Logs, "  ❌ "+err.Error())
+			continue
+		}
+		result.Comparisons = append(result.Comparisons, c)
+		if !c.OK() {
+			failed++
+			result.Logs = append(result.Logs, "  ❌ "+c.String())
+		} else {
+			result.Logs = append(result.Logs, "  ✅ "+c.String())
+		}
+	}
+	if failed > 0 {
+		// The comparisons above already explain the failure
+		result.Status = "fail"
+		result.Message = fmt.Sprintf("%d of %d optimized benchmarks do not beat their baseline", failed, len(ex.Benchmarks))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Timings depend on the machine, so a regression is only a warning
+	result.Regressions = bv.baselines.Regressions(ex.Path, result.Results, maxSlowdown/100)
+	for _, r := range result.Regressions {
+		result.Logs = append(result.Logs, "  🐢 "+r)
+	}
+
+	result.Status = "pass"
+	result.Message = fmt.Sprintf("%d benchmark pairs", len(ex.Benchmarks))
+	if len(result.Regressions) > 0 {
+		result.Status = "warn"
+		result.Message = fmt.Sprintf("%d benchmarks slower than their baseline", len(result.Regressions))
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// buildReport converts results into a machine-readable report.
+func buildReport(results []TestResult, elapsed time.Duration) *report.Report {
+	exercises := make([]report.Exercise, 0, len(results))
+	for _, result := range results {
+		var benchmarks []report.Benchmark
+		for _, c := range result.Comparisons {
+			benchmarks = append(benchmarks, report.Benchmark{
+				Baseline:       c.Pair.Baseline,
+				Optimized:      c.Pair.Optimized,
+				Metric:         c.Pair.MetricOrDefault(),
+				BaselineValue:  c.Baseline,
+				OptimizedValue: c.Optimized,
+				MinRatio:       c.Pair.MinRatioOrDefault(),
+				Passed:         c.OK(),
+			})
+		}
+		exercises = append(exercises, report.Exercise{
+			Name:        result.Exercise,
+			Status:      result.Status,
+			Message:     result.Message,
+			Duration:    result.Duration.Seconds(),
+			Benchmarks:  benchmarks,
+			Regressions: result.Regressions,
+		})
+	}
+	return report.New("bench", elapsed, exercises)
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "Show every benchmark comparison (verbose mode)")
+	flag.BoolVar(&verbose, "verbose", false, "Show every benchmark comparison (verbose mode)")
+	flag.StringVar(&outputFile, "output", "", "Write failed exercises to file")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.IntVar(&parallel, "parallel", 1, "Number of exercises to benchmark concurrently (more skews timings)")
+	flag.StringVar(&reportFormat, "format", "text", "Report format: text, json, or junit")
+	flag.StringVar(&reportFile, "report", "", "Write the json/junit report to file (default: report.json or report.xml)")
+	flag.StringVar(&benchTime, "benchtime", "200ms", "Run time of each benchmark, passed to go test -benchtime")
+	flag.IntVar(&benchCount, "count", 5, "Runs of each benchmark; the median is compared")
+	flag.DurationVar(&benchTimeout, "timeout", 5*time.Minute, "Timeout for the benchmarks of one exercise")
+	flag.StringVar(&baselineFile, "baseline", "", "Baseline file (default: "+bench.DefaultBaselineFile+" in the repo root)")
+	flag.BoolVar(&updateBaseline, "update-baseline", false, "Replace the stored baselines with this run's results")
+	flag.Float64Var(&maxSlowdown, "max-slowdown", 25, "Percent a benchmark may get slower than its baseline before it is reported")
+	flag.Parse()
+
+	if parallel < 1 || benchCount < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -parallel and -count must be at least 1")
+		os.Exit(1)
+	}
+	if reportFormat != "text" && !slices.Contains(report.Formats, reportFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unknown -format %q (want text, json, or junit)\n", reportFormat)
+		os.Exit(1)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	// When running from scripts/bench-validator, go up two levels to project root
+	repoRoot := filepath.Join("..", "..")
+	if flag.NArg() > 0 {
+		repoRoot = flag.Arg(0)
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving repo root: %v\n", err)
+		os.Exit(1)
+	}
+	if baselineFile == "" {
+		baselineFile = filepath.Join(absRoot, bench.DefaultBaselineFile)
+	}
+
+	baselines, err := bench.LoadBaselines(baselineFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading baselines: %v\n", err)
+		os.Exit(1)
+	}
+
+	console.Banner("CI Benchmark Validation (Go Edition)")
+
+	// Find exercises whose manifest pairs benchmarks
+	fmt.Println(console.Dim.Render("🔍 Finding exercises..."))
+	discovered, err := exercise.Discover(absRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+		os.Exit(1)
+	}
+	exercises := make([]exercise.Exercise, 0)
+	for _, ex := range discovered {
+		if len(ex.Benchmarks) > 0 {
+			exercises = append(exercises, ex)
+		}
+	}
+
+	fmt.Printf("\nBenchmarking %s solutions (%d concurrent)...\n",
+		console.Title.Render(fmt.Sprintf("%d", len(exercises))), parallel)
+	fmt.Println(console.Dim.Render("Each exercise: run paired benchmarks against the solution → compare optimized to baseline → compare to stored baselines"))
+	fmt.Println()
+
+	startTime := time.Now()
+	validator := NewBenchValidator(verbose, baselines)
+
+	// Results are printed in discovery order as soon as they are available
+	allResults := exercise.RunParallel(exercises, parallel, validator.benchExercise, validator.printResult)
+
+	elapsed := time.Since(startTime)
+
+	// Store this run's results for exercises without a baseline, or for all
+	// of them when asked to
+	stored := 0
+	for _, result := range allResults {
+		if result.Results == nil || result.Status == "fail" {
+			continue
+		}
+		if _, ok := baselines[result.Exercise]; ok && !updateBaseline {
+			continue
+		}
+		baselines[result.Exercise] = result.Results
+		stored++
+	}
+	if stored > 0 {
+		if err := baselines.Save(baselineFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving baselines: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Print summary
+	var summary console.Summary
+	for _, result := range allResults {
+		result.Details = result.Regressions
+		summary.Add(result.Result)
+	}
+	var extra []string
+	if stored > 0 {
+		extra = append(extra, fmt.Sprintf("Baseline: %s", console.Dim.Render(fmt.Sprintf("%d exercises stored in %s", stored, baselineFile))))
+	}
+	summary.Print(elapsed, extra...)
+	summary.WriteOutput(outputFile, "Exercises")
+
+	// Write machine-readable report if requested
+	if err := console.WriteReport(buildReport(allResults, elapsed), reportFormat, reportFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(summary.Failed) == 0 {
+		fmt.Println(console.Success.Render("✅ All benchmark validations passed"))
+		os.Exit(0)
+	} else {
+		fmt.Println(console.Fail.Render("❌ Some benchmark validations failed"))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"exercise"
+	"exercise/console"
+	"exercise/funcdiff"
+	"exercise/progress"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Command-line flags
+var (
+	verbose      bool
+	noColor      bool
+	width        int
+	explainLines int
+	repoRoot     string
+	historyFile  string
+)
+
+// rowStyles color the lines of a side-by-side diff, with their marker.
+var rowStyles = map[funcdiff.Kind]struct {
+	marker string
+	style  *lipgloss.Style
+}{
+	funcdiff.Same:    {" ", &console.Dim},
+	funcdiff.Removed: {"-", &console.Fail},
+	funcdiff.Added:   {"+", &console.Success},
+	funcdiff.Changed: {"~", &console.Warn},
+}
+
+// terminalWidth returns the width to render diffs at: -width, else $COLUMNS,
+// else 120.
+func terminalWidth() int {
+	if width > 0 {
+		return width
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 120
+}
+
+// fit expands tabs and pads or truncates a line to n columns.
+func fit(line string, n int) string {
+	line = strings.ReplaceAll(line, "\t", "    ")
+	if count := utf8.RuneCountInString(line); count <= n {
+		return line + strings.Repeat(" ", n-count)
+	}
+	runes := []rune(line)
+	return string(runes[:n-1]) + "…"
+}
+
+// printRows shows a side-by-side diff, the student's code on the left.
+func printRows(rows []funcdiff.Row) {
+	col := max((terminalWidth()-7)/2, 20)
+	fmt.Println(console.Dim.Render("  " + fit("Yours", col) + " │ Reference"))
+	for _, row := range rows {
+		rs := rowStyles[row.Kind]
+		line := rs.marker + " " + fit(row.Left, col) + " │ " + fit(row.Right, col)
+		fmt.Println(rs.style.Render(strings.TrimRight(line, " ")))
+	}
+}
+
+// printExplanation shows the EXPLANATION.md section about a function,
+// limited to -lines lines unless verbose.
+func printExplanation(sections []funcdiff.Section, fn funcdiff.Func) {
+	section, ok := funcdiff.Relevant(sections, fn)
+	if !ok {
+		return
+	}
+	fmt.Println()
+	fmt.Println(console.Title.Render("📖 " + funcdiff.ExplanationFile + " › " + section.Title()))
+
+	lines := strings.Split(section.Body, "\n")
+	if section.Body == "" {
+		lines = nil
+	}
+	shown := lines
+	if !verbose && explainLines > 0 && len(lines) > explainLines {
+		shown = lines[:explainLines]
+	}
+	for _, line := range shown {
+		fmt.Println("   " + line)
+	}
+	if len(shown) < len(lines) {
+		fmt.Println(console.Dim.Render(fmt.Sprintf("   … %d more lines (-v shows all)", len(lines)-len(shown))))
+	}
+}
+
+// printPair shows how one function compares with the reference.
+func printPair(pair funcdiff.Pair, sections []funcdiff.Section) {
+	switch {
+	case pair.Same():
+		fmt.Println(console.Success.Render("✅ " + pair.Name + " matches the reference"))
+	case pair.Student == nil:
+		fmt.Println(console.Warn.Render("➕ " + pair.Name + " is only in the reference"))
+		printRows(funcdiff.Lines("", pair.Solution.Source))
+	case pair.Solution == nil:
+		fmt.Println(console.Dim.Render("➖ " + pair.Name + " is only in your code"))
+		if verbose {
+			printRows(funcdiff.Lines(pair.Student.Source, ""))
+		}
+	default:
+		fmt.Println(console.Warn.Render("🔀 " + pair.Name + " differs from the reference"))
+		printRows(funcdiff.Lines(pair.Student.Source, pair.Solution.Source))
+	}
+
+	if pair.Solution != nil {
+		printExplanation(sections, *pair.Solution)
+	}
+	fmt.Println()
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "Show explanations in full and functions only in your code")
+	flag.BoolVar(&verbose, "verbose", false, "Show explanations in full and functions only in your code")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.IntVar(&width, "width", 0, "Output width (default: $COLUMNS, or 120)")
+	flag.IntVar(&explainLines, "lines", 12, "Lines of each explanation shown, 0 for all")
+	flag.StringVar(&repoRoot, "root", filepath.Join("..", ".."), "Repository root")
+	flag.StringVar(&historyFile, "history", "", "Progress history file (default: "+progress.DefaultFile+" in the repo root)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: compare [flags] <exercise> [function...]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Compares your functions with the reference solution, side by side, with the\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "part of %s that explains each one.\n\n", funcdiff.ExplanationFile)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	ex, err := exercise.Open(repoRoot, flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if historyFile == "" {
+		historyFile = filepath.Join(repoRoot, progress.DefaultFile)
+	}
+
+	student, err := funcdiff.Load(ex.Dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading your code: %v\n", err)
+		os.Exit(1)
+	}
+	solution, err := funcdiff.Load(ex.SolutionDir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading the solution: %v\n", err)
+		os.Exit(1)
+	}
+	if len(solution) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %s has no reference solution\n", ex.Path)
+		os.Exit(1)
+	}
+	sections, err := funcdiff.LoadSections(ex.SolutionDir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", funcdiff.ExplanationFile, err)
+		os.Exit(1)
+	}
+
+	// Only the functions asked for, when any are
+	pairs := funcdiff.Align(student, solution)
+	if names := flag.Args()[1:]; len(names) > 0 {
+		pairs = slices.DeleteFunc(pairs, func(p funcdiff.Pair) bool {
+			return !slices.Contains(names, p.Name)
+		})
+		if len(pairs) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no function named %s\n", strings.Join(names, " or "))
+			os.Exit(1)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(console.Title.Render("🔎 Comparing " + ex.Path + " - " + ex.Title + " with the reference"))
+	fmt.Println()
+
+	// A missing history is an empty one, so any error is worth reporting
+	history, err := progress.Load(historyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+	if !history.Completed(ex.Path) {
+		fmt.Println(console.Warn.Render("⚠️  Your latest recorded test run did not pass: this shows the solution"))
+		fmt.Println()
+	}
+
+	same, differ, missing, extra := 0, 0, 0, 0
+	for _, pair := range pairs {
+		printPair(pair, sections)
+		switch {
+		case pair.Same():
+			same++
+		case pair.Student == nil:
+			missing++
+		case pair.Solution == nil:
+			extra++
+		default:
+			differ++
+		}
+	}
+
+	fmt.Printf("%d functions: %s, %s, %s, %s\n", len(pairs),
+		console.Success.Render(fmt.Sprintf("%d match", same)),
+		console.Warn.Render(fmt.Sprintf("%d differ", differ)),
+		console.Warn.Render(fmt.Sprintf("%d only in the reference", missing)),
+		console.Dim.Render(fmt.Sprintf("%d only in yours", extra)))
+	fmt.Println()
+}
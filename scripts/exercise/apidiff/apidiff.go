@@ -0,0 +1,180 @@
+// Package apidiff compares the exported API of starter packages with their
+// reference solutions.
+//
+// Tests are shared between the starter code and the solution, so every
+// exported function, type, method, field, constant and variable the tests may
+// use must exist with the same signature on both sides.
+package apidiff
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Difference is an exported declaration, or a whole package, that is missing
+// on one side or declared differently.
+type Difference struct {
+	Package  string // package directory relative to the exercise or solution, e.g. "." or "query"
+	Name     string // e.g. "Reverse", "Stack.Push"; empty for a missing package
+	Starter  string // declaration in the starter, "" when missing
+	Solution string // declaration in the solution, "" when missing
+}
+
+func (d Difference) String() string {
+	where := d.Package
+	if d.Name != "" {
+		where += ": " + d.Name
+	}
+	switch {
+	case d.Starter == "":
+		return fmt.Sprintf("%s only in solution: %s", where, d.Solution)
+	case d.Solution == "":
+		return fmt.Sprintf("%s only in starter: %s", where, d.Starter)
+	default:
+		return fmt.Sprintf("%s differs:\n    starter:  %s\n    solution: %s", where, d.Starter, d.Solution)
+	}
+}
+
+// Compare reports the differences between the exported API of the starter and
+// solution packages, keyed by package directory. The result is sorted by
+// package and name.
+func Compare(starter, solution map[string]*types.Package) []Difference {
+	var diffs []Difference
+
+	for dir, sp := range starter {
+		if _, ok := solution[dir]; !ok {
+			diffs = append(diffs, Difference{Package: dir, Starter: "package " + sp.Name()})
+		}
+	}
+	for dir, pkg := range solution {
+		sp, ok := starter[dir]
+		if !ok {
+			diffs = append(diffs, Difference{Package: dir, Solution: "package " + pkg.Name()})
+			continue
+		}
+
+		want, got := API(sp), API(pkg)
+		for name, decl := range want {
+			if other, ok := got[name]; !ok {
+				diffs = append(diffs, Difference{Package: dir, Name: name, Starter: decl})
+			} else if other != decl {
+				diffs = append(diffs, Difference{Package: dir, Name: name, Starter: decl, Solution: other})
+			}
+		}
+		for name, decl := range got {
+			if _, ok := want[name]; !ok {
+				diffs = append(diffs, Difference{Package: dir, Name: name, Solution: decl})
+			}
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Package != diffs[j].Package {
+			return diffs[i].Package < diffs[j].Package
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// API returns the exported declarations of pkg, keyed by name ("Stack",
+// "Stack.Push", "Config.Timeout"). Declarations are rendered without import
+// paths, so identical APIs in differently named modules compare equal.
+func API(pkg *types.Package) map[string]string {
+	// Qualify by package name only: the starter and the solution have
+	// different import paths for the same packages
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+	typeString := func(t types.Type) string { return types.TypeString(t, qualifier) }
+
+	api := make(map[string]string)
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+
+		switch obj := obj.(type) {
+		case *types.Func:
+			api[name] = "func " + name + strings.TrimPrefix(typeString(obj.Type()), "func")
+		case *types.Const:
+			api[name] = "const " + name + " " + typeString(obj.Type())
+		case *types.Var:
+			api[name] = "var " + name + " " + typeString(obj.Type())
+		case *types.TypeName:
+			addType(api, obj, typeString)
+		}
+	}
+	return api
+}
+
+// addType adds a type declaration with its exported fields and methods.
+func addType(api map[string]string, obj *types.TypeName, typeString func(types.Type) string) {
+	name := obj.Name()
+	if obj.IsAlias() {
+		api[name] = "type " + name + " = " + typeString(types.Unalias(obj.Type()))
+		return
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return
+	}
+	decl := "type " + name + typeParams(named.TypeParams(), typeString)
+
+	switch u := named.Underlying().(type) {
+	case *types.Struct:
+		api[name] = decl + " struct"
+		for i := 0; i < u.NumFields(); i++ {
+			if f := u.Field(i); f.Exported() {
+				api[name+"."+f.Name()] = "field " + f.Name() + " " + typeString(f.Type())
+			}
+		}
+	case *types.Interface:
+		api[name] = decl + " interface"
+		for i := 0; i < u.NumMethods(); i++ {
+			if m := u.Method(i); m.Exported() {
+				api[name+"."+m.Name()] = "func " + m.Name() + strings.TrimPrefix(typeString(m.Type()), "func")
+			}
+		}
+		return
+	default:
+		api[name] = decl + " " + typeString(u)
+	}
+
+	// Methods callable on a *T, marking those that a T value has too, since
+	// pointer and value receivers decide which interfaces T satisfies
+	values := types.NewMethodSet(named)
+	pointers := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < pointers.Len(); i++ {
+		m := pointers.At(i).Obj()
+		if !m.Exported() {
+			continue
+		}
+		recv := "(*" + name + ")"
+		if values.Lookup(m.Pkg(), m.Name()) != nil {
+			recv = "(" + name + ")"
+		}
+		api[name+"."+m.Name()] = "func " + recv + " " + m.Name() + strings.TrimPrefix(typeString(m.Type()), "func")
+	}
+}
+
+// typeParams renders a type parameter list such as "[K comparable, V any]".
+func typeParams(params *types.TypeParamList, typeString func(types.Type) string) string {
+	if params.Len() == 0 {
+		return ""
+	}
+	list := make([]string, 0, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		p := params.At(i)
+		list = append(list, p.Obj().Name()+" "+typeString(p.Constraint()))
+	}
+	return "[" + strings.Join(list, ", ") + "]"
+}
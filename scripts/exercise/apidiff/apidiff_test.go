@@ -0,0 +1,123 @@
+package apidiff
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func check(t *testing.T, path, src string) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check(path, fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+const starterSrc = `package main
+
+import "errors"
+
+var ErrEmpty = errors.New("empty")
+
+type Stack[T any] struct {
+	Name  string
+	items []T
+}
+
+func (s *Stack[T]) Push(v T) {}
+func (s Stack[T]) Len() int  { return 0 }
+
+type Shape interface{ Area() float64 }
+
+func Reverse(s string) string { return "" }
+func Parse(s string) int      { return 0 }
+func helper()                 {}
+`
+
+const solutionSrc = `package main
+
+import "errors"
+
+var ErrEmpty = errors.New("empty")
+
+type Stack[T any] struct {
+	Name  string
+	items []T
+	count int
+}
+
+func (s *Stack[T]) Push(v T) {}
+func (s *Stack[T]) Len() int { return 0 }
+
+type Shape interface{ Area() float64 }
+
+func Reverse(s string) string       { return "" }
+func Parse(s string) (int, error)   { return 0, nil }
+func Extra()                        {}
+func anotherHelper()                {}
+`
+
+func TestCompare(t *testing.T) {
+	starter := map[string]*types.Package{
+		".":         check(t, "example.com/ex", starterSrc),
+		"formatter": check(t, "example.com/ex/formatter", "package formatter\n\nfunc Format() {}\n"),
+	}
+	solution := map[string]*types.Package{
+		// Different import path, same API: only real differences are reported
+		".": check(t, "example.com/ex/solution", solutionSrc),
+	}
+
+	var got []string
+	for _, d := range Compare(starter, solution) {
+		got = append(got, d.String())
+	}
+	want := []string{
+		".: Extra only in solution: func Extra()",
+		".: Parse differs:\n    starter:  func Parse(s string) int\n    solution: func Parse(s string) (int, error)",
+		".: Stack.Len differs:\n    starter:  func (Stack) Len() int\n    solution: func (*Stack) Len() int",
+		"formatter only in starter: package formatter",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d differences, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("difference %d:\ngot:  %s\nwant: %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAPI(t *testing.T) {
+	api := API(check(t, "example.com/ex", starterSrc))
+
+	want := map[string]string{
+		"ErrEmpty":   "var ErrEmpty error",
+		"Stack":      "type Stack[T any] struct",
+		"Stack.Name": "field Name string",
+		"Stack.Push": "func (*Stack) Push(v T)",
+		"Shape.Area": "func Area() float64",
+		"Reverse":    "func Reverse(s string) string",
+	}
+	for name, decl := range want {
+		if api[name] != decl {
+			t.Errorf("%s: got %q, want %q", name, api[name], decl)
+		}
+	}
+	for _, name := range []string{"helper", "Stack.items"} {
+		if _, ok := api[name]; ok {
+			t.Errorf("unexported %s should not be part of the API", name)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Package bench reads go test benchmark results, checks that optimized
+// benchmarks beat their baselines, and keeps results from earlier runs to
+// spot regressions.
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"exercise"
+)
+
+// Result is the outcome of one benchmark: the median of each metric over
+// its runs.
+type Result struct {
+	Runs        int     `json:"runs"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+}
+
+// Metric returns the value of one of exercise.BenchmarkMetrics.
+func (r Result) Metric(name string) float64 {
+	switch name {
+	case "B/op":
+		return r.BytesPerOp
+	case "allocs/op":
+		return r.AllocsPerOp
+	}
+	return r.NsPerOp
+}
+
+// procsSuffix is the -GOMAXPROCS suffix go test adds to benchmark names.
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+// Parse reads the benchmark result lines of go test output, such as
+//
+//	BenchmarkStringConcat-8   4363768   267.0 ns/op   208 B/op   8 allocs/op
+//
+// Benchmarks run more than once (-count) are reduced to the median of each
+// metric, which a single slow run does not skew. Names are returned without
+// the -GOMAXPROCS suffix.
+func Parse(output string) map[string]Result {
+	runs := make(map[string][][3]float64)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue // not a result line, e.g. a log message
+		}
+
+		var metrics [3]float64
+		found := false
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			if m := slices.Index(exercise.BenchmarkMetrics, fields[i+1]); m >= 0 {
+				metrics[m] = v
+				found = true
+			}
+		}
+		if found {
+			name := procsSuffix.ReplaceAllString(fields[0], "")
+			runs[name] = append(runs[name], metrics)
+		}
+	}
+
+	results := make(map[string]Result, len(runs))
+	for name, rs := range runs {
+		results[name] = Result{
+			Runs:        len(rs),
+			NsPerOp:     median(rs, 0),
+			BytesPerOp:  median(rs, 1),
+			AllocsPerOp: median(rs, 2),
+		}
+	}
+	return results
+}
+
+// median returns the median of metric i over runs.
+func median(runs [][3]float64, i int) float64 {
+	values := make([]float64, len(runs))
+	for j, r := range runs {
+		values[j] = r[i]
+	}
+	sort.Float64s(values)
+	if n := len(values); n%2 == 0 {
+		return (values[n/2-1] + values[n/2]) / 2
+	}
+	return values[len(values)/2]
+}
+
+// Comparison is the outcome of a benchmark pair.
+type Comparison struct {
+	Pair      exercise.BenchmarkPair
+	Baseline  float64 // metric value of the baseline benchmark
+	Optimized float64 // metric value of the optimized benchmark
+}
+
+// Compare looks up both benchmarks of a pair in results.
+func Compare(pair exercise.BenchmarkPair, results map[string]Result) (Comparison, error) {
+	c := Comparison{Pair: pair}
+	baseline, ok := results[pair.Baseline]
+	if !ok {
+		return c, fmt.Errorf("%s did not run", pair.Baseline)
+	}
+	optimized, ok := results[pair.Optimized]
+	if !ok {
+		return c, fmt.Errorf("%s did not run", pair.Optimized)
+	}
+	metric := pair.MetricOrDefault()
+	c.Baseline, c.Optimized = baseline.Metric(metric), optimized.Metric(metric)
+	return c, nil
+}
+
+// Ratio is how many times better the optimized benchmark did. Zero, as in
+// zero allocations, beats any baseline that is not zero itself.
+func (c Comparison) Ratio() float64 {
+	if c.Optimized == 0 {
+		if c.Baseline == 0 {
+			return 1
+		}
+		return math.Inf(1)
+	}
+	return c.Baseline / c.Optimized
+}
+
+// OK reports whether the optimized benchmark beat the baseline by the
+// pair's minimum ratio.
+func (c Comparison) OK() bool {
+	return c.Ratio() >= c.Pair.MinRatioOrDefault()
+}
+
+func (c Comparison) String() string {
+	metric := c.Pair.MetricOrDefault()
+	ratio := "∞"
+	if r := c.Ratio(); !math.IsInf(r, 1) {
+		ratio = fmt.Sprintf("%.2f", r)
+	}
+	return fmt.Sprintf("%s vs %s: %sx in %s (%s vs %s, want ≥ %gx)",
+		c.Pair.Optimized, c.Pair.Baseline, ratio, metric,
+		formatValue(c.Optimized), formatValue(c.Baseline), c.Pair.MinRatioOrDefault())
+}
+
+// formatValue renders a metric value without needless decimals.
+func formatValue(v float64) string {
+	if v >= 100 || v == math.Trunc(v) {
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// DefaultBaselineFile is the baseline file name, relative to the repository
+// root. Timings depend on the machine, so the file is not committed.
+const DefaultBaselineFile = ".benchmarks.json"
+
+// Baselines are stored benchmark results, keyed by exercise path, then
+// benchmark name.
+type Baselines map[string]map[string]Result
+
+// LoadBaselines reads the baseline file at path. A missing file holds no
+// baselines.
+func LoadBaselines(path string) (Baselines, error) {
+	b := make(Baselines)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Save writes the baselines to path.
+func (b Baselines) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Regressions compares an exercise's results with its baseline, in
+// benchmark name order. A benchmark regressed when its ns/op grew by more
+// than maxSlowdown (0.25 for 25%) or it allocates more often per op.
+// Benchmarks without a baseline are not compared.
+func (b Baselines) Regressions(exercisePath string, results map[string]Result, maxSlowdown float64) []string {
+	baseline := b[exercisePath]
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var regressions []string
+	for _, name := range names {
+		prev, ok := baseline[name]
+		if !ok {
+			continue
+		}
+		cur := results[name]
+		if prev.NsPerOp > 0 && cur.NsPerOp > prev.NsPerOp*(1+maxSlowdown) {
+			regressions = append(regressions, fmt.Sprintf("%s: %s ns/op, was %s (+%.0f%%)",
+				name, formatValue(cur.NsPerOp), formatValue(prev.NsPerOp), (cur.NsPerOp/prev.NsPerOp-1)*100))
+		}
+		if cur.AllocsPerOp > prev.AllocsPerOp {
+			regressions = append(regressions, fmt.Sprintf("%s: %s allocs/op, was %s",
+				name, formatValue(cur.AllocsPerOp), formatValue(prev.AllocsPerOp)))
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,99 @@
+package bench
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"exercise"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: example.com/bench
+BenchmarkStringConcat-8     	  704842	       288.3 ns/op	     208 B/op	       8 allocs/op
+BenchmarkStringConcat-8     	  830679	       264.1 ns/op	     208 B/op	       8 allocs/op
+BenchmarkStringConcat-8     	  813159	       267.0 ns/op	     208 B/op	       8 allocs/op
+BenchmarkStringsBuilder-8   	 1799737	       136.4 ns/op	     120 B/op	       4 allocs/op
+BenchmarkStringsBuilder-8   	 1784472	       132.1 ns/op	     120 B/op	       4 allocs/op
+BenchmarkSliceGrower/size=1000	   61014	      3899 ns/op
+BenchmarkOptimizedSliceGrower	  601322	       423.7 ns/op	       0 B/op	       0 allocs/op
+BenchmarkLogging	running with a message, not a result
+PASS
+ok  	example.com/bench	3.012s
+`
+
+func TestParse(t *testing.T) {
+	results := Parse(output)
+
+	want := map[string]Result{
+		"BenchmarkStringConcat":          {Runs: 3, NsPerOp: 267.0, BytesPerOp: 208, AllocsPerOp: 8},
+		"BenchmarkStringsBuilder":        {Runs: 2, NsPerOp: 134.25, BytesPerOp: 120, AllocsPerOp: 4},
+		"BenchmarkSliceGrower/size=1000": {Runs: 1, NsPerOp: 3899},
+		"BenchmarkOptimizedSliceGrower":  {Runs: 1, NsPerOp: 423.7},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Parse() = %+v\nwant %+v", results, want)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	results := Parse(output)
+
+	tests := []struct {
+		pair  exercise.BenchmarkPair
+		ratio float64
+		ok    bool
+	}{
+		{exercise.BenchmarkPair{Baseline: "BenchmarkStringConcat", Optimized: "BenchmarkStringsBuilder"}, 267.0 / 134.25, true},
+		{exercise.BenchmarkPair{Baseline: "BenchmarkStringConcat", Optimized: "BenchmarkStringsBuilder", MinRatio: 3}, 267.0 / 134.25, false},
+		{exercise.BenchmarkPair{Baseline: "BenchmarkStringsBuilder", Optimized: "BenchmarkStringConcat", Metric: "allocs/op"}, 0.5, false},
+		{exercise.BenchmarkPair{Baseline: "BenchmarkStringConcat", Optimized: "BenchmarkOptimizedSliceGrower", Metric: "B/op", MinRatio: 100}, math.Inf(1), true},
+	}
+	for _, tt := range tests {
+		c, err := Compare(tt.pair, results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Ratio() != tt.ratio || c.OK() != tt.ok {
+			t.Errorf("%s: ratio %g, ok %v; want %g, %v", c, c.Ratio(), c.OK(), tt.ratio, tt.ok)
+		}
+	}
+
+	if _, err := Compare(exercise.BenchmarkPair{Baseline: "BenchmarkStringConcat", Optimized: "BenchmarkMissing"}, results); err == nil {
+		t.Error("expected an error for a benchmark that did not run")
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultBaselineFile)
+	b, err := LoadBaselines(path)
+	if err != nil {
+		t.Fatalf("LoadBaselines of missing file: %v", err)
+	}
+	b["04-advanced/04-benchmarking"] = map[string]Result{
+		"BenchmarkStringConcat":   {Runs: 3, NsPerOp: 200, AllocsPerOp: 8},
+		"BenchmarkStringsBuilder": {Runs: 3, NsPerOp: 130, AllocsPerOp: 3},
+	}
+	if err := b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	b, err = LoadBaselines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := b.Regressions("04-advanced/04-benchmarking", Parse(output), 0.25)
+	want := []string{
+		"BenchmarkStringConcat: 267 ns/op, was 200 (+34%)",
+		"BenchmarkStringsBuilder: 4 allocs/op, was 3",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Regressions() = %q, want %q", got, want)
+	}
+	if got := b.Regressions("04-advanced/13-memory-optimization", Parse(output), 0.25); len(got) != 0 {
+		t.Errorf("exercise without baseline: got %q", got)
+	}
+}
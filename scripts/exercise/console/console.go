@@ -0,0 +1,56 @@
+// Package console prints what the command-line tools have in common: styled
+// text, exercise results as they complete and the summary of a validation
+// run.
+package console
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles of the tools' output, plain text after DisableColor.
+var (
+	Success = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+	Fail    = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+	Warn    = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+	Dim     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	Title   = lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+)
+
+// DisableColor makes every style plain text, for -no-color.
+func DisableColor() {
+	Success = lipgloss.NewStyle()
+	Fail = lipgloss.NewStyle()
+	Warn = lipgloss.NewStyle()
+	Dim = lipgloss.NewStyle()
+	Title = lipgloss.NewStyle()
+}
+
+// Banner prints the title a tool starts its output with.
+func Banner(title string) {
+	fmt.Println()
+	fmt.Println(Title.Render("═══════════════════════════════════════════════════════"))
+	fmt.Println(Title.Render("  " + title))
+	fmt.Println(Title.Render("═══════════════════════════════════════════════════════"))
+	fmt.Println()
+}
+
+// Heading prints the title of a section, such as the summary.
+func Heading(title string) {
+	fmt.Println(Title.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
+	fmt.Println(Title.Render(title))
+	fmt.Println(Title.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
+	fmt.Println()
+}
+
+// Names joins test or module names for a one-line message, abbreviating
+// long lists.
+func Names(names []string) string {
+	const limit = 3
+	if len(names) <= limit {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:limit], ", "), len(names)-limit)
+}
@@ -0,0 +1,64 @@
+package console
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"TestA", "TestB", "TestC"}, "TestA, TestB, TestC"},
+		{[]string{"TestA", "TestB", "TestC", "TestD", "TestE"}, "TestA, TestB, TestC and 2 more"},
+	}
+	for _, tt := range tests {
+		if got := Names(tt.names); got != tt.want {
+			t.Errorf("Names(%q) = %q, want %q", tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestSummaryWriteOutput(t *testing.T) {
+	var summary Summary
+	summary.Add(Result{Exercise: "01-basics/01-a", Status: "pass"})
+	summary.Add(Result{Exercise: "01-basics/02-b", Status: "fail", Message: "Tests failed: TestB"})
+	summary.Add(Result{Exercise: "01-basics/03-c", Status: "warn", Message: "2 benchmarks regressed", Details: []string{"BenchmarkC", "BenchmarkD"}})
+
+	if summary.Total != 3 || summary.Passed != 1 || len(summary.Failed) != 1 || len(summary.Warned) != 1 {
+		t.Fatalf("got %d total, %d passed, %d failed, %d warned, want 3, 1, 1, 1",
+			summary.Total, summary.Passed, len(summary.Failed), len(summary.Warned))
+	}
+
+	path := filepath.Join(t.TempDir(), "failures.txt")
+	summary.WriteOutput(path, "Exercises")
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Failed Exercises
+01-basics/02-b - Tests failed: TestB
+
+# Warned Exercises
+01-basics/03-c - 2 benchmarks regressed
+  BenchmarkC
+  BenchmarkD
+`
+	if string(got) != want {
+		t.Errorf("output file:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSummaryWriteOutputAllPassed(t *testing.T) {
+	var summary Summary
+	summary.Add(Result{Exercise: "01-basics/01-a", Status: "pass"})
+
+	path := filepath.Join(t.TempDir(), "failures.txt")
+	summary.WriteOutput(path, "Exercises")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("output file written although every exercise passed")
+	}
+}
@@ -0,0 +1,140 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"exercise/gotest"
+	"exercise/modcache"
+)
+
+// Result is the outcome of validating one exercise. The validators embed it
+// in their own result types.
+type Result struct {
+	Exercise string
+	Status   string // "pass", "fail", "warn"
+	Message  string
+	Logs     []string
+	Duration time.Duration
+
+	// Details are listed under the exercise in the -output file, such as
+	// the benchmarks that regressed.
+	Details []string
+}
+
+// Printer prints results as exercises complete, below which it can keep a
+// live progress line. It is safe for concurrent use.
+type Printer struct {
+	Verbose bool // print the logs of passing exercises too
+
+	mu            sync.Mutex
+	progressShown bool // a live progress line is on screen
+}
+
+// Progress replaces the live progress line.
+func (p *Printer) Progress(line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Print("\r" + line)
+	p.progressShown = true
+}
+
+// ClearProgress erases the live progress line, if any.
+func (p *Printer) ClearProgress() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clearProgress()
+}
+
+func (p *Printer) clearProgress() {
+	if p.progressShown {
+		fmt.Print("\r" + strings.Repeat(" ", 80) + "\r")
+		p.progressShown = false
+	}
+}
+
+// Print prints a passing result on a single line, followed by note if it is
+// not empty, and any other result with all its logs.
+func (p *Printer) Print(result Result, note string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clearProgress()
+
+	if result.Status == "pass" {
+		// Success: single line (unless verbose)
+		if p.Verbose {
+			for _, log := range result.Logs {
+				fmt.Println(log)
+			}
+		}
+		line := Success.Render("✅") + " " + result.Exercise
+		if note != "" {
+			line += Dim.Render(" " + note)
+		}
+		fmt.Println(line)
+		if p.Verbose {
+			fmt.Println()
+		}
+		return
+	}
+
+	// Failure/Warning: show all logs
+	for _, log := range result.Logs {
+		fmt.Println(log)
+	}
+	if result.Status == "fail" {
+		fmt.Println(Fail.Render(fmt.Sprintf("  ❌ %s: %s", result.Exercise, result.Message)))
+	} else if result.Status == "warn" {
+		fmt.Println(Warn.Render(fmt.Sprintf("  ⚠️  %s: %s", result.Exercise, result.Message)))
+	}
+	fmt.Println()
+}
+
+// TimeoutMessage names the tests that were still running when the timeout
+// expired, if go test reported them.
+func TimeoutMessage(run *gotest.Run, timeout time.Duration) string {
+	msg := fmt.Sprintf("Tests timed out after %s", timeout)
+	if hung := run.Hung(); len(hung) > 0 {
+		msg += " in " + Names(hung)
+	}
+	return msg
+}
+
+// CheckModules fails the result when the module cache lacks modules the
+// module in dir needs, which go mod download cannot fetch offline. It
+// returns the missing modules and whether the check passed.
+func CheckModules(result *Result, dir, cache string) ([]string, bool) {
+	if modcache.Vendored(dir) {
+		result.Logs = append(result.Logs, "  📦 Using vendored modules")
+		return nil, true
+	}
+
+	result.Logs = append(result.Logs, "  📦 Checking module cache...")
+	missing, err := modcache.Missing(dir, cache)
+	if err != nil {
+		result.Status = "fail"
+		result.Message = "Cannot read go.mod"
+		result.Logs = append(result.Logs, fmt.Sprintf("  ❌ Cannot read go.mod: %v", err))
+		return nil, false
+	}
+	if len(missing) == 0 {
+		return nil, true
+	}
+
+	names := make([]string, 0, len(missing))
+	for _, m := range missing {
+		names = append(names, m.String())
+	}
+	result.Status = "fail"
+	result.Message = "Missing modules: " + Names(names)
+	result.Logs = append(result.Logs, "  ❌ Modules missing from the cache (seed it with validator -seed-cache):")
+	for _, name := range names {
+		result.Logs = append(result.Logs, "     - "+name)
+	}
+	return names, false
+}
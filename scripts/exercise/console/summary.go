@@ -0,0 +1,120 @@
+package console
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"exercise/report"
+)
+
+// Summary tallies the results of a validation run.
+type Summary struct {
+	Total  int
+	Passed int
+	Failed []Result
+	Warned []Result
+}
+
+// Add counts a result.
+func (s *Summary) Add(result Result) {
+	s.Total++
+	switch result.Status {
+	case "pass":
+		s.Passed++
+	case "fail":
+		s.Failed = append(s.Failed, result)
+	case "warn":
+		s.Warned = append(s.Warned, result)
+	}
+}
+
+// Print prints the failed and warned exercises and the counts, followed by
+// extra lines such as an average score.
+func (s *Summary) Print(elapsed time.Duration, extra ...string) {
+	fmt.Println()
+	Heading("SUMMARY")
+
+	// Show failures
+	if len(s.Failed) > 0 {
+		fmt.Println(Fail.Render(fmt.Sprintf("❌ FAILED (%d):", len(s.Failed))))
+		for _, result := range s.Failed {
+			fmt.Printf("  - %s - %s\n", result.Exercise, result.Message)
+		}
+		fmt.Println()
+	}
+
+	// Show warnings
+	if len(s.Warned) > 0 {
+		fmt.Println(Warn.Render(fmt.Sprintf("⚠️  WARNINGS (%d):", len(s.Warned))))
+		for _, result := range s.Warned {
+			fmt.Printf("  - %s - %s\n", result.Exercise, result.Message)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Total:    %d\n", s.Total)
+	fmt.Printf("Passed:   %s\n", Success.Render(fmt.Sprintf("%d", s.Passed)))
+	fmt.Printf("Failed:   %s\n", Fail.Render(fmt.Sprintf("%d", len(s.Failed))))
+	fmt.Printf("Warnings: %s\n", Warn.Render(fmt.Sprintf("%d", len(s.Warned))))
+	fmt.Printf("Time:     %s\n", Dim.Render(elapsed.Round(time.Second).String()))
+	for _, line := range extra {
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+// WriteOutput lists the failed and warned exercises, with their details, in
+// the -output file path under headings naming them kind, e.g. "Exercises".
+// Nothing is written when path is empty or every exercise passed.
+func (s *Summary) WriteOutput(path, kind string) {
+	if path == "" || len(s.Failed)+len(s.Warned) == 0 {
+		return
+	}
+
+	var b strings.Builder
+	if len(s.Failed) > 0 {
+		fmt.Fprintf(&b, "# Failed %s\n", kind)
+		writeResults(&b, s.Failed)
+	}
+	if len(s.Warned) > 0 {
+		if len(s.Failed) > 0 {
+			fmt.Fprintln(&b, "")
+		}
+		fmt.Fprintf(&b, "# Warned %s\n", kind)
+		writeResults(&b, s.Warned)
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+	} else {
+		fmt.Printf("Output written to: %s\n", path)
+	}
+	fmt.Println()
+}
+
+func writeResults(b *strings.Builder, results []Result) {
+	for _, result := range results {
+		fmt.Fprintf(b, "%s - %s\n", result.Exercise, result.Message)
+		for _, detail := range result.Details {
+			fmt.Fprintf(b, "  %s\n", detail)
+		}
+	}
+}
+
+// WriteReport writes r to path, or to the format's default file when path
+// is empty, unless format is "text".
+func WriteReport(r *report.Report, format, path string) error {
+	if format == "text" {
+		return nil
+	}
+	if path == "" {
+		path = report.DefaultFile(format)
+	}
+	if err := r.WriteFile(path, format); err != nil {
+		return err
+	}
+	fmt.Printf("Report written to: %s\n", path)
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,224 @@
+// Package coverage parses Go coverage profiles and enforces per-category
+// coverage minimums for reference solutions.
+package coverage
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Block is a basic block of a coverage profile.
+type Block struct {
+	StartLine, StartCol int
+	EndLine, EndCol     int
+	Statements          int
+	Count               int
+}
+
+// Profile is a parsed coverage profile, as written by go test -coverprofile.
+type Profile struct {
+	Mode  string
+	Files map[string][]Block // keyed by import path file name, e.g. "example.com/ex/main.go"
+}
+
+// ParseProfile reads a coverage profile. Blocks reported more than once, as
+// happens when several test binaries cover the same package, are merged.
+func ParseProfile(r io.Reader) (*Profile, error) {
+	p := &Profile{Files: make(map[string][]Block)}
+	index := make(map[string]int) // file + position → index in Files[file]
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if mode, ok := strings.CutPrefix(text, "mode: "); ok && line == 1 {
+			p.Mode = mode
+			continue
+		}
+
+		file, block, err := parseBlock(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		key := fmt.Sprintf("%s:%d.%d,%d.%d", file, block.StartLine, block.StartCol, block.EndLine, block.EndCol)
+		if i, ok := index[key]; ok {
+			p.Files[file][i].Count += block.Count
+			continue
+		}
+		index[key] = len(p.Files[file])
+		p.Files[file] = append(p.Files[file], block)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if p.Mode == "" {
+		return nil, fmt.Errorf("missing mode line")
+	}
+	return p, nil
+}
+
+// parseBlock parses "file:startLine.startCol,endLine.endCol statements count".
+func parseBlock(text string) (string, Block, error) {
+	var b Block
+	colon := strings.LastIndex(text, ":")
+	if colon < 0 {
+		return "", b, fmt.Errorf("malformed block %q", text)
+	}
+	file, rest := text[:colon], text[colon+1:]
+
+	_, err := fmt.Sscanf(rest, "%d.%d,%d.%d %d %d", &b.StartLine, &b.StartCol, &b.EndLine, &b.EndCol, &b.Statements, &b.Count)
+	if err != nil {
+		return "", b, fmt.Errorf("malformed block %q: %w", text, err)
+	}
+	return file, b, nil
+}
+
+// ExcludeMain drops the blocks inside func main of package main from the
+// profile. Reference solutions often demonstrate their API there, and that
+// demo code is not meant to be exercised by tests. moduleDir is the directory
+// of the module the profile was collected in.
+func (p *Profile) ExcludeMain(moduleDir string) error {
+	module, err := modulePath(moduleDir)
+	if err != nil {
+		return err
+	}
+
+	for file, blocks := range p.Files {
+		rel, ok := strings.CutPrefix(file, module+"/")
+		if !ok {
+			continue
+		}
+		start, end, ok := mainFunc(filepath.Join(moduleDir, filepath.FromSlash(rel)))
+		if !ok {
+			continue
+		}
+
+		kept := blocks[:0]
+		for _, b := range blocks {
+			if b.StartLine < start || b.EndLine > end {
+				kept = append(kept, b)
+			}
+		}
+		p.Files[file] = kept
+	}
+	return nil
+}
+
+// modulePath reads the module path from the go.mod in dir.
+func modulePath(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if path, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(path), `"`), nil
+		}
+	}
+	return "", fmt.Errorf("%s/go.mod has no module line", dir)
+}
+
+// mainFunc returns the line range of func main if path belongs to package main.
+func mainFunc(path string) (start, end int, ok bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil || file.Name.Name != "main" {
+		return 0, 0, false
+	}
+	for _, decl := range file.Decls {
+		fn, isFunc := decl.(*ast.FuncDecl)
+		if isFunc && fn.Recv == nil && fn.Name.Name == "main" && fn.Body != nil {
+			return fset.Position(fn.Pos()).Line, fset.Position(fn.End()).Line, true
+		}
+	}
+	return 0, 0, false
+}
+
+// FileCoverage counts the covered statements of one file, or of a whole
+// profile.
+type FileCoverage struct {
+	Name       string `json:"name"`
+	Statements int    `json:"statements"`
+	Covered    int    `json:"covered"`
+}
+
+// Percent returns the statement coverage. Code without statements counts as
+// fully covered.
+func (f FileCoverage) Percent() float64 {
+	if f.Statements == 0 {
+		return 100
+	}
+	return 100 * float64(f.Covered) / float64(f.Statements)
+}
+
+// ByFile returns the coverage of every file, sorted by name.
+func (p *Profile) ByFile() []FileCoverage {
+	files := make([]FileCoverage, 0, len(p.Files))
+	for name, blocks := range p.Files {
+		fc := FileCoverage{Name: name}
+		for _, b := range blocks {
+			fc.Statements += b.Statements
+			if b.Count > 0 {
+				fc.Covered += b.Statements
+			}
+		}
+		files = append(files, fc)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files
+}
+
+// Total returns the coverage of the whole profile.
+func (p *Profile) Total() FileCoverage {
+	total := FileCoverage{Name: "total"}
+	for _, fc := range p.ByFile() {
+		total.Statements += fc.Statements
+		total.Covered += fc.Covered
+	}
+	return total
+}
+
+// Thresholds maps exercise categories to minimum coverage percentages. The
+// "*" entry applies to categories without their own minimum.
+type Thresholds map[string]float64
+
+// ParseThresholds parses a minimum such as "70" (every category) or
+// "basics=80,concurrency=60,*=70".
+func ParseThresholds(s string) (Thresholds, error) {
+	t := make(Thresholds)
+	if strings.TrimSpace(s) == "" {
+		return t, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		category, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			category, value = "*", category
+		}
+		minimum, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || minimum < 0 || minimum > 100 {
+			return nil, fmt.Errorf("invalid coverage minimum %q", part)
+		}
+		t[category] = minimum
+	}
+	return t, nil
+}
+
+// For returns the minimum coverage for a category, if one applies.
+func (t Thresholds) For(category string) (float64, bool) {
+	if minimum, ok := t[category]; ok {
+		return minimum, true
+	}
+	minimum, ok := t["*"]
+	return minimum, ok
+}
@@ -0,0 +1,175 @@
+package coverage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"exercise/internal/testfiles"
+)
+
+const profile = `mode: atomic
+example.com/ex/main.go:5.24,7.2 1 3
+example.com/ex/main.go:9.13,12.2 2 0
+example.com/ex/stack/stack.go:3.20,5.2 1 0
+example.com/ex/stack/stack.go:7.20,9.2 3 1
+example.com/ex/stack/stack.go:3.20,5.2 1 2
+`
+
+func TestParseProfile(t *testing.T) {
+	p, err := ParseProfile(strings.NewReader(profile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Mode != "atomic" {
+		t.Errorf("mode: got %q", p.Mode)
+	}
+
+	// The duplicate stack.go block is merged, so it counts as covered
+	want := []FileCoverage{
+		{Name: "example.com/ex/main.go", Statements: 3, Covered: 1},
+		{Name: "example.com/ex/stack/stack.go", Statements: 4, Covered: 4},
+	}
+	got := p.ByFile()
+	if len(got) != len(want) {
+		t.Fatalf("got %d files, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("file %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	total := p.Total()
+	if total.Statements != 7 || total.Covered != 5 {
+		t.Errorf("total: got %+v", total)
+	}
+}
+
+func TestParseProfileErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"example.com/ex/main.go:5.24,7.2 1 3\n",
+		"mode: set\nexample.com/ex/main.go 1 3\n",
+		"mode: set\nexample.com/ex/main.go:5.24,7.2 x 3\n",
+	} {
+		if _, err := ParseProfile(strings.NewReader(input)); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestExcludeMain(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/ex\n\ngo 1.25\n",
+		"main.go": `package main
+
+import "fmt"
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func main() {
+	fmt.Println(Add(1, 2))
+	fmt.Println(Add(3, 4))
+}
+`,
+		"stack/stack.go": "package stack\n\nfunc main() {\n\tprintln()\n}\n",
+	}
+	testfiles.Write(t, dir, files)
+
+	p, err := ParseProfile(strings.NewReader(profile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ExcludeMain(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	got := p.ByFile()
+	if got[0].Statements != 1 || got[0].Covered != 1 {
+		t.Errorf("main.go: func main should be excluded, got %+v", got[0])
+	}
+	// Only package main has a program entry point
+	if got[1].Statements != 4 {
+		t.Errorf("stack.go: got %+v", got[1])
+	}
+}
+
+func TestParseThresholds(t *testing.T) {
+	th, err := ParseThresholds("basics=80, concurrency=60%,*=70")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		category string
+		want     float64
+	}{
+		{"basics", 80},
+		{"concurrency", 60},
+		{"projects", 70},
+	}
+	for _, tt := range tests {
+		if got, ok := th.For(tt.category); !ok || got != tt.want {
+			t.Errorf("%s: got %v, %v; want %v", tt.category, got, ok, tt.want)
+		}
+	}
+
+	th, err = ParseThresholds("75")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := th.For("basics"); got != 75 {
+		t.Errorf("single minimum: got %v", got)
+	}
+
+	th, _ = ParseThresholds("")
+	if _, ok := th.For("basics"); ok {
+		t.Error("empty thresholds should not apply to any category")
+	}
+
+	for _, input := range []string{"basics=high", "120", "basics=-1"} {
+		if _, err := ParseThresholds(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	exercises := []ExerciseCoverage{
+		{
+			Path: "01-basics/01-a", Category: "basics", Minimum: 80,
+			Total: FileCoverage{Name: "total", Statements: 10, Covered: 5},
+			Files: []FileCoverage{{Name: "example.com/a/main.go", Statements: 10, Covered: 5}},
+		},
+		{
+			Path: "05-projects/01-b", Category: "projects",
+			Total: FileCoverage{Name: "total", Statements: 30, Covered: 27},
+		},
+	}
+	if !exercises[0].BelowMinimum() || exercises[1].BelowMinimum() {
+		t.Error("BelowMinimum: only the first exercise misses its minimum")
+	}
+
+	var text bytes.Buffer
+	if err := WriteText(&text, exercises); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"01-basics/01-a", "50.0%", "BELOW 80%", "example.com/a/main.go", "total", "80.0%"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text report missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var html bytes.Buffer
+	if err := WriteHTML(&html, exercises); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<table>", `class="exercise below"`, "05-projects/01-b", "32/40"} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package coverage
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// ExerciseCoverage is the coverage of one solution in an aggregated report.
+type ExerciseCoverage struct {
+	Path     string // e.g. "01-basics/01-hello-world"
+	Category string
+	Minimum  float64 // zero if no minimum applies
+	Total    FileCoverage
+	Files    []FileCoverage
+}
+
+// BelowMinimum reports whether the solution misses its category minimum.
+func (e ExerciseCoverage) BelowMinimum() bool {
+	return e.Minimum > 0 && e.Total.Percent() < e.Minimum
+}
+
+// Summary sums the statements of all exercises.
+func Summary(exercises []ExerciseCoverage) FileCoverage {
+	total := FileCoverage{Name: "total"}
+	for _, e := range exercises {
+		total.Statements += e.Total.Statements
+		total.Covered += e.Total.Covered
+	}
+	return total
+}
+
+// WriteText writes a plain text report with one line per exercise, followed
+// by its files.
+func WriteText(w io.Writer, exercises []ExerciseCoverage) error {
+	var b strings.Builder
+	for _, e := range exercises {
+		status := "ok"
+		if e.BelowMinimum() {
+			status = fmt.Sprintf("BELOW %.0f%%", e.Minimum)
+		}
+		fmt.Fprintf(&b, "%-45s %6.1f%%  %4d/%-4d %s\n", e.Path, e.Total.Percent(), e.Total.Covered, e.Total.Statements, status)
+		for _, f := range e.Files {
+			fmt.Fprintf(&b, "    %-41s %6.1f%%  %4d/%d\n", f.Name, f.Percent(), f.Covered, f.Statements)
+		}
+	}
+	total := Summary(exercises)
+	fmt.Fprintf(&b, "\n%-45s %6.1f%%  %4d/%d\n", "total", total.Percent(), total.Covered, total.Statements)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlReport = template.Must(template.New("coverage").Funcs(template.FuncMap{
+	"percent": func(f FileCoverage) string { return fmt.Sprintf("%.1f%%", f.Percent()) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Solution coverage</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { padding: 4px 12px; text-align: left; }
+td.num { text-align: right; font-family: monospace; }
+tr.exercise { border-top: 1px solid #ccc; font-weight: bold; }
+tr.file td:first-child { padding-left: 2em; font-weight: normal; color: #555; }
+tr.below { color: #c62828; }
+</style>
+</head>
+<body>
+<h1>Solution coverage</h1>
+<p>Total: {{percent .Total}} ({{.Total.Covered}}/{{.Total.Statements}} statements)</p>
+<table>
+<tr><th>Exercise</th><th>Category</th><th>Coverage</th><th>Statements</th><th>Minimum</th></tr>
+{{- range .Exercises}}
+<tr class="exercise{{if .BelowMinimum}} below{{end}}"><td>{{.Path}}</td><td>{{.Category}}</td><td class="num">{{percent .Total}}</td><td class="num">{{.Total.Covered}}/{{.Total.Statements}}</td><td class="num">{{if .Minimum}}{{printf "%.0f%%" .Minimum}}{{end}}</td></tr>
+{{- range .Files}}
+<tr class="file"><td>{{.Name}}</td><td></td><td class="num">{{percent .}}</td><td class="num">{{.Covered}}/{{.Statements}}</td><td></td></tr>
+{{- end}}
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteHTML writes a standalone HTML report.
+func WriteHTML(w io.Writer, exercises []ExerciseCoverage) error {
+	return htmlReport.Execute(w, struct {
+		Total     FileCoverage
+		Exercises []ExerciseCoverage
+	}{Summary(exercises), exercises})
+}
@@ -0,0 +1,153 @@
+// Package exercise discovers the training exercises in the repository.
+//
+// Every exercise directory declares itself with an exercise.json manifest,
+// so tools never have to guess from directory names which folders are exercises.
+package exercise
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Exercise is a discovered exercise directory and its manifest.
+type Exercise struct {
+	Path string // relative to the repo root, e.g. "01-basics/01-string-manipulation"
+	Dir  string // absolute directory
+	Manifest
+}
+
+// SolutionDir returns the directory holding the reference solution.
+func (e Exercise) SolutionDir() string {
+	return filepath.Join(e.Dir, "solution")
+}
+
+// HasSolutionModule reports whether the solution is a standalone module
+// (solution/go.mod) that can be built and tested on its own.
+func (e Exercise) HasSolutionModule() bool {
+	_, err := os.Stat(filepath.Join(e.SolutionDir(), "go.mod"))
+	return err == nil
+}
+
+// StudentPackages lists the packages of the exercise module that students
+// work on, as "./"-relative patterns for the go command. Reference solutions
+// living inside the exercise module are left out.
+func (e Exercise) StudentPackages() ([]string, error) {
+	// Directories without Go files, such as an exercise whose starter code
+	// is still missing, are not packages
+	format := "{{if or .GoFiles .CgoFiles .TestGoFiles .XTestGoFiles}}{{.Dir}}{{end}}"
+	cmd := exec.Command("go", "list", "-e", "-f", format, "./...")
+	cmd.Dir = e.Dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w", err)
+	}
+
+	packages := make([]string, 0)
+	for _, dir := range strings.Fields(string(output)) {
+		rel, err := filepath.Rel(e.Dir, dir)
+		if err != nil {
+			return nil, err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "solution" || strings.HasPrefix(rel, "solution/") {
+			continue
+		}
+		if rel == "." {
+			packages = append(packages, ".")
+		} else {
+			packages = append(packages, "./"+rel)
+		}
+	}
+	return packages, nil
+}
+
+// Open loads a single exercise. dir is tried as given first, then relative to
+// repoRoot, so both "." from inside an exercise and "01-basics/01-..." work.
+func Open(repoRoot, dir string) (Exercise, error) {
+	root, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return Exercise{}, err
+	}
+
+	path := dir
+	if _, err := os.Stat(filepath.Join(path, ManifestFile)); err != nil && !filepath.IsAbs(dir) {
+		path = filepath.Join(root, dir)
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return Exercise{}, err
+	}
+
+	relPath, err := filepath.Rel(root, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return Exercise{}, fmt.Errorf("%s is not inside %s", dir, root)
+	}
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Exercise{}, fmt.Errorf("%s is not an exercise: no %s", dir, ManifestFile)
+		}
+		return Exercise{}, fmt.Errorf("%s: %w", relPath, err)
+	}
+
+	return Exercise{
+		Path:     filepath.ToSlash(relPath),
+		Dir:      path,
+		Manifest: manifest,
+	}, nil
+}
+
+// Discover walks repoRoot and returns every exercise with a manifest, sorted by path.
+func Discover(repoRoot string) ([]Exercise, error) {
+	exercises := make([]Exercise, 0)
+
+	err := filepath.WalkDir(repoRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		// Skip backup, vendor, hidden dirs, scripts, bin, and exercise internals
+		name := d.Name()
+		if path != repoRoot && (strings.Contains(name, "backup") || name == "vendor" ||
+			strings.HasPrefix(name, ".") || name == "bin" || name == "scripts" ||
+			name == "claudedocs" || name == "solution" || name == "testdata") {
+			return filepath.SkipDir
+		}
+
+		if _, err := os.Stat(filepath.Join(path, ManifestFile)); err != nil {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(repoRoot, path)
+		if err != nil {
+			return err
+		}
+		manifest, err := LoadManifest(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", relPath, err)
+		}
+
+		exercises = append(exercises, Exercise{
+			Path:     filepath.ToSlash(relPath),
+			Dir:      path,
+			Manifest: manifest,
+		})
+
+		// Exercises don't nest
+		return filepath.SkipDir
+	})
+
+	sort.Slice(exercises, func(i, j int) bool {
+		return exercises[i].Path < exercises[j].Path
+	})
+	return exercises, err
+}
@@ -0,0 +1,109 @@
+package exercise
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeManifest(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	valid := `{"title": "T", "category": "basics", "difficulty": 1, "estimated_time": "30m", "timeout": "45s"}`
+
+	writeManifest(t, filepath.Join(root, "01-basics", "02-second"), valid)
+	writeManifest(t, filepath.Join(root, "01-basics", "01-first"), valid)
+	// Manifests under solution/ and scripts/ are not exercises
+	writeManifest(t, filepath.Join(root, "01-basics", "01-first", "solution"), valid)
+	writeManifest(t, filepath.Join(root, "scripts", "tool"), valid)
+	// Directories without a manifest are ignored, whatever their name
+	if err := os.MkdirAll(filepath.Join(root, "01-basics", "03-unlisted"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	exercises, err := Discover(root)
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+
+	want := []string{"01-basics/01-first", "01-basics/02-second"}
+	if len(exercises) != len(want) {
+		t.Fatalf("got %d exercises, want %d: %+v", len(exercises), len(want), exercises)
+	}
+	for i, ex := range exercises {
+		if ex.Path != want[i] {
+			t.Errorf("exercise %d: got %q, want %q", i, ex.Path, want[i])
+		}
+	}
+	if exercises[0].Timeout.Duration != 45*time.Second {
+		t.Errorf("timeout: got %s, want 45s", exercises[0].Timeout)
+	}
+	if got := exercises[0].TestTimeout(30 * time.Second); got != 45*time.Second {
+		t.Errorf("TestTimeout: got %s, want the manifest's 45s", got)
+	}
+	if got := (Manifest{}).TestTimeout(30 * time.Second); got != 30*time.Second {
+		t.Errorf("TestTimeout without a manifest timeout: got %s, want 30s", got)
+	}
+	if exercises[0].EstimatedTime.Duration != 30*time.Minute {
+		t.Errorf("estimated time: got %s, want 30m", exercises[0].EstimatedTime)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "01-basics", "01-first"), `{"title": "T", "category": "basics", "difficulty": 1}`)
+
+	for _, dir := range []string{"01-basics/01-first", filepath.Join(root, "01-basics", "01-first")} {
+		ex, err := Open(root, dir)
+		if err != nil {
+			t.Fatalf("Open(%q): %v", dir, err)
+		}
+		if ex.Path != "01-basics/01-first" || ex.Title != "T" {
+			t.Errorf("Open(%q) = %+v", dir, ex)
+		}
+	}
+
+	if _, err := Open(root, "01-basics"); err == nil {
+		t.Error("expected error for a directory without manifest")
+	}
+	if _, err := Open(filepath.Join(root, "01-basics"), filepath.Join(root, "01-basics", "01-first", "..", "..")); err == nil {
+		t.Error("expected error for a directory outside the repo root")
+	}
+}
+
+func TestDiscoverInvalidManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+	}{
+		{"malformed JSON", `{"title": `},
+		{"unknown category", `{"title": "T", "category": "misc", "difficulty": 1}`},
+		{"difficulty out of range", `{"title": "T", "category": "basics", "difficulty": 6}`},
+		{"bad duration", `{"title": "T", "category": "basics", "difficulty": 1, "timeout": "soon"}`},
+		{"bad must-fail name", `{"title": "T", "category": "basics", "difficulty": 1, "starter_must_fail": ["Add"]}`},
+		{"unknown starter expectation", `{"title": "T", "category": "basics", "difficulty": 1, "starter_expect": "pass"}`},
+		{"bad benchmark name", `{"title": "T", "category": "basics", "difficulty": 1, "benchmarks": [{"baseline": "Concat", "optimized": "BenchmarkBuilder"}]}`},
+		{"bad benchmark metric", `{"title": "T", "category": "basics", "difficulty": 1, "benchmarks": [{"baseline": "BenchmarkA", "optimized": "BenchmarkB", "metric": "ms"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeManifest(t, filepath.Join(root, "01-basics", "01-first"), tt.manifest)
+
+			if _, err := Discover(root); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
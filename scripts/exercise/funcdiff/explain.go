@@ -0,0 +1,103 @@
+package funcdiff
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ExplanationFile is the solution's write-up, next to solution/main.go.
+const ExplanationFile = "EXPLANATION.md"
+
+// Section is the text under one heading of the explanation, down to the
+// next heading.
+type Section struct {
+	Path []string // headings from the outermost, e.g. ["Algorithm Analysis", "Reverse Function"]
+	Body string
+}
+
+// Title returns the section's headings joined with " › ".
+func (s Section) Title() string {
+	return strings.Join(s.Path, " › ")
+}
+
+var heading = regexp.MustCompile(`^(#{2,6})\s+(.+?)\s*#*\s*$`)
+
+// ParseSections splits a Markdown document at its "##" to "######"
+// headings. The "#" title and the text before the first section are left
+// out, as are lines in fenced code blocks that look like headings.
+func ParseSections(data []byte) []Section {
+	var sections []Section
+	var path []string
+	var body []string
+	inSection, inCode := false, false
+
+	flush := func() {
+		if inSection {
+			sections = append(sections, Section{
+				Path: append([]string(nil), path...),
+				Body: strings.TrimSpace(strings.Join(body, "\n")),
+			})
+		}
+		body = body[:0]
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+		m := heading.FindStringSubmatch(line)
+		if inCode || m == nil {
+			body = append(body, line)
+			continue
+		}
+		flush()
+		inSection = true
+
+		// Headings below level 2 nest under the ones above them
+		level := len(m[1]) - 2
+		path = append(path[:min(level, len(path))], m[2])
+	}
+	flush()
+	return sections
+}
+
+// LoadSections reads dir/EXPLANATION.md. A missing file has no sections.
+func LoadSections(dir string) ([]Section, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ExplanationFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseSections(data), nil
+}
+
+// Relevant returns the section that discusses fn the most: mentions in a
+// heading count more than in the body, and the function's name more than
+// the calls it makes. Ties go to the earlier section.
+func Relevant(sections []Section, fn Func) (Section, bool) {
+	best, bestScore := -1, 0
+	for i, s := range sections {
+		score := 0
+		title := s.Path[len(s.Path)-1]
+		for term, weight := range fn.Terms {
+			re := regexp.MustCompile(`\b` + regexp.QuoteMeta(term) + `\b`)
+			if re.MatchString(title) {
+				score += 3 * weight
+			}
+			score += weight * len(re.FindAllStringIndex(s.Body, -1))
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return Section{}, false
+	}
+	return sections[best], true
+}
@@ -0,0 +1,201 @@
+// Package funcdiff compares the functions of a student's code with those of
+// the reference solution, and finds where EXPLANATION.md discusses them.
+//
+// Functions are aligned by name and compared at the AST level, so formatting
+// and comments do not count as differences.
+package funcdiff
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Func is a function or method declared in a package directory.
+type Func struct {
+	Name   string // e.g. "Reverse" or "SafeCounter.Inc"
+	Source string // normalized source, without comments
+
+	// Terms weighs the words an explanation of the function would use: its
+	// name, and the package functions and methods it calls.
+	Terms map[string]int
+}
+
+// Term weights
+const (
+	nameWeight = 3
+	callWeight = 1
+)
+
+// Load parses the non-test .go files in dir, in file name order, and returns
+// their functions in declaration order.
+func Load(dir string) ([]Func, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	fset := token.NewFileSet()
+	funcs := make([]Func, 0)
+	seen := make(map[string]int)
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		imports := importNames(file)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			f, err := newFunc(fset, fn, imports)
+			if err != nil {
+				return nil, err
+			}
+
+			// init may be declared more than once
+			if seen[f.Name]++; seen[f.Name] > 1 {
+				f.Name += fmt.Sprintf("#%d", seen[f.Name])
+			}
+			funcs = append(funcs, f)
+		}
+	}
+	return funcs, nil
+}
+
+// importNames returns the names a file refers to its imports by.
+func importNames(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, imp := range file.Imports {
+		if imp.Name != nil {
+			names[imp.Name.Name] = true
+			continue
+		}
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err == nil {
+			names[path.Base(p)] = true
+		}
+	}
+	return names
+}
+
+func newFunc(fset *token.FileSet, fn *ast.FuncDecl, imports map[string]bool) (Func, error) {
+	f := Func{Name: fn.Name.Name, Terms: make(map[string]int)}
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		recv := receiverType(fn.Recv.List[0].Type)
+		f.Name = recv + "." + fn.Name.Name
+		f.Terms[f.Name] = nameWeight
+	}
+	// main and init are demo and setup code the explanation is not about,
+	// and too common a word to look for
+	if f.Name != "main" && f.Name != "init" {
+		f.Terms[fn.Name.Name] = nameWeight
+	}
+
+	if fn.Body != nil && len(f.Terms) > 0 {
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			term := sel.Sel.Name
+			if id, ok := sel.X.(*ast.Ident); ok && imports[id.Name] {
+				term = id.Name + "." + term
+			}
+			if f.Terms[term] == 0 {
+				f.Terms[term] = callWeight
+			}
+			return true
+		})
+	}
+
+	// The doc comment is dropped, and comments inside the body are not
+	// attached to the node, so the printer drops them as well
+	decl := *fn
+	decl.Doc = nil
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, &decl); err != nil {
+		return Func{}, err
+	}
+	f.Source = buf.String()
+	return f, nil
+}
+
+// receiverType returns the name of a receiver's type, without pointer or
+// type parameters.
+func receiverType(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return "?"
+		}
+	}
+}
+
+// Pair is a function of the student's code and its counterpart in the
+// solution.
+type Pair struct {
+	Name     string
+	Student  *Func // nil when only the solution declares the function
+	Solution *Func // nil when only the student declares the function
+}
+
+// Same reports whether both sides declare the function identically.
+func (p Pair) Same() bool {
+	return p.Student != nil && p.Solution != nil && p.Student.Source == p.Solution.Source
+}
+
+// Align pairs functions by name, in the solution's order followed by the
+// functions only the student declares.
+func Align(student, solution []Func) []Pair {
+	byName := make(map[string]*Func, len(student))
+	for i := range student {
+		byName[student[i].Name] = &student[i]
+	}
+
+	pairs := make([]Pair, 0, len(solution))
+	matched := make(map[string]bool)
+	for i := range solution {
+		name := solution[i].Name
+		pairs = append(pairs, Pair{Name: name, Student: byName[name], Solution: &solution[i]})
+		matched[name] = true
+	}
+	for i := range student {
+		if !matched[student[i].Name] {
+			pairs = append(pairs, Pair{Name: student[i].Name, Student: &student[i]})
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,161 @@
+package funcdiff
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"exercise/internal/testfiles"
+)
+
+func TestLoadAndAlign(t *testing.T) {
+	dir := t.TempDir()
+	testfiles.Write(t, dir, map[string]string{
+		"main.go": `package main
+
+// Reverse has a doc comment only in this copy.
+func Reverse(s string) string {
+	if s == "" { return s } // reformatted below
+	return s
+}
+
+func (c *Counter) Inc() { panic("TODO") }
+func helper() {}
+`,
+		"main_test.go": "package main\n\nfunc TestReverse() {}\n",
+		"solution/main.go": `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func Reverse(s string) string {
+	if s == "" {
+		return s
+	}
+	return s
+}
+
+func (c *Counter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n = strings.Count("", "") + c.n
+}
+
+func main() { fmt.Println(strings.Count("", "")) }
+`,
+	})
+
+	student, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	solution, err := Load(filepath.Join(dir, "solution"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	var got []string
+	for _, p := range Align(student, solution) {
+		got = append(got, p.Name+" "+map[bool]string{true: "same", false: "differs"}[p.Same()])
+	}
+	want := []string{"Reverse same", "Counter.Inc differs", "main differs", "helper differs"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Align() = %q, want %q", got, want)
+	}
+
+	wantTerms := map[string]int{"Counter.Inc": 3, "Inc": 3, "Lock": 1, "Unlock": 1, "strings.Count": 1}
+	if terms := solution[1].Terms; !reflect.DeepEqual(terms, wantTerms) {
+		t.Errorf("Terms = %v, want %v", terms, wantTerms)
+	}
+	if len(solution[2].Terms) != 0 {
+		t.Errorf("main should have no terms, got %v", solution[2].Terms)
+	}
+}
+
+func TestLines(t *testing.T) {
+	rows := Lines("a\nb\nc\nd\n", "a\nB\nc\nd\ne\n")
+	want := []Row{
+		{Kind: Same, Left: "a", Right: "a"},
+		{Kind: Changed, Left: "b", Right: "B"},
+		{Kind: Same, Left: "c", Right: "c"},
+		{Kind: Same, Left: "d", Right: "d"},
+		{Kind: Added, Right: "e"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Lines() = %+v\nwant %+v", rows, want)
+	}
+
+	rows = Lines("x\ny\n", "")
+	want = []Row{{Kind: Removed, Left: "x"}, {Kind: Removed, Left: "y"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Lines() = %+v\nwant %+v", rows, want)
+	}
+}
+
+const explanation = `# Solution Explanation: String Manipulation
+
+Intro text.
+
+## Key Concepts
+
+### 1. Strings vs Runes
+
+Use ` + "`[]rune`" + ` to Reverse by character.
+
+` + "```go" + `
+## not a heading
+` + "```" + `
+
+### 2. Unicode Package
+
+Use strings.ToLower and unicode.IsLetter.
+
+## Algorithm Analysis
+
+### Reverse Function
+
+O(n) time.
+`
+
+func TestParseSections(t *testing.T) {
+	sections := ParseSections([]byte(explanation))
+
+	var titles []string
+	for _, s := range sections {
+		titles = append(titles, s.Title())
+	}
+	want := []string{
+		"Key Concepts",
+		"Key Concepts › 1. Strings vs Runes",
+		"Key Concepts › 2. Unicode Package",
+		"Algorithm Analysis",
+		"Algorithm Analysis › Reverse Function",
+	}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("titles = %q, want %q", titles, want)
+	}
+	if body := sections[1].Body; body != "Use `[]rune` to Reverse by character.\n\n```go\n## not a heading\n```" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestRelevant(t *testing.T) {
+	sections := ParseSections([]byte(explanation))
+
+	tests := []struct {
+		fn   Func
+		want string
+	}{
+		{Func{Terms: map[string]int{"Reverse": 3}}, "Algorithm Analysis › Reverse Function"},
+		{Func{Terms: map[string]int{"IsPalindrome": 3, "strings.ToLower": 1}}, "Key Concepts › 2. Unicode Package"},
+		{Func{Terms: map[string]int{"CountChars": 3}}, ""},
+	}
+	for _, tt := range tests {
+		s, ok := Relevant(sections, tt.fn)
+		if got := s.Title(); got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Relevant(%v) = %q, %v; want %q", tt.fn.Terms, got, ok, tt.want)
+		}
+	}
+}
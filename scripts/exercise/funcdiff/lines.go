@@ -0,0 +1,86 @@
+package funcdiff
+
+import "strings"
+
+// Kind tells how a line of a side-by-side diff changed.
+type Kind int
+
+const (
+	Same    Kind = iota // both sides have the line
+	Removed             // only the left side has the line
+	Added               // only the right side has the line
+	Changed             // each side has its own line
+)
+
+// Row is a line of a side-by-side diff. Left is empty for Added rows and
+// Right for Removed ones.
+type Row struct {
+	Kind        Kind
+	Left, Right string
+}
+
+// Lines diffs two texts line by line, along their longest common
+// subsequence. Lines removed and added at the same place are shown side by
+// side as Changed rows.
+func Lines(left, right string) []Row {
+	a := splitLines(left)
+	b := splitLines(right)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	rows := make([]Row, 0, max(len(a), len(b)))
+	var removed, added []string
+	flush := func() {
+		n := min(len(removed), len(added))
+		for k := 0; k < n; k++ {
+			rows = append(rows, Row{Kind: Changed, Left: removed[k], Right: added[k]})
+		}
+		for _, line := range removed[n:] {
+			rows = append(rows, Row{Kind: Removed, Left: line})
+		}
+		for _, line := range added[n:] {
+			rows = append(rows, Row{Kind: Added, Right: line})
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			rows = append(rows, Row{Kind: Same, Left: a[i], Right: b[j]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	flush()
+	return rows
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,90 @@
+package gotest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"exercise/sandbox"
+)
+
+// Test runs "go test -json" in dir with the given flags and packages and parses
+// the event stream. The returned error is that of the go command, so failing
+// tests yield both a Run and an error.
+func Test(dir string, args ...string) (*Run, error) {
+	return TestWithLimits(dir, sandbox.Limits{}, args...)
+}
+
+// TestWithLimits is like Test, but runs go test and the test binaries it
+// starts under limits. When the timeout expires the error wraps
+// sandbox.ErrTimeout and the Run holds the events written until then.
+func TestWithLimits(dir string, limits sandbox.Limits, args ...string) (*Run, error) {
+	output, err := sandbox.Output(dir, limits, "go", append([]string{"test", "-json"}, args...)...)
+
+	run, parseErr := Parse(bytes.NewReader(output))
+	if err == nil {
+		err = parseErr
+	}
+	return run, err
+}
+
+// Stream is like Test, but calls out with each line of human-readable output
+// as soon as go test writes it, e.g. to show a run live. Canceling ctx kills
+// go test and the test binaries it started.
+func Stream(ctx context.Context, dir string, out func(line string), args ...string) (*Run, error) {
+	cmd := sandbox.Command(ctx, dir, sandbox.Limits{}, "go", append([]string{"test", "-json"}, args...)...)
+	w := &lineWriter{out: out}
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	err := cmd.Run()
+	w.flush()
+	run, parseErr := Parse(&w.stream)
+	if err == nil {
+		err = parseErr
+	}
+	return run, err
+}
+
+// lineWriter keeps a "go test -json" stream written to it and passes the
+// output of each complete line on to out.
+type lineWriter struct {
+	out     func(string)
+	stream  bytes.Buffer
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.stream.Write(p)
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		w.emit(w.partial[:i])
+		w.partial = w.partial[i+1:]
+	}
+}
+
+// flush passes on a last line without a newline.
+func (w *lineWriter) flush() {
+	if len(w.partial) > 0 {
+		w.emit(w.partial)
+		w.partial = nil
+	}
+}
+
+// emit passes on the output of an event, or a line that is not one, such as
+// a build error.
+func (w *lineWriter) emit(line []byte) {
+	var ev Event
+	if err := json.Unmarshal(line, &ev); err != nil || ev.Action == "" {
+		w.out(string(line))
+		return
+	}
+	if ev.Action == "output" {
+		w.out(strings.TrimSuffix(ev.Output, "\n"))
+	}
+}
@@ -0,0 +1,340 @@
+// Package gotest parses the event stream written by "go test -json".
+package gotest
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is a single line of "go test -json" output (see "go doc test2json").
+type Event struct {
+	Time       time.Time `json:"Time"`
+	Action     string    `json:"Action"`
+	Package    string    `json:"Package"`
+	Test       string    `json:"Test"`
+	Elapsed    float64   `json:"Elapsed"` // seconds
+	Output     string    `json:"Output"`
+	OutputType string    `json:"OutputType"` // "frame" for === RUN / --- FAIL lines (Go 1.25+)
+}
+
+// TestCase is the outcome of a single test or subtest.
+type TestCase struct {
+	Package    string     `json:"package"`
+	Name       string     `json:"name"`   // full name, e.g. "TestSplit/empty_input"
+	Status     string     `json:"status"` // "pass", "fail", "skip"
+	Elapsed    float64    `json:"elapsed_seconds"`
+	Output     string     `json:"output,omitempty"` // failure output: assertions, panics, race reports
+	SkipReason string     `json:"skip_reason,omitempty"`
+	Race       bool       `json:"race,omitempty"`    // the race detector fired during this test
+	Timeout    bool       `json:"timeout,omitempty"` // running when the -timeout expired
+	Subtests   []TestCase `json:"subtests,omitempty"`
+}
+
+// Run is the parsed outcome of one "go test -json" invocation.
+type Run struct {
+	Tests        []TestCase // top-level tests; subtests are nested inside them
+	Output       string     // human-readable output, as "go test -v" would print it
+	RaceDetected bool       // a data race was reported, inside or outside a test
+	TimedOut     bool       // the test binary panicked because -timeout expired
+	OutOfMemory  bool       // the Go runtime or race detector ran out of memory
+	BuildFailed  bool       // a package or its tests did not compile
+}
+
+// raceMarkers identify race detector reports in test output.
+var raceMarkers = []string{"WARNING: DATA RACE", "race detected during execution of test"}
+
+// oomMarkers identify allocation failures, e.g. under a memory limit.
+var oomMarkers = []string{"fatal error: out of memory", "runtime: out of memory", "ThreadSanitizer: out of memory", "ThreadSanitizer failed to allocate"}
+
+// timeoutMarker starts the panic of a test binary whose -timeout expired.
+const timeoutMarker = "panic: test timed out after"
+
+// buildFailedMarker ends the result line of a package that did not compile,
+// for toolchains before Go 1.24 without build-fail events.
+const buildFailedMarker = "[build failed]"
+
+type node struct {
+	tc       TestCase
+	output   strings.Builder
+	children []*node
+}
+
+// Parse reads a "go test -json" stream. Lines that are not JSON events, such as
+// build errors printed before the test binary starts, are kept in Output.
+func Parse(r io.Reader) (*Run, error) {
+	run := &Run{}
+	nodes := make(map[string]*node) // package + test name
+	var roots []*node
+	var output strings.Builder
+
+	lookup := func(pkg, name string) *node {
+		key := pkg + "\x00" + name
+		if n, ok := nodes[key]; ok {
+			return n
+		}
+		n := &node{tc: TestCase{Package: pkg, Name: name}}
+		nodes[key] = n
+
+		// Attach subtests to the closest enclosing test
+		parent := name
+		for {
+			i := strings.LastIndex(parent, "/")
+			if i < 0 {
+				roots = append(roots, n)
+				break
+			}
+			parent = parent[:i]
+			if p, ok := nodes[pkg+"\x00"+parent]; ok {
+				p.children = append(p.children, n)
+				break
+			}
+		}
+		return n
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		var ev Event
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &ev) != nil {
+			output.WriteString(line)
+			output.WriteString("\n")
+			continue
+		}
+
+		switch ev.Action {
+		case "output", "build-output":
+			output.WriteString(ev.Output)
+			if containsRace(ev.Output) {
+				run.RaceDetected = true
+			}
+			if strings.Contains(ev.Output, timeoutMarker) {
+				run.TimedOut = true
+			}
+			if containsAny(ev.Output, oomMarkers) {
+				run.OutOfMemory = true
+			}
+			if strings.Contains(ev.Output, buildFailedMarker) {
+				run.BuildFailed = true
+			}
+			if ev.Test != "" && !isFrame(ev) {
+				lookup(ev.Package, ev.Test).output.WriteString(ev.Output)
+			}
+		case "build-fail":
+			run.BuildFailed = true
+		case "run":
+			if ev.Test != "" {
+				lookup(ev.Package, ev.Test)
+			}
+		case "pass", "fail", "skip":
+			if ev.Test == "" {
+				continue // package result
+			}
+			n := lookup(ev.Package, ev.Test)
+			n.tc.Status = ev.Action
+			n.tc.Elapsed = ev.Elapsed
+		}
+	}
+
+	for _, n := range roots {
+		run.Tests = append(run.Tests, n.build())
+	}
+	run.Output = output.String()
+	return run, scanner.Err()
+}
+
+// build converts the node tree into TestCases, keeping output only where it explains a result.
+func (n *node) build() TestCase {
+	tc := n.tc
+	out := n.output.String()
+	tc.Race = containsRace(out)
+	tc.Timeout = strings.Contains(out, timeoutMarker)
+
+	switch tc.Status {
+	case "fail":
+		tc.Output = out
+	case "skip":
+		tc.SkipReason = skipReason(out)
+	case "pass":
+	default:
+		// Started but never finished: the test binary crashed or was killed
+		tc.Status = "fail"
+		tc.Output = out
+	}
+
+	for _, c := range n.children {
+		tc.Subtests = append(tc.Subtests, c.build())
+	}
+	return tc
+}
+
+// isFrame reports whether the event is test framing (=== RUN, --- FAIL, ...)
+// rather than output written by the test itself.
+func isFrame(ev Event) bool {
+	if ev.OutputType != "" {
+		return ev.OutputType == "frame"
+	}
+	// Older toolchains don't set OutputType
+	trimmed := strings.TrimLeft(ev.Output, " ")
+	for _, prefix := range []string{"=== ", "--- PASS", "--- FAIL", "--- SKIP"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRace(s string) bool {
+	return containsAny(s, raceMarkers)
+}
+
+func containsAny(s string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipReason extracts the message passed to t.Skip, dropping the "file.go:12: " prefix.
+func skipReason(out string) string {
+	reason := strings.TrimSpace(out)
+	if file, msg, ok := strings.Cut(reason, ": "); ok && strings.Contains(file, ".go:") {
+		reason = msg
+	}
+	return reason
+}
+
+// Walk calls fn for every test and subtest, parents before their subtests.
+func (r *Run) Walk(fn func(TestCase)) {
+	var walk func([]TestCase)
+	walk = func(tests []TestCase) {
+		for _, tc := range tests {
+			fn(tc)
+			walk(tc.Subtests)
+		}
+	}
+	walk(r.Tests)
+}
+
+// Count returns the number of tests and subtests with the given status.
+func (r *Run) Count(status string) int {
+	n := 0
+	r.Walk(func(tc TestCase) {
+		if tc.Status == status {
+			n++
+		}
+	})
+	return n
+}
+
+// Failed returns the most specific failing tests: a failing test whose
+// subtests also failed is represented by those subtests.
+func (r *Run) Failed() []TestCase {
+	var failed []TestCase
+	var walk func([]TestCase)
+	walk = func(tests []TestCase) {
+		for _, tc := range tests {
+			if tc.Status != "fail" {
+				continue
+			}
+			before := len(failed)
+			walk(tc.Subtests)
+			if len(failed) == before {
+				failed = append(failed, tc)
+			}
+		}
+	}
+	walk(r.Tests)
+	return failed
+}
+
+// Racy returns the names of the tests during which the race detector fired.
+func (r *Run) Racy() []string {
+	var names []string
+	r.Walk(func(tc TestCase) {
+		if tc.Race {
+			names = append(names, tc.Name)
+		}
+	})
+	return names
+}
+
+// Hung returns the names of the tests that were running when the -timeout
+// expired.
+func (r *Run) Hung() []string {
+	var names []string
+	r.Walk(func(tc TestCase) {
+		if tc.Timeout {
+			names = append(names, tc.Name)
+		}
+	})
+	return names
+}
+
+// Passed returns the names, in the given order, that belong to a passing test
+// or subtest. Names of tests that failed, were skipped or never ran are left
+// out; Missing reports the ones that never ran.
+func (r *Run) Passed(names []string) []string {
+	var passed []string
+	for _, name := range names {
+		found := false
+		r.Walk(func(tc TestCase) {
+			if tc.Name == name && tc.Status == "pass" {
+				found = true
+			}
+		})
+		if found {
+			passed = append(passed, name)
+		}
+	}
+	return passed
+}
+
+// Missing returns the names, in the given order, that match no test or
+// subtest of the run, such as misspelt or renamed tests.
+func (r *Run) Missing(names []string) []string {
+	ran := make(map[string]bool)
+	r.Walk(func(tc TestCase) {
+		ran[tc.Name] = true
+	})
+	var missing []string
+	for _, name := range names {
+		if !ran[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// Change is a test whose status differs between two runs.
+type Change struct {
+	Name   string
+	Before string // "" when the test did not run before
+	After  string
+}
+
+// Diff returns the tests and subtests of cur whose status differs from prev,
+// in the order they ran. A nil prev treats every test as new.
+func Diff(prev, cur *Run) []Change {
+	before := make(map[string]string)
+	if prev != nil {
+		prev.Walk(func(tc TestCase) {
+			before[tc.Package+"\x00"+tc.Name] = tc.Status
+		})
+	}
+
+	var changes []Change
+	cur.Walk(func(tc TestCase) {
+		if status := before[tc.Package+"\x00"+tc.Name]; status != tc.Status {
+			changes = append(changes, Change{Name: tc.Name, Before: status, After: tc.Status})
+		}
+	})
+	return changes
+}
@@ -0,0 +1,253 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+)
+
+// stream is trimmed "go test -json -race" output for a package with a racy
+// test and a test with passing, failing and skipped subtests.
+const stream = `{"Action":"start","Package":"ex"}
+{"Action":"run","Package":"ex","Test":"TestRace"}
+{"Action":"output","Package":"ex","Test":"TestRace","Output":"=== RUN   TestRace\n","OutputType":"frame"}
+{"Action":"output","Package":"ex","Test":"TestRace","Output":"==================\n"}
+{"Action":"output","Package":"ex","Test":"TestRace","Output":"WARNING: DATA RACE\n"}
+{"Action":"output","Package":"ex","Test":"TestRace","Output":"Read at 0x00c0000182c8 by goroutine 10:\n"}
+{"Action":"output","Package":"ex","Test":"TestRace","Output":"    testing.go:1865: race detected during execution of test\n","OutputType":"error"}
+{"Action":"output","Package":"ex","Test":"TestRace","Output":"--- FAIL: TestRace (0.00s)\n","OutputType":"frame"}
+{"Action":"fail","Package":"ex","Test":"TestRace","Elapsed":0.01}
+{"Action":"run","Package":"ex","Test":"TestSub"}
+{"Action":"output","Package":"ex","Test":"TestSub","Output":"=== RUN   TestSub\n","OutputType":"frame"}
+{"Action":"run","Package":"ex","Test":"TestSub/ok"}
+{"Action":"output","Package":"ex","Test":"TestSub/ok","Output":"=== RUN   TestSub/ok\n","OutputType":"frame"}
+{"Action":"output","Package":"ex","Test":"TestSub/ok","Output":"    main_test.go:19: just logging\n"}
+{"Action":"pass","Package":"ex","Test":"TestSub/ok","Elapsed":0}
+{"Action":"run","Package":"ex","Test":"TestSub/bad_case"}
+{"Action":"output","Package":"ex","Test":"TestSub/bad_case","Output":"=== RUN   TestSub/bad_case\n","OutputType":"frame"}
+{"Action":"output","Package":"ex","Test":"TestSub/bad_case","Output":"    main_test.go:20: got 1, want 2\n","OutputType":"error"}
+{"Action":"output","Package":"ex","Test":"TestSub/bad_case","Output":"--- FAIL: TestSub/bad_case (0.00s)\n","OutputType":"frame"}
+{"Action":"fail","Package":"ex","Test":"TestSub/bad_case","Elapsed":0.02}
+{"Action":"run","Package":"ex","Test":"TestSub/skipped"}
+{"Action":"output","Package":"ex","Test":"TestSub/skipped","Output":"    main_test.go:21: needs network\n"}
+{"Action":"output","Package":"ex","Test":"TestSub/skipped","Output":"--- SKIP: TestSub/skipped (0.00s)\n","OutputType":"frame"}
+{"Action":"skip","Package":"ex","Test":"TestSub/skipped","Elapsed":0}
+{"Action":"output","Package":"ex","Test":"TestSub","Output":"--- FAIL: TestSub (0.00s)\n","OutputType":"frame"}
+{"Action":"fail","Package":"ex","Test":"TestSub","Elapsed":0.03}
+{"Action":"run","Package":"ex","Test":"TestHang"}
+{"Action":"output","Package":"ex","Test":"TestHang","Output":"panic: test timed out after 1s\n"}
+{"Action":"output","Package":"ex","Output":"FAIL\n"}
+{"Action":"fail","Package":"ex","Elapsed":0.05}
+`
+
+func TestParse(t *testing.T) {
+	run, err := Parse(strings.NewReader("# ex\nnote: not JSON\n" + stream))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(run.Tests) != 3 {
+		t.Fatalf("got %d top-level tests, want 3: %+v", len(run.Tests), run.Tests)
+	}
+
+	race := run.Tests[0]
+	if race.Name != "TestRace" || race.Status != "fail" || race.Elapsed != 0.01 || !race.Race {
+		t.Errorf("TestRace: got %+v", race)
+	}
+
+	sub := run.Tests[1]
+	if sub.Name != "TestSub" || sub.Status != "fail" || len(sub.Subtests) != 3 {
+		t.Fatalf("TestSub: got %+v", sub)
+	}
+	if sub.Race {
+		t.Error("TestSub: race must only be attributed to the test that triggered it")
+	}
+
+	ok, bad, skipped := sub.Subtests[0], sub.Subtests[1], sub.Subtests[2]
+	if ok.Status != "pass" || ok.Output != "" {
+		t.Errorf("passing subtest should not keep output: %+v", ok)
+	}
+	if bad.Name != "TestSub/bad_case" || bad.Status != "fail" {
+		t.Errorf("bad_case: got %+v", bad)
+	}
+	if want := "    main_test.go:20: got 1, want 2\n"; bad.Output != want {
+		t.Errorf("bad_case output: got %q, want %q", bad.Output, want)
+	}
+	if skipped.Status != "skip" || skipped.SkipReason != "needs network" {
+		t.Errorf("skipped: got %+v", skipped)
+	}
+
+	hang := run.Tests[2]
+	if hang.Status != "fail" || !strings.Contains(hang.Output, "timed out") || !hang.Timeout {
+		t.Errorf("unfinished test should fail with its output: %+v", hang)
+	}
+
+	if !run.RaceDetected {
+		t.Error("RaceDetected: got false, want true")
+	}
+	if !run.TimedOut {
+		t.Error("TimedOut: got false, want true")
+	}
+	if run.OutOfMemory {
+		t.Error("OutOfMemory: got true, want false")
+	}
+	for _, s := range []string{"# ex\n", "note: not JSON\n", "=== RUN   TestRace\n", "got 1, want 2", "FAIL\n"} {
+		if !strings.Contains(run.Output, s) {
+			t.Errorf("Output missing %q:\n%s", s, run.Output)
+		}
+	}
+}
+
+func TestRunHelpers(t *testing.T) {
+	run, err := Parse(strings.NewReader(stream))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if got := run.Count("fail"); got != 4 {
+		t.Errorf("Count(fail) = %d, want 4", got)
+	}
+
+	var failed []string
+	for _, tc := range run.Failed() {
+		failed = append(failed, tc.Name)
+	}
+	// TestSub is represented by its failing subtest
+	if got, want := strings.Join(failed, ","), "TestRace,TestSub/bad_case,TestHang"; got != want {
+		t.Errorf("Failed() = %s, want %s", got, want)
+	}
+
+	if got := run.Racy(); len(got) != 1 || got[0] != "TestRace" {
+		t.Errorf("Racy() = %v, want [TestRace]", got)
+	}
+	if got := run.Hung(); len(got) != 1 || got[0] != "TestHang" {
+		t.Errorf("Hung() = %v, want [TestHang]", got)
+	}
+
+	names := []string{"TestSub/ok", "TestSub", "TestSub/skipped", "TestMissing", "TestRace"}
+	if got := run.Passed(names); len(got) != 1 || got[0] != "TestSub/ok" {
+		t.Errorf("Passed(%v) = %v, want [TestSub/ok]", names, got)
+	}
+	if got := run.Missing(names); len(got) != 1 || got[0] != "TestMissing" {
+		t.Errorf("Missing(%v) = %v, want [TestMissing]", names, got)
+	}
+}
+
+func TestParseBuildFailure(t *testing.T) {
+	const failed = `{"ImportPath":"ex [ex.test]","Action":"build-output","Output":"# ex [ex.test]\n"}
+{"ImportPath":"ex [ex.test]","Action":"build-output","Output":"./main_test.go:15:7: p.Name undefined\n"}
+{"ImportPath":"ex [ex.test]","Action":"build-fail"}
+{"Action":"start","Package":"ex"}
+{"Action":"output","Package":"ex","Output":"FAIL\tex [build failed]\n","OutputType":"frame"}
+{"Action":"fail","Package":"ex","Elapsed":0,"FailedBuild":"ex [ex.test]"}
+`
+	run, err := Parse(strings.NewReader(failed))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !run.BuildFailed || len(run.Tests) != 0 {
+		t.Errorf("got BuildFailed=%v and %d tests, want true and none", run.BuildFailed, len(run.Tests))
+	}
+	if !strings.Contains(run.Output, "p.Name undefined") {
+		t.Errorf("Output = %q, want the compiler error", run.Output)
+	}
+}
+
+func TestParseOutOfMemory(t *testing.T) {
+	// A test allocating beyond the address space limit set by the validators
+	const oom = `{"Action":"run","Package":"ex","Test":"TestHog"}
+{"Action":"output","Package":"ex","Test":"TestHog","Output":"runtime: out of memory: cannot allocate 67108864-byte block (809271296 in use)\n"}
+{"Action":"output","Package":"ex","Test":"TestHog","Output":"fatal error: out of memory\n"}
+{"Action":"fail","Package":"ex","Elapsed":0.9}
+`
+	run, err := Parse(strings.NewReader(oom))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !run.OutOfMemory || run.TimedOut {
+		t.Errorf("got OutOfMemory=%v TimedOut=%v, want true, false", run.OutOfMemory, run.TimedOut)
+	}
+}
+
+func TestParseWithoutOutputType(t *testing.T) {
+	// Toolchains before Go 1.25 don't mark framing lines
+	const old = `{"Action":"run","Package":"ex","Test":"TestA"}
+{"Action":"output","Package":"ex","Test":"TestA","Output":"=== RUN   TestA\n"}
+{"Action":"output","Package":"ex","Test":"TestA","Output":"    main_test.go:8: boom\n"}
+{"Action":"output","Package":"ex","Test":"TestA","Output":"--- FAIL: TestA (0.00s)\n"}
+{"Action":"fail","Package":"ex","Test":"TestA","Elapsed":0}
+`
+	run, err := Parse(strings.NewReader(old))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if want := "    main_test.go:8: boom\n"; run.Tests[0].Output != want {
+		t.Errorf("output: got %q, want %q", run.Tests[0].Output, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	prev := &Run{Tests: []TestCase{
+		{Package: "ex", Name: "TestA", Status: "fail"},
+		{Package: "ex", Name: "TestB", Status: "pass", Subtests: []TestCase{
+			{Package: "ex", Name: "TestB/one", Status: "pass"},
+		}},
+	}}
+	cur := &Run{Tests: []TestCase{
+		{Package: "ex", Name: "TestA", Status: "pass"},
+		{Package: "ex", Name: "TestB", Status: "fail", Subtests: []TestCase{
+			{Package: "ex", Name: "TestB/one", Status: "pass"},
+			{Package: "ex", Name: "TestB/two", Status: "fail"},
+		}},
+	}}
+
+	want := []Change{
+		{Name: "TestA", Before: "fail", After: "pass"},
+		{Name: "TestB", Before: "pass", After: "fail"},
+		{Name: "TestB/two", Before: "", After: "fail"},
+	}
+	got := Diff(prev, cur)
+	if len(got) != len(want) {
+		t.Fatalf("Diff() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := Diff(nil, cur); len(got) != 4 {
+		t.Errorf("Diff(nil, cur) returned %d changes, want every test", len(got))
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{out: func(line string) { lines = append(lines, line) }}
+
+	// Written in chunks that split lines, ending without a newline
+	input := "# ex\n./main.go:3:2: undefined: x\n" + strings.TrimSuffix(stream, "\n")
+	for chunk := range strings.SplitSeq(input, "") {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.flush()
+
+	if w.stream.String() != input {
+		t.Error("stream not kept as written")
+	}
+	if got, want := len(lines), 2+strings.Count(stream, `"Action":"output"`); got != want {
+		t.Fatalf("got %d lines, want %d: %q", got, want, lines)
+	}
+	want := map[int]string{
+		0:              "# ex",
+		1:              "./main.go:3:2: undefined: x",
+		2:              "=== RUN   TestRace",
+		len(lines) - 1: "FAIL",
+	}
+	for i, line := range want {
+		if lines[i] != line {
+			t.Errorf("line %d = %q, want %q", i, lines[i], line)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Package hints splits an exercise's HINTS.md into levels that can be
+// revealed one at a time.
+package hints
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// File is the hints file name in an exercise directory.
+const File = "HINTS.md"
+
+// Level is one section of a hints file.
+type Level struct {
+	Number int    // 1-based, in file order
+	Title  string // heading text after the number, e.g. "Getting Started"
+	Body   string // markdown below the heading, trimmed
+}
+
+// levelHeading matches numbered hint headings such as "## Level 2: String
+// Reversal" or "## Hint 3".
+var levelHeading = regexp.MustCompile(`^##\s+(?:Level|Hint)\s+\d+\s*(?::\s*(.*))?$`)
+
+// section is a level-2 heading and the lines below it.
+type section struct {
+	heading string
+	lines   []string
+}
+
+// Parse splits a hints file into levels. Files with numbered headings
+// ("## Level 1: ..." or "## Hint 1: ...") yield one level per numbered
+// section; other level-2 sections after the first numbered one belong to the
+// level above them. Files without numbered headings yield one level per
+// level-2 section. Text before the first section, usually the file title, is
+// not part of any level.
+func Parse(data []byte) []Level {
+	var sections []section
+	inFence := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, "## ") {
+			sections = append(sections, section{heading: line})
+			continue
+		}
+		if len(sections) > 0 {
+			sections[len(sections)-1].lines = append(sections[len(sections)-1].lines, line)
+		}
+	}
+
+	numbered := false
+	for _, s := range sections {
+		if levelHeading.MatchString(s.heading) {
+			numbered = true
+			break
+		}
+	}
+
+	var levels []Level
+	for _, s := range sections {
+		body := strings.TrimSpace(strings.Join(s.lines, "\n"))
+		if !numbered {
+			title := strings.TrimSpace(strings.TrimPrefix(s.heading, "##"))
+			levels = append(levels, Level{Number: len(levels) + 1, Title: title, Body: body})
+			continue
+		}
+
+		m := levelHeading.FindStringSubmatch(s.heading)
+		if m == nil {
+			// A plain section continues the level above it
+			if len(levels) > 0 {
+				last := &levels[len(levels)-1]
+				last.Body = strings.TrimSpace(last.Body + "\n\n" + s.heading + "\n" + body)
+			}
+			continue
+		}
+		levels = append(levels, Level{Number: len(levels) + 1, Title: strings.TrimSpace(m[1]), Body: body})
+	}
+	return levels
+}
+
+// Load reads and parses the hints file of the exercise in dir.
+func Load(dir string) ([]Level, error) {
+	data, err := os.ReadFile(filepath.Join(dir, File))
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data), nil
+}
@@ -0,0 +1,54 @@
+package hints
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNumbered(t *testing.T) {
+	levels := Parse([]byte(`# Hints for Custom Errors
+
+Read the tests first.
+
+## Level 1: Getting Started
+
+- Strings are immutable
+
+## Hint 2
+
+` + "```go" + `
+## not a heading inside a code block
+` + "```" + `
+
+## Common Patterns
+
+- Two pointers
+
+## Level 3: Edge Cases
+- Empty input
+`))
+
+	want := []Level{
+		{Number: 1, Title: "Getting Started", Body: "- Strings are immutable"},
+		{Number: 2, Title: "", Body: "```go\n## not a heading inside a code block\n```\n\n## Common Patterns\n- Two pointers"},
+		{Number: 3, Title: "Edge Cases", Body: "- Empty input"},
+	}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("got %#v\nwant %#v", levels, want)
+	}
+}
+
+func TestParseUnnumbered(t *testing.T) {
+	levels := Parse([]byte("# Hints: Mutex\n\n## Key Concepts\n\nLock, then defer Unlock.\n\n## Testing\n\nRun with -race.\n"))
+
+	want := []Level{
+		{Number: 1, Title: "Key Concepts", Body: "Lock, then defer Unlock."},
+		{Number: 2, Title: "Testing", Body: "Run with -race."},
+	}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("got %#v\nwant %#v", levels, want)
+	}
+	if levels := Parse([]byte("# Hints\n\nNo sections.\n")); len(levels) != 0 {
+		t.Errorf("file without sections: got %d levels", len(levels))
+	}
+}
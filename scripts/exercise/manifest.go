@@ -0,0 +1,173 @@
+package exercise
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ManifestFile is the name of the metadata file every exercise directory declares.
+const ManifestFile = "exercise.json"
+
+// Categories lists the known exercise categories in curriculum order.
+var Categories = []string{"basics", "intermediate", "concurrency", "advanced", "projects"}
+
+// CategoryDir returns the repository directory holding a category's
+// exercises, e.g. "01-basics", or "" for an unknown category.
+func CategoryDir(category string) string {
+	i := slices.Index(Categories, category)
+	if i < 0 {
+		return ""
+	}
+	return fmt.Sprintf("%02d-%s", i+1, category)
+}
+
+// Manifest is the machine-readable description of an exercise, read from exercise.json.
+type Manifest struct {
+	Title         string   `json:"title"`
+	Category      string   `json:"category"`
+	Difficulty    int      `json:"difficulty"` // 1-5 stars
+	EstimatedTime Duration `json:"estimated_time"`
+	Race          bool     `json:"race"` // run tests with -race
+	Timeout       Duration `json:"timeout,omitempty"`
+	Prerequisites []string `json:"prerequisites,omitempty"` // exercise paths, e.g. "01-basics/01-string-manipulation"
+
+	// StarterMustFail names tests the starter code must not pass. When empty,
+	// the starter only has to fail at least one test.
+	StarterMustFail []string `json:"starter_must_fail,omitempty"`
+
+	// StarterExpect declares a starter whose tests cannot simply fail, as
+	// one of StarterExpectations: "build-failure" when the tests only compile
+	// once students define the types they use, "no-tests" when the exercise
+	// has no tests for the starter.
+	StarterExpect string `json:"starter_expect,omitempty"`
+
+	// Benchmarks pairs benchmarks whose optimized side must beat its
+	// baseline when run against the solution.
+	Benchmarks []BenchmarkPair `json:"benchmarks,omitempty"`
+
+	// Analyzers names static checks the lint command runs on this exercise
+	// on top of those of its category, e.g. "errcheck".
+	Analyzers []string `json:"analyzers,omitempty"`
+}
+
+// Starter expectations a manifest may declare instead of failing tests.
+var StarterExpectations = []string{"build-failure", "no-tests"}
+
+// Benchmark metrics, as reported by go test -benchmem.
+var BenchmarkMetrics = []string{"ns/op", "B/op", "allocs/op"}
+
+// BenchmarkPair requires Optimized to beat Baseline: the Baseline's metric
+// divided by the Optimized one must be at least MinRatio.
+type BenchmarkPair struct {
+	Baseline  string  `json:"baseline"`            // e.g. "BenchmarkStringConcat"
+	Optimized string  `json:"optimized"`           // e.g. "BenchmarkStringsBuilder"
+	Metric    string  `json:"metric,omitempty"`    // one of BenchmarkMetrics, default "ns/op"
+	MinRatio  float64 `json:"min_ratio,omitempty"` // default 1, merely faster
+}
+
+// MetricOrDefault returns the pair's metric, "ns/op" when unset.
+func (p BenchmarkPair) MetricOrDefault() string {
+	if p.Metric == "" {
+		return "ns/op"
+	}
+	return p.Metric
+}
+
+// MinRatioOrDefault returns the pair's minimum ratio, 1 when unset.
+func (p BenchmarkPair) MinRatioOrDefault() float64 {
+	if p.MinRatio == 0 {
+		return 1
+	}
+	return p.MinRatio
+}
+
+// TestTimeout returns the manifest's test timeout, or def when it sets none.
+func (m Manifest) TestTimeout(def time.Duration) time.Duration {
+	if m.Timeout.Duration > 0 {
+		return m.Timeout.Duration
+	}
+	return def
+}
+
+// Duration is a time.Duration that reads and writes as a string such as "30s" or "45m".
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// LoadManifest reads and validates the manifest in dir.
+func LoadManifest(dir string) (Manifest, error) {
+	var m Manifest
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("parsing %s: %w", ManifestFile, err)
+	}
+	if err := m.Validate(); err != nil {
+		return m, fmt.Errorf("invalid %s: %w", ManifestFile, err)
+	}
+
+	return m, nil
+}
+
+// Validate reports the first problem found in the manifest, if any.
+func (m Manifest) Validate() error {
+	if m.Title == "" {
+		return fmt.Errorf("missing title")
+	}
+	if !slices.Contains(Categories, m.Category) {
+		return fmt.Errorf("unknown category %q", m.Category)
+	}
+	if m.Difficulty < 1 || m.Difficulty > 5 {
+		return fmt.Errorf("difficulty must be between 1 and 5, got %d", m.Difficulty)
+	}
+	if m.Timeout.Duration < 0 {
+		return fmt.Errorf("negative timeout %s", m.Timeout)
+	}
+	for _, name := range m.StarterMustFail {
+		if !strings.HasPrefix(name, "Test") {
+			return fmt.Errorf("starter_must_fail: %q is not a test name", name)
+		}
+	}
+	if m.StarterExpect != "" && !slices.Contains(StarterExpectations, m.StarterExpect) {
+		return fmt.Errorf("unknown starter_expect %q", m.StarterExpect)
+	}
+	for _, p := range m.Benchmarks {
+		for _, name := range []string{p.Baseline, p.Optimized} {
+			if !strings.HasPrefix(name, "Benchmark") {
+				return fmt.Errorf("benchmarks: %q is not a benchmark name", name)
+			}
+		}
+		if p.Metric != "" && !slices.Contains(BenchmarkMetrics, p.Metric) {
+			return fmt.Errorf("benchmarks: unknown metric %q", p.Metric)
+		}
+		if p.MinRatio < 0 {
+			return fmt.Errorf("benchmarks: negative min_ratio %g", p.MinRatio)
+		}
+	}
+	return nil
+}
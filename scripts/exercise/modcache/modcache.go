@@ -0,0 +1,159 @@
+// Package modcache checks and fills the Go module cache used by exercises, so
+// the validators can run on machines without network access.
+package modcache
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// LocalDir is the directory, relative to the repository root, that may hold
+// a module cache shipped with the repository for offline use.
+const LocalDir = ".modcache"
+
+// Module is a module version required by an exercise.
+type Module struct {
+	Path    string
+	Version string
+}
+
+func (m Module) String() string {
+	return m.Path + "@" + m.Version
+}
+
+// Default returns the module cache the go command uses, honoring GOMODCACHE.
+func Default() (string, error) {
+	out, err := exec.Command("go", "env", "GOMODCACHE").Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOMODCACHE: %w", err)
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "" {
+		return "", fmt.Errorf("go env GOMODCACHE is empty")
+	}
+	return dir, nil
+}
+
+// Find returns the module cache to use offline: the repository's LocalDir if
+// it exists, and the go command's cache otherwise.
+func Find(repoRoot string) (string, error) {
+	local := filepath.Join(repoRoot, LocalDir)
+	if info, err := os.Stat(local); err == nil && info.IsDir() {
+		return local, nil
+	}
+	return Default()
+}
+
+// Env returns the environment settings that make go commands use only the
+// modules in cache: no proxy, no checksum database and no toolchain
+// downloads. Modules are still checked against go.sum.
+func Env(cache string) []string {
+	return []string{"GOMODCACHE=" + cache, "GOPROXY=off", "GOSUMDB=off", "GOTOOLCHAIN=local"}
+}
+
+// Vendored reports whether the module in dir vendors its dependencies, in
+// which case it does not need the module cache.
+func Vendored(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "vendor", "modules.txt"))
+	return err == nil
+}
+
+// Missing returns the modules required by the go.mod in dir whose go.mod
+// file or source is not in cache, sorted by path. Since Go 1.17, go.mod
+// requires every module that provides packages to the build, so these are
+// exactly the modules an offline build would fail to find. Vendored modules
+// miss nothing.
+func Missing(dir, cache string) ([]Module, error) {
+	if Vendored(dir) {
+		return nil, nil
+	}
+	required, err := requirements(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []Module
+	for _, m := range required {
+		files := []string{
+			filepath.Join(cache, "cache", "download", escape(m.Path), "@v", escape(m.Version)+".mod"),
+			filepath.Join(cache, escape(m.Path)+"@"+escape(m.Version)),
+		}
+		for _, file := range files {
+			if _, err := os.Stat(file); err != nil {
+				missing = append(missing, m)
+				break
+			}
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].Path < missing[j].Path })
+	return missing, nil
+}
+
+// requirements reads the require and replace directives of a go.mod file.
+// Required modules replaced by another module version are returned as the
+// replacement; those replaced by a local directory are left out.
+func requirements(gomod string) ([]Module, error) {
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return nil, err
+	}
+	file, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := make(map[module.Version]module.Version) // a replacement without version is a directory
+	for _, r := range file.Replace {
+		replaced[r.Old] = r.New
+	}
+
+	modules := make([]Module, 0, len(file.Require))
+	for _, req := range file.Require {
+		r, ok := replaced[req.Mod]
+		if !ok {
+			r, ok = replaced[module.Version{Path: req.Mod.Path}]
+		}
+		switch {
+		case !ok:
+			modules = append(modules, Module{req.Mod.Path, req.Mod.Version})
+		case r.Version != "":
+			modules = append(modules, Module{r.Path, r.Version})
+		}
+	}
+	return modules, nil
+}
+
+// escape applies the module cache's case encoding: every upper-case letter
+// is replaced by "!" and its lower-case form, e.g. "github.com/!burnt!sushi".
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Seed downloads everything the module in dir needs to build and test into
+// cache, using the proxy configured in the environment. The cache is left
+// writable so it can be removed with rm -rf.
+func Seed(dir, cache string) error {
+	cmd := exec.Command("go", "mod", "download", "-modcacherw")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOMODCACHE="+cache)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package modcache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"exercise/internal/testfiles"
+)
+
+const gomod = `module example.com/ex
+
+go 1.25
+
+require github.com/BurntSushi/toml v1.5.0 // direct
+
+require (
+	// indirect dependencies
+	golang.org/x/text v0.30.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+	example.com/forked v1.0.0
+	example.com/local v0.0.0
+)
+
+replace example.com/forked v1.0.0 => example.com/fork v1.2.0
+
+replace (
+	example.com/local => ../local
+)
+`
+
+func TestMissing(t *testing.T) {
+	dir, cache := t.TempDir(), t.TempDir()
+	testfiles.Write(t, dir, map[string]string{"go.mod": gomod})
+	testfiles.Write(t, cache, map[string]string{
+		// Complete: go.mod file and extracted source
+		"cache/download/github.com/!burnt!sushi/toml/@v/v1.5.0.mod": "module github.com/BurntSushi/toml\n",
+		"github.com/!burnt!sushi/toml@v1.5.0/go.mod":                "module github.com/BurntSushi/toml\n",
+		"cache/download/example.com/fork/@v/v1.2.0.mod":             "module example.com/fork\n",
+		"example.com/fork@v1.2.0/go.mod":                            "module example.com/fork\n",
+		// Only the go.mod file: the source was never downloaded
+		"cache/download/golang.org/x/text/@v/v0.30.0.mod": "module golang.org/x/text\n",
+	})
+
+	missing, err := Missing(dir, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, m := range missing {
+		got = append(got, m.String())
+	}
+	want := "golang.org/x/sys@v0.38.0 golang.org/x/text@v0.30.0"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Missing = %v, want %s", got, want)
+	}
+
+	// Vendored dependencies do not need the cache
+	testfiles.Write(t, dir, map[string]string{"vendor/modules.txt": "# golang.org/x/sys v0.38.0\n"})
+	if missing, err := Missing(dir, cache); err != nil || len(missing) != 0 {
+		t.Errorf("vendored: Missing = %v, %v", missing, err)
+	}
+}
+
+func TestMissingWithoutRequirements(t *testing.T) {
+	dir := t.TempDir()
+	testfiles.Write(t, dir, map[string]string{"go.mod": "module example.com/ex\n\ngo 1.25\n"})
+	if missing, err := Missing(dir, t.TempDir()); err != nil || len(missing) != 0 {
+		t.Errorf("Missing = %v, %v", missing, err)
+	}
+
+	if _, err := Missing(t.TempDir(), t.TempDir()); err == nil {
+		t.Error("expected an error without go.mod")
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, LocalDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Find(root)
+	if err != nil || got != filepath.Join(root, LocalDir) {
+		t.Errorf("Find = %q, %v; want the repository cache", got, err)
+	}
+}
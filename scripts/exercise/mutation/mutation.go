@@ -0,0 +1,204 @@
+// Package mutation generates mutants of Go source code: copies with one
+// small change each, such as a flipped comparison. A test suite that still
+// passes against a mutant does not constrain the code the mutant changed.
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Mutant is a single change to a source file.
+type Mutant struct {
+	Line, Column int
+	Description  string // e.g. "changed < to <="
+
+	start, end  int // byte range replaced
+	replacement string
+}
+
+func (m Mutant) String() string {
+	return fmt.Sprintf("%d:%d: %s", m.Line, m.Column, m.Description)
+}
+
+// Apply returns a copy of src with the mutation applied. src must be the
+// source the mutant was generated from.
+func (m Mutant) Apply(src []byte) []byte {
+	out := make([]byte, 0, len(src)-(m.end-m.start)+len(m.replacement))
+	out = append(out, src[:m.start]...)
+	out = append(out, m.replacement...)
+	return append(out, src[m.end:]...)
+}
+
+// negated and boundary map comparison operators to their mutations.
+var (
+	negated = map[token.Token]token.Token{
+		token.EQL: token.NEQ, token.NEQ: token.EQL,
+		token.LSS: token.GEQ, token.GEQ: token.LSS,
+		token.GTR: token.LEQ, token.LEQ: token.GTR,
+		token.LAND: token.LOR, token.LOR: token.LAND,
+	}
+	boundary = map[token.Token]token.Token{
+		token.LSS: token.LEQ, token.LEQ: token.LSS,
+		token.GTR: token.GEQ, token.GEQ: token.GTR,
+	}
+)
+
+// Generate returns the mutants of a Go source file in source order:
+//
+//   - comparisons are negated (< becomes >=) and moved across their
+//     boundary (< becomes <=), && and || are swapped
+//   - statements with side effects (calls, assignments, ++, sends, defer
+//     and go) are removed
+//   - integer and float constants change (0 to 1, 1 to 0, n to n+1) and
+//     true and false are swapped
+//
+// Func main is left alone: exercise tests do not run it. String constants
+// are not mutated either, as they are mostly messages that tests rightly
+// do not pin down.
+func Generate(filename string, src []byte) ([]Mutant, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutants []Mutant
+	add := func(pos, end token.Pos, replacement, description string) {
+		p := fset.Position(pos)
+		mutants = append(mutants, Mutant{
+			Line:        p.Line,
+			Column:      p.Column,
+			Description: description,
+			start:       p.Offset,
+			end:         fset.Position(end).Offset,
+			replacement: replacement,
+		})
+	}
+	dropStatements := func(list []ast.Stmt) {
+		for _, stmt := range list {
+			switch s := stmt.(type) {
+			case *ast.AssignStmt:
+				// Removing a declaration leaves its uses undefined
+				if s.Tok == token.DEFINE {
+					continue
+				}
+			case *ast.ExprStmt, *ast.IncDecStmt, *ast.SendStmt, *ast.DeferStmt, *ast.GoStmt:
+			default:
+				continue
+			}
+			text := string(src[fset.Position(stmt.Pos()).Offset:fset.Position(stmt.End()).Offset])
+			add(stmt.Pos(), stmt.End(), "", "removed "+summarize(text))
+		}
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Recv == nil && n.Name.Name == "main" {
+				return false
+			}
+		case *ast.ArrayType:
+			// Array lengths are part of types: changing them does not compile
+			if n.Len != nil {
+				return false
+			}
+		case *ast.BinaryExpr:
+			if op, ok := negated[n.Op]; ok {
+				add(n.OpPos, n.OpPos+token.Pos(len(n.Op.String())), op.String(), fmt.Sprintf("changed %s to %s", n.Op, op))
+			}
+			if op, ok := boundary[n.Op]; ok {
+				add(n.OpPos, n.OpPos+token.Pos(len(n.Op.String())), op.String(), fmt.Sprintf("changed %s to %s", n.Op, op))
+			}
+		case *ast.BlockStmt:
+			dropStatements(n.List)
+		case *ast.CaseClause:
+			dropStatements(n.Body)
+		case *ast.CommClause:
+			dropStatements(n.Body)
+		case *ast.BasicLit:
+			if value, ok := mutateNumber(n); ok {
+				add(n.Pos(), n.End(), value, fmt.Sprintf("changed %s to %s", n.Value, value))
+			}
+		case *ast.Ident:
+			switch n.Name {
+			case "true":
+				add(n.Pos(), n.End(), "false", "changed true to false")
+			case "false":
+				add(n.Pos(), n.End(), "true", "changed false to true")
+			}
+		}
+		return true
+	})
+
+	sort.SliceStable(mutants, func(i, j int) bool { return mutants[i].start < mutants[j].start })
+	return mutants, nil
+}
+
+// mutateNumber returns the replacement of an integer or float literal.
+func mutateNumber(lit *ast.BasicLit) (string, bool) {
+	switch lit.Kind {
+	case token.INT:
+		n, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			return "", false
+		}
+		switch n {
+		case 0:
+			return "1", true
+		case 1:
+			return "0", true
+		}
+		return strconv.FormatInt(n+1, 10), true
+	case token.FLOAT:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(lit.Value, "_", ""), 64)
+		if err != nil {
+			return "", false
+		}
+		value := strconv.FormatFloat(f+1, 'g', -1, 64)
+		if !strings.ContainsAny(value, ".eE") {
+			value += ".0"
+		}
+		return value, true
+	}
+	return "", false
+}
+
+// summarize shortens a removed statement to its first line.
+func summarize(stmt string) string {
+	line, _, cut := strings.Cut(stmt, "\n")
+	if runes := []rune(line); len(runes) > 40 {
+		line, cut = string(runes[:40]), true
+	}
+	if cut {
+		line += "…"
+	}
+	return "`" + line + "`"
+}
+
+// Sample returns at most n mutants spread evenly over mutants, keeping their
+// order. n <= 0 keeps them all.
+func Sample(mutants []Mutant, n int) []Mutant {
+	if n <= 0 || len(mutants) <= n {
+		return mutants
+	}
+	sampled := make([]Mutant, n)
+	for i := range sampled {
+		sampled[i] = mutants[i*len(mutants)/n]
+	}
+	return sampled
+}
+
+// Score is the share of mutants the tests killed, in percent. Mutants that
+// do not build are not counted; there being none to kill scores 100.
+func Score(killed, survived int) float64 {
+	if killed+survived == 0 {
+		return 100
+	}
+	return float64(killed) * 100 / float64(killed+survived)
+}
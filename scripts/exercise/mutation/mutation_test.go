@@ -0,0 +1,120 @@
+package mutation
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const src = `package main
+
+import "fmt"
+
+var grid [3]int
+
+func Clamp(n, max int) int {
+	if n > max && max >= 0 {
+		return max
+	}
+	total := 0
+	total += n
+	fmt.Println(total)
+	return n * 2
+}
+
+func IsEven(n int) bool {
+	switch {
+	case n%2 == 0:
+		return true
+	}
+	return false
+}
+
+func main() {
+	fmt.Println(Clamp(5, 3) < 10)
+}
+`
+
+func TestGenerate(t *testing.T) {
+	mutants, err := Generate("main.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, m := range mutants {
+		got = append(got, m.String())
+		// Every mutant is still valid Go syntax
+		if _, err := parser.ParseFile(token.NewFileSet(), "", m.Apply([]byte(src)), 0); err != nil {
+			t.Errorf("%s: %v", m, err)
+		}
+	}
+	want := []string{
+		"8:7: changed > to <=",
+		"8:7: changed > to >=",
+		"8:13: changed && to ||",
+		"8:20: changed >= to <",
+		"8:20: changed >= to >",
+		"8:23: changed 0 to 1",
+		"11:11: changed 0 to 1",
+		"12:2: removed `total += n`",
+		"13:2: removed `fmt.Println(total)`",
+		"14:13: changed 2 to 3",
+		"19:9: changed 2 to 3",
+		"19:11: changed == to !=",
+		"19:14: changed 0 to 1",
+		"20:10: changed true to false",
+		"22:9: changed false to true",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("mutants:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	mutated := string(mutants[2].Apply([]byte(src)))
+	if !strings.Contains(mutated, "if n > max || max >= 0 {") {
+		t.Errorf("Apply:\n%s", mutated)
+	}
+}
+
+func TestMutateFloat(t *testing.T) {
+	mutants, err := Generate("main.go", []byte("package main\n\nvar ratio = 0.5 + 1e3 + 1_000\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, m := range mutants {
+		got = append(got, m.Description)
+	}
+	want := "changed 0.5 to 1.5, changed 1e3 to 1001.0, changed 1_000 to 1001"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("got %q, want %q", strings.Join(got, ", "), want)
+	}
+}
+
+func TestSample(t *testing.T) {
+	mutants := make([]Mutant, 10)
+	for i := range mutants {
+		mutants[i].Line = i
+	}
+	sampled := Sample(mutants, 4)
+	var lines []int
+	for _, m := range sampled {
+		lines = append(lines, m.Line)
+	}
+	if len(lines) != 4 || lines[0] != 0 || lines[1] != 2 || lines[2] != 5 || lines[3] != 7 {
+		t.Errorf("Sample = %v", lines)
+	}
+	if len(Sample(mutants, 0)) != 10 || len(Sample(mutants, 20)) != 10 {
+		t.Error("Sample should keep all mutants when n <= 0 or n >= len")
+	}
+}
+
+func TestScore(t *testing.T) {
+	if got := Score(3, 1); got != 75 {
+		t.Errorf("Score(3, 1) = %v", got)
+	}
+	if got := Score(0, 0); got != 100 {
+		t.Errorf("Score(0, 0) = %v", got)
+	}
+}
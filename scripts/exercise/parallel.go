@@ -0,0 +1,52 @@
+package exercise
+
+import "sync"
+
+// RunParallel calls run for every exercise using at most workers goroutines.
+//
+// emit is called from the calling goroutine with each result in input order,
+// as soon as that result and all earlier ones are available, so callers can
+// print results without interleaving or reordering. The results are also
+// returned in input order.
+func RunParallel[R any](exercises []Exercise, workers int, run func(Exercise) R, emit func(R)) []R {
+	if workers < 1 {
+		workers = 1
+	}
+	workers = min(workers, len(exercises))
+
+	results := make([]R, len(exercises))
+	done := make([]chan struct{}, len(exercises))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = run(exercises[i])
+				close(done[i])
+			}
+		}()
+	}
+
+	go func() {
+		for i := range exercises {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	for i := range exercises {
+		<-done[i]
+		if emit != nil {
+			emit(results[i])
+		}
+	}
+
+	wg.Wait()
+	return results
+}
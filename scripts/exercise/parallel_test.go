@@ -0,0 +1,67 @@
+package exercise
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunParallel(t *testing.T) {
+	exercises := make([]Exercise, 20)
+	for i := range exercises {
+		exercises[i].Path = fmt.Sprintf("ex-%02d", i)
+		// Later exercises finish first, so emit has to reorder
+		exercises[i].Timeout.Duration = time.Duration(len(exercises)-i) * time.Millisecond
+	}
+
+	const workers = 4
+	var running, peak atomic.Int32
+
+	run := func(ex Exercise) string {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(ex.Timeout.Duration)
+		running.Add(-1)
+		return ex.Path
+	}
+
+	var emitted []string
+	results := RunParallel(exercises, workers, run, func(r string) {
+		emitted = append(emitted, r)
+	})
+
+	for i, ex := range exercises {
+		if results[i] != ex.Path {
+			t.Errorf("result %d: got %q, want %q", i, results[i], ex.Path)
+		}
+		if emitted[i] != ex.Path {
+			t.Errorf("emitted %d: got %q, want %q", i, emitted[i], ex.Path)
+		}
+	}
+	if p := peak.Load(); p > workers {
+		t.Errorf("ran %d exercises at once, want at most %d", p, workers)
+	}
+}
+
+func TestRunParallelSequential(t *testing.T) {
+	exercises := []Exercise{{Path: "a"}, {Path: "b"}, {Path: "c"}}
+
+	var running atomic.Int32
+	results := RunParallel(exercises, 0, func(ex Exercise) string {
+		if running.Add(1) > 1 {
+			t.Error("workers < 1 must run sequentially")
+		}
+		defer running.Add(-1)
+		return ex.Path
+	}, nil)
+
+	if len(results) != len(exercises) {
+		t.Fatalf("got %d results, want %d", len(results), len(exercises))
+	}
+}
@@ -0,0 +1,47 @@
+package progress
+
+import "time"
+
+// HintUse records a hint level being revealed.
+type HintUse struct {
+	Level int       `json:"level"`
+	Time  time.Time `json:"time"`
+	Runs  int       `json:"runs"` // test runs of the exercise at the time
+}
+
+// HintPolicy decides when the next hint level unlocks: after Runs more test
+// runs of the exercise or after Wait, counted from the previous level,
+// whichever comes first. The first level is always unlocked.
+type HintPolicy struct {
+	Runs int
+	Wait time.Duration
+}
+
+// HintsRevealed returns the number of hint levels revealed so far.
+func (eh *ExerciseHistory) HintsRevealed() int {
+	if eh == nil {
+		return 0
+	}
+	return len(eh.Hints)
+}
+
+// HintUnlocked reports whether the next hint level may be revealed at now.
+// When it may not, it returns the test runs still needed and the time left,
+// either of which unlocks it.
+func (eh *ExerciseHistory) HintUnlocked(p HintPolicy, now time.Time) (ok bool, runsLeft int, wait time.Duration) {
+	if eh == nil || len(eh.Hints) == 0 {
+		return true, 0, 0
+	}
+	last := eh.Hints[len(eh.Hints)-1]
+	runsLeft = max(p.Runs-(eh.Runs-last.Runs), 0)
+	wait = max(last.Time.Add(p.Wait).Sub(now), 0)
+	return runsLeft == 0 || wait == 0, runsLeft, wait
+}
+
+// RevealHint records the next hint level of the exercise as revealed at now
+// and returns its number. Callers check HintUnlocked first.
+func (h *History) RevealHint(path string, now time.Time) int {
+	eh := h.Exercise(path)
+	eh.Hints = append(eh.Hints, HintUse{Level: len(eh.Hints) + 1, Time: now, Runs: eh.Runs})
+	return len(eh.Hints)
+}
@@ -0,0 +1,43 @@
+package progress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHintUnlocked(t *testing.T) {
+	h := &History{Exercises: make(map[string]*ExerciseHistory)}
+	policy := HintPolicy{Runs: 2, Wait: 10 * time.Minute}
+	now := time.Now()
+	path := "01-basics/01-a"
+
+	if ok, _, _ := h.Exercises[path].HintUnlocked(policy, now); !ok {
+		t.Fatal("first hint should always be unlocked")
+	}
+	if level := h.RevealHint(path, now); level != 1 {
+		t.Fatalf("RevealHint() = %d, want 1", level)
+	}
+
+	eh := h.Exercises[path]
+	ok, runsLeft, wait := eh.HintUnlocked(policy, now.Add(4*time.Minute))
+	if ok || runsLeft != 2 || wait != 6*time.Minute {
+		t.Errorf("HintUnlocked() = %v, %d, %s; want locked, 2 runs, 6m", ok, runsLeft, wait)
+	}
+
+	h.Record(path, Attempt{Time: now, Status: "fail"})
+	if ok, runsLeft, _ := eh.HintUnlocked(policy, now); ok || runsLeft != 1 {
+		t.Errorf("after one run: unlocked %v with %d runs left, want locked with 1", ok, runsLeft)
+	}
+	h.Record(path, Attempt{Time: now, Status: "fail"})
+	if ok, _, _ := eh.HintUnlocked(policy, now); !ok {
+		t.Error("hint should unlock after enough runs")
+	}
+
+	h.RevealHint(path, now)
+	if ok, _, _ := eh.HintUnlocked(policy, now.Add(10*time.Minute)); !ok {
+		t.Error("hint should unlock after waiting")
+	}
+	if eh.HintsRevealed() != 2 || eh.Hints[1].Level != 2 || eh.Hints[1].Runs != 2 {
+		t.Errorf("got hints %+v", eh.Hints)
+	}
+}
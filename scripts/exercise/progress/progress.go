@@ -0,0 +1,220 @@
+// Package progress keeps a student's local history of exercise test runs.
+//
+// The history is a JSON file at the repository root (DefaultFile) that is
+// never committed. It is shared by the student-facing tools: every test run
+// is counted, and an attempt is recorded whenever an exercise's result
+// changes. The hint levels revealed to the student are recorded too.
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"exercise"
+)
+
+// DefaultFile is the history file name, relative to the repository root.
+const DefaultFile = ".progress.json"
+
+// Attempt is the result of a test run that differed from the previous one.
+type Attempt struct {
+	Time   time.Time `json:"time"`
+	Status string    `json:"status"` // "pass", "fail"
+	Passed int       `json:"passed"` // passing top-level tests
+	Total  int       `json:"total"`  // top-level tests
+}
+
+// ExerciseHistory is everything recorded for one exercise.
+type ExerciseHistory struct {
+	Runs     int       `json:"runs"` // every test run, including unchanged results
+	LastRun  time.Time `json:"last_run"`
+	Attempts []Attempt `json:"attempts,omitempty"`
+	Hints    []HintUse `json:"hints,omitempty"` // hint levels revealed, in order
+}
+
+// Last returns the most recent attempt, if any.
+func (eh *ExerciseHistory) Last() (Attempt, bool) {
+	if eh == nil || len(eh.Attempts) == 0 {
+		return Attempt{}, false
+	}
+	return eh.Attempts[len(eh.Attempts)-1], true
+}
+
+// History is the persisted progress of one student, keyed by exercise path.
+type History struct {
+	Exercises map[string]*ExerciseHistory `json:"exercises"`
+}
+
+// Load reads the history file at path. A missing file is an empty history.
+func Load(path string) (*History, error) {
+	h := &History{Exercises: make(map[string]*ExerciseHistory)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if h.Exercises == nil {
+		h.Exercises = make(map[string]*ExerciseHistory)
+	}
+	return h, nil
+}
+
+// Save writes the history to path. The file is replaced atomically so an
+// interrupted run never leaves a truncated history behind.
+func (h *History) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Exercise returns the history of an exercise, creating it if needed.
+func (h *History) Exercise(path string) *ExerciseHistory {
+	eh, ok := h.Exercises[path]
+	if !ok {
+		eh = &ExerciseHistory{}
+		h.Exercises[path] = eh
+	}
+	return eh
+}
+
+// Record counts a test run of the exercise and stores it as an attempt when
+// its status or number of passing tests changed since the last one. It
+// reports whether an attempt was added.
+func (h *History) Record(path string, a Attempt) bool {
+	eh := h.Exercise(path)
+	eh.Runs++
+	eh.LastRun = a.Time
+
+	if last, ok := eh.Last(); ok && last.Status == a.Status && last.Passed == a.Passed && last.Total == a.Total {
+		return false
+	}
+	eh.Attempts = append(eh.Attempts, a)
+	return true
+}
+
+// Completed reports whether the latest recorded run of the exercise passed.
+func (h *History) Completed(path string) bool {
+	last, ok := h.Exercises[path].Last()
+	return ok && last.Status == "pass"
+}
+
+// Streak returns the current and longest number of consecutive days on which
+// the student made progress, i.e. an exercise gained passing tests. The
+// current streak is still alive when the last progress was yesterday.
+func (h *History) Streak(now time.Time) (current, longest int) {
+	days := make(map[time.Time]bool)
+	for _, eh := range h.Exercises {
+		var prev Attempt
+		for _, a := range eh.Attempts {
+			if a.Passed > prev.Passed || (a.Status == "pass" && prev.Status != "pass") {
+				days[day(a.Time)] = true
+			}
+			prev = a
+		}
+	}
+	if len(days) == 0 {
+		return 0, 0
+	}
+
+	sorted := make([]time.Time, 0, len(days))
+	for d := range days {
+		sorted = append(sorted, d)
+	}
+	slices.SortFunc(sorted, func(a, b time.Time) int { return a.Compare(b) })
+
+	run := 0
+	for i, d := range sorted {
+		if i > 0 && sorted[i-1].AddDate(0, 0, 1).Equal(d) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+
+	today := day(now)
+	last := sorted[len(sorted)-1]
+	if last.Equal(today) || last.AddDate(0, 0, 1).Equal(today) {
+		current = run
+	}
+	return current, longest
+}
+
+// day truncates t to local midnight.
+func day(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// Next recommends the exercise to work on: among the unfinished exercises
+// whose prerequisites are completed, the easiest one of the earliest
+// category, in curriculum order. When no unfinished exercise is ready, the
+// first unfinished one is returned. It returns false when nothing is left.
+func (h *History) Next(exercises []exercise.Exercise) (exercise.Exercise, bool) {
+	var best, first exercise.Exercise
+	found, unfinished := false, false
+	for _, ex := range exercises {
+		if h.Completed(ex.Path) {
+			continue
+		}
+		if !unfinished {
+			first, unfinished = ex, true
+		}
+		if h.ready(ex) && (!found || better(ex, best)) {
+			best, found = ex, true
+		}
+	}
+	if !found {
+		return first, unfinished
+	}
+	return best, true
+}
+
+// ready reports whether all prerequisites of ex are completed.
+func (h *History) ready(ex exercise.Exercise) bool {
+	for _, p := range ex.Prerequisites {
+		if !h.Completed(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// better orders recommendation candidates by category, then difficulty.
+// Exercises are passed in curriculum order, so ties keep the earlier one.
+func better(a, b exercise.Exercise) bool {
+	ca := slices.Index(exercise.Categories, a.Category)
+	cb := slices.Index(exercise.Categories, b.Category)
+	if ca != cb {
+		return ca < cb
+	}
+	return a.Difficulty < b.Difficulty
+}
@@ -0,0 +1,122 @@
+package progress
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"exercise"
+)
+
+func TestRecord(t *testing.T) {
+	h := &History{Exercises: make(map[string]*ExerciseHistory)}
+	now := time.Now()
+
+	if !h.Record("01-basics/01-a", Attempt{Time: now, Status: "fail", Passed: 1, Total: 3}) {
+		t.Error("first run should be recorded as an attempt")
+	}
+	if h.Record("01-basics/01-a", Attempt{Time: now.Add(time.Minute), Status: "fail", Passed: 1, Total: 3}) {
+		t.Error("unchanged result should not add an attempt")
+	}
+	if h.Completed("01-basics/01-a") {
+		t.Error("failing exercise reported as completed")
+	}
+	if !h.Record("01-basics/01-a", Attempt{Time: now.Add(2 * time.Minute), Status: "pass", Passed: 3, Total: 3}) {
+		t.Error("changed result should be recorded")
+	}
+
+	eh := h.Exercises["01-basics/01-a"]
+	if eh.Runs != 3 || len(eh.Attempts) != 2 || !eh.LastRun.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("got %+v", eh)
+	}
+	if !h.Completed("01-basics/01-a") {
+		t.Error("passing exercise not reported as completed")
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+
+	h, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of missing file: %v", err)
+	}
+	h.Record("01-basics/01-a", Attempt{Time: time.Now(), Status: "pass", Passed: 2, Total: 2})
+	if err := h.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !loaded.Completed("01-basics/01-a") || loaded.Exercises["01-basics/01-a"].Runs != 1 {
+		t.Errorf("round trip lost data: %+v", loaded.Exercises)
+	}
+}
+
+func TestStreak(t *testing.T) {
+	today := time.Date(2025, 3, 10, 15, 0, 0, 0, time.Local)
+	daysAgo := func(n int) time.Time { return today.AddDate(0, 0, -n) }
+
+	h := &History{Exercises: map[string]*ExerciseHistory{
+		"01-basics/01-a": {Attempts: []Attempt{
+			{Time: daysAgo(9), Status: "fail", Passed: 1},
+			{Time: daysAgo(8), Status: "fail", Passed: 2},
+			{Time: daysAgo(7), Status: "pass", Passed: 3},
+		}},
+		"01-basics/02-b": {Attempts: []Attempt{
+			{Time: daysAgo(2), Status: "fail", Passed: 1},
+			{Time: daysAgo(1), Status: "pass", Passed: 2},
+			// Regressions are not progress
+			{Time: today, Status: "fail", Passed: 0},
+		}},
+	}}
+
+	current, longest := h.Streak(today)
+	if current != 2 || longest != 3 {
+		t.Errorf("Streak() = %d, %d; want 2, 3", current, longest)
+	}
+	if current, _ := h.Streak(today.AddDate(0, 0, 2)); current != 0 {
+		t.Errorf("streak should end after a day without progress, got %d", current)
+	}
+}
+
+func TestNext(t *testing.T) {
+	exercises := []exercise.Exercise{
+		{Path: "01-basics/01-a", Manifest: exercise.Manifest{Category: "basics", Difficulty: 1}},
+		{Path: "01-basics/02-b", Manifest: exercise.Manifest{Category: "basics", Difficulty: 3}},
+		{Path: "01-basics/03-c", Manifest: exercise.Manifest{Category: "basics", Difficulty: 2}},
+		{Path: "01-basics/04-d", Manifest: exercise.Manifest{Category: "basics", Difficulty: 1, Prerequisites: []string{"01-basics/02-b"}}},
+		{Path: "02-intermediate/01-e", Manifest: exercise.Manifest{Category: "intermediate", Difficulty: 1}},
+	}
+	h := &History{Exercises: make(map[string]*ExerciseHistory)}
+	pass := func(path string) { h.Record(path, Attempt{Time: time.Now(), Status: "pass"}) }
+
+	next := func() string {
+		ex, ok := h.Next(exercises)
+		if !ok {
+			return ""
+		}
+		return ex.Path
+	}
+
+	if got := next(); got != "01-basics/01-a" {
+		t.Errorf("Next() = %s, want 01-basics/01-a", got)
+	}
+	pass("01-basics/01-a")
+	// Easier exercise first; 04-d is blocked by its prerequisite
+	if got := next(); got != "01-basics/03-c" {
+		t.Errorf("Next() = %s, want 01-basics/03-c", got)
+	}
+	pass("01-basics/03-c")
+	pass("01-basics/02-b")
+	if got := next(); got != "01-basics/04-d" {
+		t.Errorf("Next() = %s, want 01-basics/04-d", got)
+	}
+	pass("01-basics/04-d")
+	pass("02-intermediate/01-e")
+	if got := next(); got != "" {
+		t.Errorf("Next() = %s, want nothing left", got)
+	}
+}
@@ -0,0 +1,62 @@
+package progress
+
+import (
+	"context"
+	"time"
+
+	"exercise"
+	"exercise/gotest"
+)
+
+// Test runs the tests of the student's code in ex, with the race detector
+// and timeout its manifest asks for. Reference solutions are not tested.
+func Test(ex exercise.Exercise) (*gotest.Run, error) {
+	args, err := testArgs(ex)
+	if err != nil {
+		return &gotest.Run{}, err
+	}
+	return gotest.Test(ex.Dir, args...)
+}
+
+// Stream is like Test, but calls out with each line of output as soon as
+// go test writes it.
+func Stream(ctx context.Context, ex exercise.Exercise, out func(line string)) (*gotest.Run, error) {
+	args, err := testArgs(ex)
+	if err != nil {
+		return &gotest.Run{}, err
+	}
+	return gotest.Stream(ctx, ex.Dir, out, args...)
+}
+
+// testArgs returns the go test flags and packages that test ex.
+func testArgs(ex exercise.Exercise) ([]string, error) {
+	packages, err := ex.StudentPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0)
+	if ex.Race {
+		args = append(args, "-race")
+	}
+	if ex.Timeout.Duration > 0 {
+		args = append(args, "-timeout", ex.Timeout.String())
+	}
+	return append(args, packages...), nil
+}
+
+// NewAttempt summarizes a test run finished at t. The attempt passes when the
+// run has tests and none of them failed.
+func NewAttempt(t time.Time, run *gotest.Run, err error) Attempt {
+	a := Attempt{Time: t, Status: "fail"}
+	for _, tc := range run.Tests {
+		a.Total++
+		if tc.Status == "pass" {
+			a.Passed++
+		}
+	}
+	if err == nil && a.Total > 0 && run.Count("fail") == 0 {
+		a.Status = "pass"
+	}
+	return a
+}
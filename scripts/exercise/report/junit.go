@@ -0,0 +1,142 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+
+	"exercise/gotest"
+)
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitCase     `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit encodes the report as JUnit XML, one <testsuite> per exercise.
+//
+// Each suite starts with a "validate" case carrying the exercise status,
+// followed by one case per Go test and subtest. A failing Go test is only reported as a
+// failure when the exercise itself failed validation; otherwise the validator
+// accepted it (starter code is expected to fail) and it is reported as skipped.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	doc := junitSuites{Name: r.Validator, Time: seconds(r.Duration)}
+
+	for _, ex := range r.Exercises {
+		suiteName := ex.Name
+		if r.Validator != ex.Validator {
+			suiteName = ex.Validator + "/" + ex.Name
+		}
+		suite := junitSuite{
+			Name: suiteName,
+			Time: seconds(ex.Duration),
+			Properties: []junitProperty{
+				{Name: "validator", Value: ex.Validator},
+				{Name: "status", Value: ex.Status},
+				{Name: "race_detected", Value: fmt.Sprint(ex.RaceDetected)},
+			},
+		}
+
+		validate := junitCase{ClassName: suiteName, Name: "validate", Time: seconds(ex.Duration)}
+		switch ex.Status {
+		case "fail":
+			validate.Failure = &junitMessage{Message: ex.Message}
+		case "warn":
+			validate.SystemOut = "warning: " + ex.Message
+		}
+		suite.Cases = append(suite.Cases, validate)
+
+		for _, tc := range flatten(ex.Tests) {
+			c := junitCase{ClassName: suiteName, Name: tc.Name, Time: seconds(tc.Elapsed)}
+			message := "test failed"
+			if tc.Race {
+				message = "race detected"
+			}
+			switch {
+			case slices.Contains(ex.UnexpectedPasses, tc.Name):
+				c.Failure = &junitMessage{Message: "test passed, but the " + ex.Validator + " validator requires it to fail"}
+			case tc.Status == "skip":
+				c.Skipped = &junitMessage{Message: tc.SkipReason}
+			case tc.Status == "fail" && ex.Status == "fail":
+				c.Failure = &junitMessage{Message: message, Body: tc.Output}
+			case tc.Status == "fail":
+				c.Skipped = &junitMessage{Message: message + "; accepted by " + ex.Validator + " validator", Body: tc.Output}
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+
+		for _, c := range suite.Cases {
+			suite.Tests++
+			if c.Failure != nil {
+				suite.Failures++
+			}
+			if c.Skipped != nil {
+				suite.Skipped++
+			}
+		}
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// flatten lists tests and their subtests, parents first.
+func flatten(tests []gotest.TestCase) []gotest.TestCase {
+	var all []gotest.TestCase
+	for _, tc := range tests {
+		all = append(all, tc)
+		all = append(all, flatten(tc.Subtests)...)
+	}
+	return all
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
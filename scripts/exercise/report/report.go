@@ -0,0 +1,170 @@
+// Package report writes validator results as JSON or JUnit XML documents.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"exercise/gotest"
+)
+
+// Formats lists the supported report formats.
+var Formats = []string{"json", "junit"}
+
+// Report is the machine-readable outcome of a validation run.
+type Report struct {
+	Validator string     `json:"validator"` // "starter", "solution", or "validator" when merged
+	Duration  float64    `json:"duration_seconds"`
+	Summary   Summary    `json:"summary"`
+	Exercises []Exercise `json:"exercises"`
+}
+
+// Summary counts exercises by status.
+type Summary struct {
+	Total  int `json:"total"`
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+	Warned int `json:"warned"`
+}
+
+// Exercise is the validation outcome of one exercise.
+type Exercise struct {
+	Validator    string            `json:"validator"`
+	Name         string            `json:"name"`
+	Status       string            `json:"status"` // "pass", "fail", "warn"
+	Message      string            `json:"message,omitempty"`
+	Duration     float64           `json:"duration_seconds"`
+	RaceDetected bool              `json:"race_detected"`
+	Tests        []gotest.TestCase `json:"tests,omitempty"`
+
+	// UnexpectedPasses lists tests that passed although the validator
+	// requires them to fail, e.g. starter code that ships a solution.
+	UnexpectedPasses []string `json:"unexpected_passes,omitempty"`
+
+	// MissingModules lists the modules an offline run could not find in the
+	// module cache.
+	MissingModules []string `json:"missing_modules,omitempty"`
+
+	// Coverage is the statement coverage of a solution, when collected.
+	Coverage *float64 `json:"coverage_percent,omitempty"`
+
+	// MutationScore is the share of solution mutants the exercise tests
+	// killed, and SurvivingMutants the ones they did not, when mutated.
+	MutationScore    *float64 `json:"mutation_score,omitempty"`
+	SurvivingMutants []string `json:"surviving_mutants,omitempty"`
+
+	// Benchmarks are the benchmark pairs compared, and Regressions the
+	// benchmarks that got slower than their stored baseline, when benchmarked.
+	Benchmarks  []Benchmark `json:"benchmarks,omitempty"`
+	Regressions []string    `json:"benchmark_regressions,omitempty"`
+}
+
+// Benchmark is the outcome of a pair of benchmarks, the optimized one of
+// which must beat the baseline by MinRatio in Metric.
+type Benchmark struct {
+	Baseline       string  `json:"baseline"`
+	Optimized      string  `json:"optimized"`
+	Metric         string  `json:"metric"`
+	BaselineValue  float64 `json:"baseline_value"`
+	OptimizedValue float64 `json:"optimized_value"`
+	MinRatio       float64 `json:"min_ratio"`
+	Passed         bool    `json:"passed"`
+}
+
+// New builds a report and its summary from per-exercise results.
+func New(validator string, elapsed time.Duration, exercises []Exercise) *Report {
+	r := &Report{
+		Validator: validator,
+		Duration:  elapsed.Seconds(),
+		Exercises: exercises,
+	}
+	for i := range r.Exercises {
+		if r.Exercises[i].Validator == "" {
+			r.Exercises[i].Validator = validator
+		}
+	}
+	r.summarize()
+	return r
+}
+
+func (r *Report) summarize() {
+	r.Summary = Summary{Total: len(r.Exercises)}
+	for _, ex := range r.Exercises {
+		switch ex.Status {
+		case "pass":
+			r.Summary.Passed++
+		case "fail":
+			r.Summary.Failed++
+		case "warn":
+			r.Summary.Warned++
+		}
+	}
+}
+
+// Merge combines several reports into one document. Each exercise keeps the
+// name of the validator that produced it.
+func Merge(reports ...*Report) *Report {
+	merged := &Report{Validator: "validator", Exercises: make([]Exercise, 0)}
+	for _, r := range reports {
+		merged.Duration += r.Duration
+		merged.Exercises = append(merged.Exercises, r.Exercises...)
+	}
+	merged.summarize()
+	return merged
+}
+
+// DefaultFile returns the report file name used when none is given.
+func DefaultFile(format string) string {
+	if format == "junit" {
+		return "report.xml"
+	}
+	return "report." + format
+}
+
+// Write encodes the report in the given format.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		return r.WriteJSON(w)
+	case "junit":
+		return r.WriteJUnit(w)
+	default:
+		return fmt.Errorf("unknown report format %q (want json or junit)", format)
+	}
+}
+
+// WriteFile writes the report to path in the given format.
+func (r *Report) WriteFile(path, format string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Write(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteJSON encodes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// ReadFile decodes a JSON report written by WriteJSON.
+func ReadFile(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing report %s: %w", path, err)
+	}
+	return &r, nil
+}
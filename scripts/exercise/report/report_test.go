@@ -0,0 +1,131 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"exercise/gotest"
+)
+
+func sampleReports() (*Report, *Report) {
+	starter := New("starter", 2*time.Second, []Exercise{
+		{Name: "01-basics/01-a", Status: "pass", Tests: []gotest.TestCase{
+			{Name: "TestA", Status: "fail"},
+		}},
+		{Name: "01-basics/02-b", Status: "warn", Message: "Tests timed out after 30s"},
+	})
+	solution := New("solution", 3*time.Second, []Exercise{
+		{Name: "01-basics/01-a", Status: "fail", Message: "Race condition detected in TestA", RaceDetected: true, Tests: []gotest.TestCase{
+			{Name: "TestA", Status: "fail", Elapsed: 0.5, Race: true, Output: "WARNING: DATA RACE\n"},
+			{Name: "TestB", Status: "pass", Subtests: []gotest.TestCase{
+				{Name: "TestB/empty", Status: "skip", SkipReason: "not supported"},
+			}},
+		}},
+	})
+	return starter, solution
+}
+
+func TestMerge(t *testing.T) {
+	starter, solution := sampleReports()
+	merged := Merge(starter, solution)
+
+	want := Summary{Total: 3, Passed: 1, Failed: 1, Warned: 1}
+	if merged.Summary != want {
+		t.Errorf("summary: got %+v, want %+v", merged.Summary, want)
+	}
+	if merged.Duration != 5 {
+		t.Errorf("duration: got %v, want 5", merged.Duration)
+	}
+	if got := merged.Exercises[2].Validator; got != "solution" {
+		t.Errorf("merged exercise validator: got %q, want solution", got)
+	}
+}
+
+func TestWriteJSONRoundTrip(t *testing.T) {
+	_, solution := sampleReports()
+
+	var buf bytes.Buffer
+	if err := solution.Write(&buf, "json"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var got Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got.Exercises) != 1 || len(got.Exercises[0].Tests) != 2 || len(got.Exercises[0].Tests[1].Subtests) != 1 {
+		t.Errorf("round trip lost data: %+v", got)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	starter, solution := sampleReports()
+
+	var buf bytes.Buffer
+	if err := Merge(starter, solution).Write(&buf, "junit"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var doc junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+
+	// 3 validate cases + TestA (starter) + TestA, TestB, TestB/empty (solution)
+	if doc.Tests != 7 {
+		t.Errorf("tests: got %d, want 7", doc.Tests)
+	}
+	// Solution validate case and its failing TestA
+	if doc.Failures != 2 {
+		t.Errorf("failures: got %d, want 2", doc.Failures)
+	}
+	// Starter TestA failed but the exercise passed validation, and TestB/empty was skipped
+	if doc.Skipped != 2 {
+		t.Errorf("skipped: got %d, want 2", doc.Skipped)
+	}
+	for _, s := range []string{`message="race detected"`, "WARNING: DATA RACE", `name="TestB/empty"`, `message="not supported"`} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("JUnit output missing %s:\n%s", s, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), `name="solution/01-basics/01-a"`) {
+		t.Errorf("merged suites should be prefixed with the validator name:\n%s", buf.String())
+	}
+}
+
+func TestWriteJUnitUnexpectedPass(t *testing.T) {
+	starter := New("starter", time.Second, []Exercise{
+		{Name: "01-basics/01-a", Status: "fail", Message: "Starter passes tests it must fail: TestA", UnexpectedPasses: []string{"TestA"}, Tests: []gotest.TestCase{
+			{Name: "TestA", Status: "pass"},
+			{Name: "TestB", Status: "fail"},
+		}},
+	})
+
+	var buf bytes.Buffer
+	if err := starter.Write(&buf, "junit"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var doc junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	// The validate case, the unexpectedly passing TestA and the failing TestB
+	if doc.Failures != 3 {
+		t.Errorf("failures: got %d, want 3\n%s", doc.Failures, buf.String())
+	}
+	if !strings.Contains(buf.String(), "requires it to fail") {
+		t.Errorf("JUnit output should explain the unexpected pass:\n%s", buf.String())
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	_, solution := sampleReports()
+	if err := solution.Write(&bytes.Buffer{}, "yaml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
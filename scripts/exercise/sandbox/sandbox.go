@@ -0,0 +1,96 @@
+// Package sandbox runs commands under a wall-clock timeout and per-process
+// resource limits, so a runaway test cannot hang or exhaust the machine
+// running the validators.
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits caps the resources of a command. Zero values mean no limit.
+type Limits struct {
+	Timeout time.Duration // wall clock, for the command and all its children
+	Memory  int64         // bytes of address space, per process
+	CPU     time.Duration // CPU time, per process
+}
+
+// ErrTimeout is returned when a command is killed because its timeout expired.
+var ErrTimeout = errors.New("timed out")
+
+// waitDelay bounds how long a command waits for its output pipes to close
+// after the process group was killed.
+const waitDelay = 5 * time.Second
+
+// Output runs name with args in dir and returns its combined stdout and
+// stderr. The command runs in its own process group under the memory and CPU
+// limits; when the timeout expires the whole group is killed, test binaries
+// started by "go test" included, and the error wraps ErrTimeout.
+//
+// Memory and CPU limits are applied with ulimit and are ignored on systems
+// without a POSIX shell. For "go test" they apply to the test binary only,
+// not to the go command, compiler and linker building it.
+func Output(dir string, limits Limits, name string, args ...string) ([]byte, error) {
+	ctx := context.Background()
+	if limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
+
+	output, err := Command(ctx, dir, limits, name, args...).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("%w after %s", ErrTimeout, limits.Timeout)
+	}
+	return output, err
+}
+
+// Command returns a command running name with args in dir, in its own
+// process group under the memory and CPU limits. Canceling ctx kills the
+// whole group; limits.Timeout is not applied, Output derives ctx from it.
+func Command(ctx context.Context, dir string, limits Limits, name string, args ...string) *exec.Cmd {
+	name, args = wrap(limits, name, args)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = waitDelay
+	isolate(cmd)
+	return cmd
+}
+
+// ParseSize parses a byte size such as "512MiB", "2GB" or "1048576". Units
+// are powers of 1024; "0" and "" mean no limit.
+func ParseSize(s string) (int64, error) {
+	input := s
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	units := []struct {
+		suffix string
+		scale  int64
+	}{
+		{"GiB", 1 << 30}, {"GB", 1 << 30}, {"G", 1 << 30},
+		{"MiB", 1 << 20}, {"MB", 1 << 20}, {"M", 1 << 20},
+		{"KiB", 1 << 10}, {"KB", 1 << 10}, {"K", 1 << 10},
+		{"B", 1},
+	}
+	scale := int64(1)
+	for _, u := range units {
+		if number, ok := strings.CutSuffix(s, u.suffix); ok {
+			s, scale = strings.TrimSpace(number), u.scale
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", input)
+	}
+	return int64(n * float64(scale)), nil
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package sandbox
+
+import "os/exec"
+
+// isolate leaves cmd as is: cancellation kills only cmd's process.
+func isolate(cmd *exec.Cmd) {}
+
+// wrap returns the command unchanged; resource limits are not supported.
+func wrap(limits Limits, name string, args []string) (string, []string) {
+	return name, args
+}
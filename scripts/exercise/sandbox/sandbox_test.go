@@ -0,0 +1,30 @@
+package sandbox
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"", 0},
+		{"0", 0},
+		{"1048576", 1 << 20},
+		{"512MiB", 512 << 20},
+		{"2GB", 2 << 30},
+		{"1.5G", 3 << 29},
+		{"64 KiB", 64 << 10},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"lots", "-1G", "GiB"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q): expected an error", in)
+		}
+	}
+}
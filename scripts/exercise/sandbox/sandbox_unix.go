@@ -0,0 +1,44 @@
+//go:build unix
+
+package sandbox
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// isolate starts cmd in a new process group and makes cancellation kill the
+// whole group rather than only cmd's process.
+func isolate(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// wrap runs the command through sh to set its resource limits; children
+// inherit them. For "go test" only the test binary is limited, through
+// -exec: the compiler and linker, and the race runtime linked into -race
+// binaries, need far more address space than the tests themselves.
+func wrap(limits Limits, name string, args []string) (string, []string) {
+	var ulimits []string
+	if limits.Memory > 0 {
+		ulimits = append(ulimits, fmt.Sprintf("ulimit -v %d", limits.Memory/1024))
+	}
+	if limits.CPU > 0 {
+		ulimits = append(ulimits, fmt.Sprintf("ulimit -t %d", int64(math.Ceil(limits.CPU.Seconds()))))
+	}
+	if len(ulimits) == 0 {
+		return name, args
+	}
+
+	script := strings.Join(ulimits, " && ") + ` && exec "$0" "$@"`
+	if name == "go" && len(args) > 0 && args[0] == "test" {
+		// go test splits -exec on spaces outside quotes; script has no single quotes
+		return name, append([]string{"test", "-exec", "sh -c '" + script + "'"}, args[1:]...)
+	}
+	return "sh", append([]string{"-c", script, name}, args...)
+}
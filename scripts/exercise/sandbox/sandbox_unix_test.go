@@ -0,0 +1,117 @@
+//go:build unix
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"exercise/internal/testfiles"
+)
+
+func TestOutputKillsProcessGroup(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "orphan")
+
+	// The background child stands in for a test binary started by go test
+	script := "(sleep 1; touch " + marker + ") & echo started; wait"
+	start := time.Now()
+	output, err := Output(dir, Limits{Timeout: 200 * time.Millisecond}, "sh", "-c", script)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Output returned after %s, want shortly after the timeout", elapsed)
+	}
+	if !strings.Contains(string(output), "started") {
+		t.Errorf("output before the timeout was lost: %q", output)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("child process outlived the timeout")
+	}
+}
+
+func TestOutputLimits(t *testing.T) {
+	output, err := Output(t.TempDir(), Limits{Memory: 256 << 20, CPU: 90 * time.Second}, "sh", "-c", "ulimit -v; ulimit -t")
+	if err != nil {
+		t.Fatalf("%v: %s", err, output)
+	}
+	if got, want := strings.Fields(string(output)), []string{"262144", "90"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("limits: got %v, want %v", got, want)
+	}
+
+	output, err = Output(t.TempDir(), Limits{}, "echo", "no", "limits")
+	if err != nil || string(output) != "no limits\n" {
+		t.Errorf("without limits: got %q, %v", output, err)
+	}
+}
+
+func TestWrapGoTest(t *testing.T) {
+	name, args := wrap(Limits{Memory: 1 << 30}, "go", []string{"test", "-json", "./..."})
+	want := []string{"test", "-exec", `sh -c 'ulimit -v 1048576 && exec "$0" "$@"'`, "-json", "./..."}
+	if name != "go" || strings.Join(args, "\x00") != strings.Join(want, "\x00") {
+		t.Errorf("wrap = %s %q, want go %q", name, args, want)
+	}
+}
+
+func TestOutputLimitsGoTestBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a race-enabled test binary")
+	}
+	dir := t.TempDir()
+	testfiles.Write(t, dir, map[string]string{
+		"go.mod": "module limits\n\ngo 1.24\n",
+		"limits_test.go": `package limits
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	out, err := exec.Command("sh", "-c", "ulimit -v").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("limit=%s", out)
+}
+`,
+	})
+
+	// Running a small -race binary fits in 2GiB, unlike building one
+	output, err := Output(dir, Limits{Memory: 2 << 30}, "go", "test", "-race", "-count=1", "-v", ".")
+	if err != nil {
+		t.Fatalf("%v: %s", err, output)
+	}
+	if !strings.Contains(string(output), "limit=2097152") {
+		t.Errorf("test binary not limited: %s", output)
+	}
+}
+
+func TestCommandCancelKillsProcessGroup(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "orphan")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := Command(ctx, dir, Limits{}, "sh", "-c", "(sleep 1; touch "+marker+") & wait")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	if err := cmd.Wait(); err == nil {
+		t.Error("canceled command exited without error")
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("child process outlived the cancellation")
+	}
+}
@@ -0,0 +1,292 @@
+// Package scaffold generates new exercises from templates. Inserting an
+// exercise in the middle of a category renumbers the exercises after it and
+// updates every reference to their paths.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"exercise"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// GoVersion is the go directive of generated go.mod files.
+const GoVersion = "1.25"
+
+// Spec describes the exercise to create.
+type Spec struct {
+	Number int    // position in the category, starting at 1
+	Name   string // kebab-case, e.g. "worker-pools"
+	exercise.Manifest
+}
+
+// File is a file to write, relative to the repository root.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// Rename moves an exercise directory, relative to the repository root.
+type Rename struct {
+	From, To string
+}
+
+// Plan lists every change needed to add an exercise. Nothing is written
+// until Apply is called.
+type Plan struct {
+	Root    string
+	Path    string   // the new exercise, e.g. "03-concurrency/16-worker-pools"
+	Renames []Rename // exercises moved up by one, last first
+	Created []File   // files of the new exercise
+	Updated []File   // manifests, READMEs, the category table and the exercise count badge
+}
+
+var (
+	namePattern    = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	dirPattern     = regexp.MustCompile(`^(\d+)-(.+)$`)
+	headingPattern = regexp.MustCompile(`(?m)^# (Exercise|Project) (\d+):`)
+	badgePattern   = regexp.MustCompile(`(badge/Exercises-)(\d+)(-)`)
+)
+
+// NewPlan works out how to add the exercise described by spec to the
+// repository at root.
+func NewPlan(root string, spec Spec) (*Plan, error) {
+	if !namePattern.MatchString(spec.Name) {
+		return nil, fmt.Errorf("invalid name %q: use lower-case words separated by hyphens", spec.Name)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	categoryDir := exercise.CategoryDir(spec.Category)
+
+	exercises, err := exercise.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exercises of the category from the insertion point on move up by one
+	renamed := make(map[string]string)
+	var renames []Rename
+	last := 0
+	for _, ex := range exercises {
+		dir, base := path.Split(ex.Path)
+		if dir != categoryDir+"/" {
+			continue
+		}
+		m := dirPattern.FindStringSubmatch(base)
+		if m == nil {
+			return nil, fmt.Errorf("%s: exercise directory is not numbered", ex.Path)
+		}
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == spec.Name {
+			return nil, fmt.Errorf("%s already exists", ex.Path)
+		}
+		last = max(last, n)
+		if n >= spec.Number {
+			to := fmt.Sprintf("%s/%02d-%s", categoryDir, n+1, m[2])
+			renames = append(renames, Rename{From: ex.Path, To: to})
+			renamed[ex.Path] = to
+		}
+	}
+	if spec.Number < 1 || spec.Number > last+1 {
+		return nil, fmt.Errorf("number must be between 1 and %d for %s", last+1, spec.Category)
+	}
+	slices.Reverse(renames)
+
+	plan := &Plan{
+		Root:    root,
+		Path:    fmt.Sprintf("%s/%02d-%s", categoryDir, spec.Number, spec.Name),
+		Renames: renames,
+	}
+
+	if err := plan.updateExercises(exercises, renamed); err != nil {
+		return nil, err
+	}
+	if err := plan.updateCategoryReadme(spec, exercises, renamed); err != nil {
+		return nil, err
+	}
+	if err := plan.updateBadge(len(exercises) + 1); err != nil {
+		return nil, err
+	}
+	if plan.Created, err = render(plan.Path, spec); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// updateExercises rewrites the manifests referring to renamed exercises and
+// the README headings of the renamed exercises themselves.
+func (p *Plan) updateExercises(exercises []exercise.Exercise, renamed map[string]string) error {
+	var pairs []string
+	for from, to := range renamed {
+		pairs = append(pairs, strconv.Quote(from), strconv.Quote(to))
+	}
+	paths := strings.NewReplacer(pairs...)
+
+	for _, ex := range exercises {
+		dir := ex.Path
+		if to, ok := renamed[dir]; ok {
+			dir = to
+		}
+
+		manifest := filepath.Join(ex.Dir, exercise.ManifestFile)
+		data, err := os.ReadFile(manifest)
+		if err != nil {
+			return err
+		}
+		updated := paths.Replace(string(data))
+		if updated != string(data) {
+			p.Updated = append(p.Updated, File{Path: path.Join(dir, exercise.ManifestFile), Data: []byte(updated)})
+		}
+
+		if dir == ex.Path {
+			continue
+		}
+		readme, err := os.ReadFile(filepath.Join(ex.Dir, "README.md"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		// Only the title heading, keeping its zero padding
+		if m := headingPattern.FindSubmatchIndex(readme); m != nil {
+			number, _ := strconv.Atoi(dirPattern.FindStringSubmatch(path.Base(dir))[1])
+			renumbered := fmt.Appendf(nil, "%s%0*d%s", readme[:m[4]], m[5]-m[4], number, readme[m[5]:])
+			p.Updated = append(p.Updated, File{Path: path.Join(dir, "README.md"), Data: renumbered})
+		}
+	}
+	return nil
+}
+
+// updateBadge sets the exercise count badge of the repository README.
+func (p *Plan) updateBadge(count int) error {
+	data, err := os.ReadFile(filepath.Join(p.Root, "README.md"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	updated := badgePattern.ReplaceAll(data, []byte("${1}"+strconv.Itoa(count)+"${3}"))
+	if !bytes.Equal(updated, data) {
+		p.Updated = append(p.Updated, File{Path: "README.md", Data: updated})
+	}
+	return nil
+}
+
+// Apply renames the exercises that move, writes the updated files, then
+// creates the new exercise.
+func (p *Plan) Apply() error {
+	for _, r := range p.Renames {
+		to := filepath.Join(p.Root, filepath.FromSlash(r.To))
+		if _, err := os.Stat(to); err == nil {
+			return fmt.Errorf("cannot rename %s: %s already exists", r.From, r.To)
+		}
+		if err := os.Rename(filepath.Join(p.Root, filepath.FromSlash(r.From)), to); err != nil {
+			return err
+		}
+	}
+	for _, f := range p.Updated {
+		if err := os.WriteFile(filepath.Join(p.Root, filepath.FromSlash(f.Path)), f.Data, 0o644); err != nil {
+			return err
+		}
+	}
+	for _, f := range p.Created {
+		name := filepath.Join(p.Root, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, f.Data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateData is what the templates see of the exercise.
+type templateData struct {
+	Spec
+	Heading   string // "Exercise 03" or "Project 3"
+	Stars     string
+	Level     string
+	Minutes   int
+	GoVersion string
+}
+
+// levels names the difficulties, as in the repository README's legend.
+var levels = []string{"Beginner", "Intermediate", "Advanced", "Expert", "Master"}
+
+// render executes every template for the exercise in dir. Go files are
+// gofmt'ed, so a broken template fails here rather than in the validators.
+func render(dir string, spec Spec) ([]File, error) {
+	data := templateData{
+		Spec:      spec,
+		Heading:   fmt.Sprintf("Exercise %02d", spec.Number),
+		Stars:     strings.Repeat("⭐", spec.Difficulty),
+		Level:     levels[spec.Difficulty-1],
+		Minutes:   int(spec.EstimatedTime.Minutes()),
+		GoVersion: GoVersion,
+	}
+	if spec.Category == "projects" {
+		data.Heading = fmt.Sprintf("Project %d", spec.Number)
+	}
+	funcs := template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"duration": formatDuration,
+	}
+
+	var files []File
+	err := fs.WalkDir(templates, "templates", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		tmpl, err := template.New(path.Base(name)).Funcs(funcs).ParseFS(templates, name)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(name, "templates/"), ".tmpl")
+		out := buf.Bytes()
+		if strings.HasSuffix(rel, ".go") {
+			if out, err = format.Source(out); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		files = append(files, File{Path: path.Join(dir, rel), Data: out})
+		return nil
+	})
+	return files, err
+}
+
+// formatDuration writes whole minutes as manifests do, "90m" rather than
+// time.Duration's "1h30m0s".
+func formatDuration(d exercise.Duration) string {
+	if d.Duration > 0 && d.Duration%time.Minute == 0 {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return d.String()
+}
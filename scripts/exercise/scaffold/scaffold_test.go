@@ -0,0 +1,213 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"exercise"
+	"exercise/internal/testfiles"
+)
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func manifest(category string, prerequisites ...string) string {
+	quoted := make([]string, len(prerequisites))
+	for i, p := range prerequisites {
+		quoted[i] = `"` + p + `"`
+	}
+	return `{"title": "T", "category": "` + category + `", "difficulty": 1, "estimated_time": "30m", ` +
+		`"prerequisites": [` + strings.Join(quoted, ", ") + `]}`
+}
+
+// repo lays out two basics exercises and one intermediate exercise requiring
+// the second one.
+func repo(t *testing.T) string {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"README.md":                                   "[![Exercises](https://img.shields.io/badge/Exercises-3-success)](.)\n",
+		"01-basics/README.md":                         categoryReadme,
+		"01-basics/01-strings/exercise.json":          manifest("basics"),
+		"01-basics/01-strings/README.md":              "# Exercise 01: Strings\n",
+		"01-basics/02-maps/exercise.json":             manifest("basics"),
+		"01-basics/02-maps/README.md":                 "# Exercise 2: Maps\n\n# Exercise 2: not a heading\n",
+		"02-intermediate/README.md":                   "| # | Name | Difficulty | Time |\n|---|------|------------|------|\n| 1 | T | ⭐ | 30min |\n",
+		"02-intermediate/01-interfaces/exercise.json": manifest("intermediate", "01-basics/02-maps"),
+	})
+	return root
+}
+
+const categoryReadme = `# Basics
+
+| # | Exercise | Time | Focus Areas |
+|---|----------|------|-------------|
+| 01 | [Strings](01-strings/) | 30 min | Runes |
+| 02 | [Maps](02-maps/) | 35 min | Hash maps |
+
+Start with 01-strings, then 02-maps.
+`
+
+func spec(number int, name string) Spec {
+	return Spec{
+		Number: number,
+		Name:   name,
+		Manifest: exercise.Manifest{
+			Title:         "Slices",
+			Category:      "basics",
+			Difficulty:    2,
+			EstimatedTime: exercise.Duration{Duration: 45 * time.Minute},
+			Timeout:       exercise.Duration{Duration: 30 * time.Second},
+		},
+	}
+}
+
+func TestInsert(t *testing.T) {
+	root := repo(t)
+	plan, err := NewPlan(root, spec(2, "slices"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Path != "01-basics/02-slices" {
+		t.Errorf("Path = %q", plan.Path)
+	}
+	if len(plan.Renames) != 1 || plan.Renames[0] != (Rename{"01-basics/02-maps", "01-basics/03-maps"}) {
+		t.Errorf("Renames = %v", plan.Renames)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	exercises, err := exercise.Discover(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prerequisites := make(map[string][]string)
+	for _, ex := range exercises {
+		prerequisites[ex.Path] = ex.Prerequisites
+	}
+	want := map[string]string{
+		"01-basics/01-strings":          "",
+		"01-basics/02-slices":           "",
+		"01-basics/03-maps":             "",
+		"02-intermediate/01-interfaces": "01-basics/03-maps",
+	}
+	if len(prerequisites) != len(want) {
+		t.Fatalf("exercises = %v", prerequisites)
+	}
+	for path, prerequisite := range want {
+		if got := strings.Join(prerequisites[path], ","); got != prerequisite {
+			t.Errorf("%s: prerequisites = %q, want %q", path, got, prerequisite)
+		}
+	}
+
+	if got := readFile(t, filepath.Join(root, "01-basics/03-maps/README.md")); got != "# Exercise 3: Maps\n\n# Exercise 2: not a heading\n" {
+		t.Errorf("renumbered README = %q", got)
+	}
+	if got := readFile(t, filepath.Join(root, "README.md")); !strings.Contains(got, "badge/Exercises-4-success") {
+		t.Errorf("badge not updated: %q", got)
+	}
+	if got := readFile(t, filepath.Join(root, "01-basics/README.md")); got != `# Basics
+
+| # | Exercise | Time | Focus Areas |
+|---|----------|------|-------------|
+| 01 | [Strings](01-strings/) | 30 min | Runes |
+| 02 | [Slices](02-slices/) | 45 min | |
+| 03 | [Maps](03-maps/) | 35 min | Hash maps |
+
+Start with 01-strings, then 03-maps.
+` {
+		t.Errorf("category README = %s", got)
+	}
+
+	dir := filepath.Join(root, "01-basics/02-slices")
+	for _, name := range []string{"README.md", "HINTS.md", "main.go", "main_test.go", "go.mod", "solution/main.go", "solution/EXPLANATION.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+	if got := readFile(t, filepath.Join(dir, "go.mod")); got != "module slices\n\ngo "+GoVersion+"\n" {
+		t.Errorf("go.mod = %q", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "README.md")); !strings.HasPrefix(got, "# Exercise 02: Slices\n\n**Difficulty**: ⭐⭐ Intermediate\n**Estimated Time**: 45 minutes\n") {
+		t.Errorf("README.md = %q", got)
+	}
+	m, err := exercise.LoadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Title != "Slices" || m.EstimatedTime.Duration != 45*time.Minute || m.Timeout.Duration != 30*time.Second {
+		t.Errorf("manifest = %+v", m)
+	}
+	if got := readFile(t, filepath.Join(dir, exercise.ManifestFile)); !strings.Contains(got, `"estimated_time": "45m",`) || strings.Contains(got, "prerequisites") {
+		t.Errorf("exercise.json = %s", got)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	root := repo(t)
+	s := spec(3, "slices")
+	s.Prerequisites = []string{"01-basics/01-strings", "01-basics/02-maps"}
+	plan, err := NewPlan(root, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Renames) != 0 {
+		t.Errorf("Renames = %v", plan.Renames)
+	}
+	// Nothing is renamed: the new exercise gets a row and the badge changes
+	if len(plan.Updated) != 2 || plan.Updated[0].Path != "01-basics/README.md" || plan.Updated[1].Path != "README.md" {
+		t.Errorf("Updated = %v", plan.Updated)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(root, "01-basics/README.md")); !strings.Contains(got, "| 02 | [Maps](02-maps/) | 35 min | Hash maps |\n| 03 | [Slices](03-slices/) | 45 min | |\n") {
+		t.Errorf("category README = %s", got)
+	}
+	m, err := exercise.LoadManifest(filepath.Join(root, "01-basics/03-slices"))
+	if err != nil || strings.Join(m.Prerequisites, ",") != "01-basics/01-strings,01-basics/02-maps" {
+		t.Errorf("manifest = %+v, %v", m, err)
+	}
+}
+
+func TestInsertFirstInNumberedTable(t *testing.T) {
+	root := repo(t)
+	s := spec(1, "generics")
+	s.Category = "intermediate"
+	plan, err := NewPlan(root, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	want := "| # | Name | Difficulty | Time |\n|---|------|------------|------|\n| 1 | Slices | ⭐⭐ | 45min |\n| 2 | T | ⭐ | 30min |\n"
+	if got := readFile(t, filepath.Join(root, "02-intermediate/README.md")); got != want {
+		t.Errorf("category README = %q, want %q", got, want)
+	}
+}
+
+func TestNewPlanErrors(t *testing.T) {
+	root := repo(t)
+	for _, s := range []Spec{
+		spec(4, "slices"),      // leaves a gap
+		spec(0, "slices"),      // before the first exercise
+		spec(1, "maps"),        // already exists
+		spec(1, "Bad_Name"),    // not kebab-case
+		spec(1, "slices-"),     // not kebab-case
+		{Number: 1, Name: "x"}, // invalid manifest
+	} {
+		if _, err := NewPlan(root, s); err == nil {
+			t.Errorf("NewPlan(%d, %q): expected an error", s.Number, s.Name)
+		}
+	}
+}
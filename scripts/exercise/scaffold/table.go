@@ -0,0 +1,166 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"exercise"
+)
+
+var (
+	numberCellPattern = regexp.MustCompile(`^(\|\s*)(\d+)(\s*\|)`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+	timePattern       = regexp.MustCompile(`^\d+(\s*)min$`)
+	wordPattern       = regexp.MustCompile(`[A-Za-z0-9]+(-[A-Za-z0-9]+)*`)
+)
+
+// updateCategoryReadme updates the exercise tables of the category README:
+// rows of renamed exercises get their new number and directory, and the new
+// exercise gets a row next to the one of the exercise before it, filled in
+// from the manifest. Other mentions of renamed directories are updated too.
+func (p *Plan) updateCategoryReadme(spec Spec, exercises []exercise.Exercise, renamed map[string]string) error {
+	categoryDir := exercise.CategoryDir(spec.Category)
+	readme := path.Join(categoryDir, "README.md")
+	data, err := os.ReadFile(filepath.Join(p.Root, filepath.FromSlash(readme)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Exercises of the category by directory name and by number
+	byBase := make(map[string]exercise.Exercise)
+	byNumber := make(map[int]exercise.Exercise)
+	dirs := make(map[string]string)
+	for _, ex := range exercises {
+		dir, base := path.Split(ex.Path)
+		if dir != categoryDir+"/" {
+			continue
+		}
+		n, _ := strconv.Atoi(dirPattern.FindStringSubmatch(base)[1])
+		byBase[base] = ex
+		byNumber[n] = ex
+		if to, ok := renamed[ex.Path]; ok {
+			dirs[base] = path.Base(to)
+		}
+	}
+	// Whole words only, so 02-maps leaves 02-maps-extra alone
+	rename := func(line string) string {
+		return wordPattern.ReplaceAllStringFunc(line, func(w string) string {
+			if to, ok := dirs[w]; ok {
+				return to
+			}
+			return w
+		})
+	}
+
+	// The new row goes after the exercise before it, or before the first one
+	neighbour, after := byNumber[spec.Number-1], true
+	if spec.Number == 1 {
+		neighbour, after = byNumber[1], false
+	}
+
+	lines := strings.Split(string(data), "\n")
+	updated := make([]string, 0, len(lines)+1)
+	added := false
+	for _, line := range lines {
+		ex, ok := tableRow(line, byBase, byNumber)
+		if !ok {
+			updated = append(updated, rename(line))
+			continue
+		}
+
+		var row string
+		if !added && ex.Path == neighbour.Path {
+			row, added = newRow(line, ex, spec), true
+		}
+		if row != "" && !after {
+			updated = append(updated, row)
+		}
+		if to, ok := renamed[ex.Path]; ok {
+			line = renumber(line, to)
+		}
+		updated = append(updated, rename(line))
+		if row != "" && after {
+			updated = append(updated, row)
+		}
+	}
+
+	if result := strings.Join(updated, "\n"); result != string(data) {
+		p.Updated = append(p.Updated, File{Path: readme, Data: []byte(result)})
+	}
+	return nil
+}
+
+// tableRow returns the exercise a table row is about: the one it links to,
+// or else the one numbered in its first cell.
+func tableRow(line string, byBase map[string]exercise.Exercise, byNumber map[int]exercise.Exercise) (exercise.Exercise, bool) {
+	if !strings.HasPrefix(line, "|") {
+		return exercise.Exercise{}, false
+	}
+	for _, m := range linkPattern.FindAllStringSubmatch(line, -1) {
+		if ex, ok := byBase[path.Base(m[2])]; ok {
+			return ex, true
+		}
+	}
+	if m := numberCellPattern.FindStringSubmatch(line); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		ex, ok := byNumber[n]
+		return ex, ok
+	}
+	return exercise.Exercise{}, false
+}
+
+// renumber sets the number cell of a row to the number of the exercise's
+// new directory, keeping its zero padding.
+func renumber(line, to string) string {
+	m := numberCellPattern.FindStringSubmatchIndex(line)
+	if m == nil {
+		return line
+	}
+	number, _ := strconv.Atoi(dirPattern.FindStringSubmatch(path.Base(to))[1])
+	return fmt.Sprintf("%s%0*d%s", line[:m[4]], m[5]-m[4], number, line[m[5]:])
+}
+
+// newRow builds the row of the new exercise from the row of ex, cell by
+// cell: numbers, links, stars and times are replaced, the first other cell
+// of a row without a link is taken for the title, and the cells the
+// manifest knows nothing about, such as topics, are left empty.
+func newRow(line string, ex exercise.Exercise, spec Spec) string {
+	base := fmt.Sprintf("%02d-%s", spec.Number, spec.Name)
+	titled := linkPattern.MatchString(line)
+	cells := strings.Split(line, "|")
+	for i := 1; i < len(cells)-1; i++ {
+		c := strings.TrimSpace(cells[i])
+		switch {
+		case c == "":
+			continue
+		case strings.Trim(c, "0123456789") == "":
+			c = fmt.Sprintf("%0*d", len(c), spec.Number)
+		case linkPattern.MatchString(c):
+			m := linkPattern.FindStringSubmatch(c)
+			label, target := spec.Title, strings.Replace(m[2], path.Base(ex.Path), base, 1)
+			if m[1] == path.Base(ex.Path) {
+				label = base
+			}
+			c = "[" + label + "](" + target + ")"
+		case strings.Trim(c, "⭐") == "":
+			c = strings.Repeat("⭐", spec.Difficulty)
+		case timePattern.MatchString(c):
+			c = fmt.Sprintf("%d%smin", int(spec.EstimatedTime.Minutes()), timePattern.FindStringSubmatch(c)[1])
+		case !titled:
+			c, titled = spec.Title, true
+		default:
+			cells[i] = " "
+			continue
+		}
+		cells[i] = " " + c + " "
+	}
+	return strings.Join(cells, "|")
+}
@@ -0,0 +1,134 @@
+package exercise
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// StageSolution copies the student-facing exercise into dst with its code
+// replaced by the reference solution, so the exercise's own tests run against
+// the solution. The exercise's go.mod and test files are kept; in every
+// package directory the solution provides, the non-test Go files are replaced
+// by the solution's. The solution's own tests are not copied.
+func (e Exercise) StageSolution(dst string) error {
+	// Student-facing tree, without the solution
+	err := copyTree(e.Dir, dst, func(rel string, d fs.DirEntry) bool {
+		return d.IsDir() && rel == "solution"
+	})
+	if err != nil {
+		return err
+	}
+
+	solutionDir := e.SolutionDir()
+	replaced := make(map[string]bool)
+	return filepath.WalkDir(solutionDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != solutionDir && (d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isCodeFile(d.Name()) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(solutionDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if !replaced[rel] {
+			if err := removeCodeFiles(target); err != nil {
+				return err
+			}
+			replaced[rel] = true
+		}
+		return copyFile(path, filepath.Join(target, d.Name()))
+	})
+}
+
+// isCodeFile reports whether name is a non-test Go file.
+func isCodeFile(name string) bool {
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
+
+// removeCodeFiles deletes the non-test Go files of dir and creates dir if it
+// does not exist yet.
+func removeCodeFiles(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return os.MkdirAll(dir, 0o755)
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && isCodeFile(entry.Name()) {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyTree copies the regular files and directories under src to dst,
+// leaving out entries for which skip returns true.
+func copyTree(src, dst string, skip func(rel string, d fs.DirEntry) bool) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && skip(rel, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case d.Type().IsRegular():
+			return copyFile(path, target)
+		default:
+			return nil // symlinks, sockets, ...
+		}
+	})
+}
+
+// copyFile copies a regular file, keeping its permission bits.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("copying %s: %w", src, err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,56 @@
+package exercise
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"exercise/internal/testfiles"
+)
+
+func TestStageSolution(t *testing.T) {
+	dir := t.TempDir()
+	testfiles.Write(t, dir, map[string]string{
+		"go.mod":                    "module ex\n",
+		"main.go":                   "starter",
+		"helpers.go":                "starter helpers",
+		"main_test.go":              "exercise tests",
+		"formatter/format.go":       "starter only",
+		"query/query.go":            "starter query",
+		"solution/main.go":          "solution",
+		"solution/main_test.go":     "solution tests",
+		"solution/EXPLANATION.md":   "explanation",
+		"solution/query/query.go":   "solution query",
+		"solution/query/extra.go":   "solution extra",
+		"solution/testdata/in.json": "{}",
+	})
+
+	dst := filepath.Join(t.TempDir(), "staged")
+	ex := Exercise{Path: "01-basics/01-ex", Dir: dir}
+	if err := ex.StageSolution(dst); err != nil {
+		t.Fatalf("StageSolution: %v", err)
+	}
+
+	want := map[string]string{
+		"go.mod":              "module ex\n",
+		"main.go":             "solution",
+		"main_test.go":        "exercise tests",
+		"formatter/format.go": "starter only",
+		"query/query.go":      "solution query",
+		"query/extra.go":      "solution extra",
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		} else if string(data) != content {
+			t.Errorf("%s: got %q, want %q", name, data, content)
+		}
+	}
+	// Replaced package code and the solution itself must be gone
+	for _, name := range []string{"helpers.go", "solution", "EXPLANATION.md"} {
+		if _, err := os.Stat(filepath.Join(dst, name)); err == nil {
+			t.Errorf("%s should not be staged", name)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+// Package starter generates starter code from reference solutions, so the
+// two never drift apart.
+//
+// A solution function is stubbed out in the starter when its doc comment
+// carries a directive:
+//
+//	// Parse parses a query such as ".items[0].name".
+//	//starter:todo Tokenize the query, then build the AST
+//	func Parse(query string) (*Query, error) {
+//
+// //starter:todo replaces the body with panic("TODO"), //starter:zero with a
+// return of zero values. Any text after the directive becomes a TODO comment
+// in the stubbed body. Signatures and doc comments are kept, the directives
+// themselves and imports only the removed bodies used are dropped. The
+// starter package is type-checked, so generation fails rather than produce
+// code that does not compile.
+package starter
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Directives marking the functions to stub out.
+const (
+	TodoDirective = "//starter:todo"
+	ZeroDirective = "//starter:zero"
+)
+
+// File is a generated starter file.
+type File struct {
+	Path string // relative to the solution directory, and so to the exercise
+	Data []byte
+}
+
+// Generate returns the starter version of every solution file in dir that
+// has at least one marked function, sorted by path. Files without markers
+// are not generated: their starter is maintained by hand.
+func Generate(solutionDir string) ([]File, error) {
+	// The type checker knows files by absolute path
+	solutionDir, err := filepath.Abs(solutionDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make(map[string][]string) // directory → Go files
+	err = filepath.WalkDir(solutionDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != solutionDir && (d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".go") && !strings.HasSuffix(d.Name(), "_test.go") {
+			dirs[filepath.Dir(path)] = append(dirs[filepath.Dir(path)], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	for _, paths := range dirs {
+		generated, err := generatePackage(solutionDir, paths)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, generated...)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// generatePackage stubs the files of one package, which are then
+// type-checked. The package's type declarations are collected first, as
+// zero values depend on them.
+func generatePackage(solutionDir string, paths []string) ([]File, error) {
+	fset := token.NewFileSet()
+	sources := make(map[string][]byte)
+	parsed := make(map[string]*ast.File)
+	types := make(map[string]ast.Expr)
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, path, src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		sources[path], parsed[path] = src, f
+		for _, decl := range f.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					spec := spec.(*ast.TypeSpec)
+					types[spec.Name.Name] = spec.Type
+				}
+			}
+		}
+	}
+
+	stubbed := make(map[string][]byte)
+	for _, path := range paths {
+		s := stubber{fset: fset, src: sources[path], types: types}
+		data, ok, err := s.stub(parsed[path])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if ok {
+			stubbed[path] = data
+		}
+	}
+	if len(stubbed) == 0 {
+		return nil, nil
+	}
+	if err := pruneImports(filepath.Dir(paths[0]), stubbed); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Dir(paths[0]), err)
+	}
+
+	var files []File
+	for _, path := range paths {
+		data, ok := stubbed[path]
+		if !ok {
+			continue
+		}
+		data, err := format.Source(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rel, err := filepath.Rel(solutionDir, path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: filepath.ToSlash(rel), Data: data})
+	}
+	return files, nil
+}
+
+// edit replaces src[start:end] with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// apply performs non-overlapping edits on src.
+func apply(src []byte, edits []edit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var out bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		out.Write(src[last:e.start])
+		out.WriteString(e.text)
+		last = e.end
+	}
+	out.Write(src[last:])
+	return out.Bytes()
+}
+
+type stubber struct {
+	fset  *token.FileSet
+	src   []byte
+	types map[string]ast.Expr // type declarations of the package
+}
+
+func (s *stubber) offset(pos token.Pos) int {
+	return s.fset.Position(pos).Offset
+}
+
+// lines widens src[start:end] to the whole lines holding it, newline
+// included.
+func lines(src []byte, start, end int) (int, int) {
+	start = bytes.LastIndexByte(src[:start], '\n') + 1
+	if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+		end += i + 1
+	} else {
+		end = len(src)
+	}
+	return start, end
+}
+
+// stub returns the starter version of f and whether it had marked functions.
+func (s *stubber) stub(f *ast.File) ([]byte, bool, error) {
+	var edits []edit
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Doc == nil {
+			continue
+		}
+		var directive string
+		var todos []string
+		for _, c := range fn.Doc.List {
+			name, text, _ := strings.Cut(c.Text, " ")
+			if name != TodoDirective && name != ZeroDirective {
+				continue
+			}
+			if directive != "" && directive != name {
+				return nil, false, fmt.Errorf("%s: both %s and %s", fn.Name.Name, directive, name)
+			}
+			directive = name
+			if text = strings.TrimSpace(text); text != "" {
+				todos = append(todos, text)
+			}
+			start, end := lines(s.src, s.offset(c.Pos()), s.offset(c.End()))
+			edits = append(edits, edit{start, end, ""})
+		}
+		if directive == "" {
+			continue
+		}
+		if fn.Body == nil {
+			return nil, false, fmt.Errorf("%s: %s on a function without a body", fn.Name.Name, directive)
+		}
+
+		var body strings.Builder
+		body.WriteString("{\n")
+		for _, todo := range todos {
+			body.WriteString("// TODO: " + todo + "\n")
+		}
+		if directive == TodoDirective {
+			body.WriteString(`panic("TODO")` + "\n")
+		} else if zero := s.zeroValues(fn.Type.Results); zero != "" {
+			body.WriteString("return " + zero + "\n")
+		}
+		body.WriteString("}")
+		edits = append(edits, edit{s.offset(fn.Body.Lbrace), s.offset(fn.Body.End()), body.String()})
+	}
+	if len(edits) == 0 {
+		return nil, false, nil
+	}
+
+	return apply(s.src, edits), true, nil
+}
+
+// zeroValues returns the zero values of a result list, comma-separated.
+func (s *stubber) zeroValues(results *ast.FieldList) string {
+	if results == nil {
+		return ""
+	}
+	var values []string
+	for _, field := range results.List {
+		zero := s.zeroValue(field.Type, make(map[string]bool))
+		for range max(len(field.Names), 1) {
+			values = append(values, zero)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// zeroValue returns an expression for the zero value of t. Types it cannot
+// resolve, such as imported or generic ones, get *new(T).
+func (s *stubber) zeroValue(t ast.Expr, seen map[string]bool) string {
+	switch t := t.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "bool":
+			return "false"
+		case "string":
+			return `""`
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"float32", "float64", "complex64", "complex128", "byte", "rune":
+			return "0"
+		case "error", "any":
+			return "nil"
+		}
+		if underlying, ok := s.types[t.Name]; ok && !seen[t.Name] {
+			seen[t.Name] = true
+			switch u := underlying.(type) {
+			case *ast.StructType:
+				return t.Name + "{}"
+			case *ast.ArrayType:
+				if u.Len != nil {
+					return t.Name + "{}"
+				}
+			}
+			// Untyped constants and nil are assignable to the named type
+			if zero := s.zeroValue(underlying, seen); !strings.HasPrefix(zero, "*new(") {
+				return zero
+			}
+		}
+	case *ast.StarExpr, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+		return "nil"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil"
+		}
+	}
+	return "*new(" + string(s.src[s.offset(t.Pos()):s.offset(t.End())]) + ")"
+}
+
+// pruneImports type-checks the package in dir with the stubbed files in
+// place of the solution's and drops the imports the type checker reports
+// unused, those only the removed bodies used. Any other error would remain
+// in the starter, which must compile.
+func pruneImports(dir string, stubbed map[string][]byte) error {
+	// With NeedDeps the imports are type-checked from source as well, rather
+	// than go list compiling the stubbed package for their export data
+	cfg := &packages.Config{
+		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedDeps,
+		Dir:     dir,
+		Overlay: stubbed,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return err
+	}
+
+	// Unused imports are soft errors, reported at their import spec
+	unused := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, e := range pkg.TypeErrors {
+			if e.Soft {
+				unused[e.Fset.Position(e.Pos).String()] = true
+			}
+		}
+	}
+
+	removed := make(map[string]bool)
+	for path, src := range stubbed {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, path, src, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+
+		var edits []edit
+		remove := func(node ast.Node) {
+			start, end := lines(src, fset.Position(node.Pos()).Offset, fset.Position(node.End()).Offset)
+			edits = append(edits, edit{start, end, ""})
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.IMPORT {
+				continue
+			}
+			var specs []ast.Node
+			for _, spec := range gen.Specs {
+				if pos := fset.Position(spec.Pos()).String(); unused[pos] {
+					removed[pos] = true
+					specs = append(specs, spec)
+				}
+			}
+			if len(specs) == len(gen.Specs) {
+				remove(gen)
+				continue
+			}
+			for _, spec := range specs {
+				remove(spec)
+			}
+		}
+		stubbed[path] = apply(src, edits)
+	}
+
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			if !removed[e.Pos] {
+				return fmt.Errorf("starter does not compile: %v", e)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package starter
+
+import (
+	"strings"
+	"testing"
+
+	"exercise/internal/testfiles"
+)
+
+const solution = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/ex/store"
+)
+
+// Decode parses a JSON document.
+//
+//starter:todo Decode with encoding/json
+//starter:todo Wrap errors with the input name
+func Decode(name string, data []byte) (any, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		// Keep the cause
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return v, nil
+}
+
+// Describe is left alone.
+func Describe(v any) string {
+	return fmt.Sprint(v)
+}
+
+// Title shadows an import: strings.Title below is not the package's.
+func Title(v any) string {
+	strings := struct{ Title string }{fmt.Sprint(v)}
+	return strings.Title
+}
+
+//starter:todo
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
+
+//starter:zero
+func Stats(items []Item) (count, total int, avg float64, best Item, names Names, grid Grid, ok bool, d time.Duration, s *store.Store) {
+	return len(items), 0, 0, Item{}, nil, Grid{}, true, time.Second, store.New()
+}
+
+//starter:zero
+func First[T any](items []T) T {
+	return items[0]
+}
+
+//starter:zero
+func Reset() {
+	fmt.Println("reset")
+}
+
+type Item struct{ Name string }
+
+type Names []string
+
+type Grid [3][3]int
+`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	testfiles.Write(t, dir, map[string]string{
+		"go.mod":              "module example.com/ex\n\ngo 1.25\n",
+		"main.go":             solution,
+		"main_test.go":        "package main\n\n//starter:todo\nfunc helper() {}\n",
+		"store/store.go":      "package store\n\ntype Store struct{}\n\nfunc New() *Store { return &Store{} }\n",
+		"testdata/ignored.go": "package ignored\n\n//starter:todo\nfunc F() {}\n",
+	})
+
+	files, err := Generate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Path != "main.go" {
+		t.Fatalf("generated %v, want only main.go", files)
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+	"time"
+
+	"example.com/ex/store"
+)
+
+// Decode parses a JSON document.
+func Decode(name string, data []byte) (any, error) {
+	// TODO: Decode with encoding/json
+	// TODO: Wrap errors with the input name
+	panic("TODO")
+}
+
+// Describe is left alone.
+func Describe(v any) string {
+	return fmt.Sprint(v)
+}
+
+// Title shadows an import: strings.Title below is not the package's.
+func Title(v any) string {
+	strings := struct{ Title string }{fmt.Sprint(v)}
+	return strings.Title
+}
+
+func Upper(s string) string {
+	panic("TODO")
+}
+
+func Stats(items []Item) (count, total int, avg float64, best Item, names Names, grid Grid, ok bool, d time.Duration, s *store.Store) {
+	return 0, 0, 0, Item{}, nil, Grid{}, false, *new(time.Duration), nil
+}
+
+func First[T any](items []T) T {
+	return *new(T)
+}
+
+func Reset() {
+}
+
+type Item struct{ Name string }
+
+type Names []string
+
+type Grid [3][3]int
+`
+	if got := string(files[0].Data); got != want {
+		t.Errorf("generated main.go:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for name, tt := range map[string]struct{ src, want string }{
+		"mixed directives": {"package main\n\n//starter:todo\n//starter:zero\nfunc F() {}\n", "F: both"},
+		"no body":          {"package main\n\n//starter:todo\nfunc F()\n", "F: //starter:todo on a function without a body"},
+		"type error":       {"package main\n\n//starter:todo\nfunc F() {}\n\nfunc G() { undefined() }\n", "starter does not compile"},
+	} {
+		dir := t.TempDir()
+		testfiles.Write(t, dir, map[string]string{"go.mod": "module example.com/ex\n", "main.go": tt.src})
+		if _, err := Generate(dir); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got error %v, want %q", name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Package testdiff compares the test suites of an exercise and its solution.
+//
+// Exercises often keep a copy of their tests under solution/. The copies are
+// compared at the AST level, declaration by declaration, so formatting and
+// comments do not count as differences but a changed table or helper does.
+package testdiff
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Suite maps the names of the top-level declarations of _test.go files to
+// their normalized source: tests, benchmarks, examples and fuzz functions,
+// but also TestMain, helpers, test tables and types. Methods are named after
+// their receiver, e.g. "(*fixture).setup", and names declared in the same
+// spec are joined, e.g. "a, b". Imports are left out. Declarations outside
+// the root package are prefixed with their directory, e.g. "query.TestLexer".
+type Suite map[string]string
+
+// Load parses the _test.go files under root. Directories named solution,
+// testdata or vendor, and hidden directories, are skipped: a solution has its
+// own suite.
+func Load(root string) (Suite, error) {
+	suite := make(Suite)
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (name == "solution" || name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range declarations(file) {
+			source, err := normalize(fset, decl.node)
+			if err != nil {
+				return err
+			}
+			key := decl.name
+			if rel != "." {
+				key = filepath.ToSlash(rel) + "." + key
+			}
+			// init and _ may be declared more than once: "init#2"
+			unique := key
+			for n := 2; suite[unique] != ""; n++ {
+				unique = fmt.Sprintf("%s#%d", key, n)
+			}
+			suite[unique] = source
+		}
+		return nil
+	})
+	return suite, err
+}
+
+// declaration is a top-level declaration: a function, or a single spec of
+// a var, const or type declaration.
+type declaration struct {
+	name string
+	node ast.Node
+}
+
+// declarations splits the top-level declarations of file, without imports,
+// into functions and single specs.
+func declarations(file *ast.File) []declaration {
+	var decls []declaration
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				name = "(" + types.ExprString(decl.Recv.List[0].Type) + ")." + name
+			}
+			decls = append(decls, declaration{name, decl})
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				var names []string
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name.Name)
+				case *ast.ValueSpec:
+					for _, id := range spec.Names {
+						names = append(names, id.Name)
+					}
+				}
+				single := &ast.GenDecl{Tok: decl.Tok, Specs: []ast.Spec{spec}}
+				decls = append(decls, declaration{strings.Join(names, ", "), single})
+			}
+		}
+	}
+	return decls
+}
+
+// normalize prints a declaration. Files are parsed without comments, so
+// doc comments and comments inside bodies are left out.
+func normalize(fset *token.FileSet, node ast.Node) (string, error) {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Difference is a declaration that exists in only one suite or whose source
+// differs.
+type Difference struct {
+	Name       string // e.g. "TestParse", "tests" or "query.TestLexer"
+	InStarter  bool
+	InSolution bool
+}
+
+func (d Difference) String() string {
+	switch {
+	case !d.InSolution:
+		return d.Name + " only in exercise tests"
+	case !d.InStarter:
+		return d.Name + " only in solution tests"
+	default:
+		return d.Name + " differs"
+	}
+}
+
+// Compare returns the differences between the exercise's and the solution's
+// test suites, sorted by name.
+func Compare(starter, solution Suite) []Difference {
+	var diffs []Difference
+	for name, source := range starter {
+		other, ok := solution[name]
+		if !ok || other != source {
+			diffs = append(diffs, Difference{Name: name, InStarter: true, InSolution: ok})
+		}
+	}
+	for name := range solution {
+		if _, ok := starter[name]; !ok {
+			diffs = append(diffs, Difference{Name: name, InSolution: true})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs
+}
@@ -0,0 +1,94 @@
+package testdiff
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"exercise/internal/testfiles"
+)
+
+func TestCompare(t *testing.T) {
+	dir := t.TempDir()
+	testfiles.Write(t, dir, map[string]string{
+		"main_test.go": `package main
+
+import "testing"
+
+// TestSame has a doc comment only in this copy.
+func TestSame(t *testing.T) {
+	if 1+1 != 2 { t.Fatal("math") } // reformatted below
+}
+
+func TestChanged(t *testing.T) { t.Log("v1") }
+func TestOnlyExercise(t *testing.T) {}
+func TestMain(m *testing.M) { m.Run() }
+
+var tests = []struct{ in, want string }{{"a", "A"}}
+
+const limit, depth = 1, 2
+
+type fixture struct{ dir string }
+
+func (f *fixture) setup() {}
+func helper() {}
+func init() {}
+func init() { helper() }
+`,
+		"query/query_test.go": "package query\n\nimport \"testing\"\n\nfunc TestLexer(t *testing.T) {}\n",
+		"solution/main_test.go": `package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSame(t *testing.T) {
+	if 1+1 != 2 {
+		t.Fatal("math")
+	}
+}
+
+func TestChanged(t *testing.T) { t.Log("v2") }
+func BenchmarkOnlySolution(b *testing.B) {}
+func TestMain(m *testing.M) { os.Exit(m.Run()) }
+
+var tests = []struct{ in, want string }{{"a", "A"}, {"b", "B"}}
+
+const limit, depth = 1, 2
+
+type fixture struct{ dir string }
+
+func (f *fixture) setup() { f.dir = "" }
+func init() {}
+`,
+	})
+
+	starter, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	solution, err := Load(filepath.Join(dir, "solution"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	var got []string
+	for _, d := range Compare(starter, solution) {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"(*fixture).setup differs",
+		"BenchmarkOnlySolution only in solution tests",
+		"TestChanged differs",
+		"TestMain differs",
+		"TestOnlyExercise only in exercise tests",
+		"helper only in exercise tests",
+		"init#2 only in exercise tests",
+		"query.TestLexer only in exercise tests",
+		"tests differs",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Compare():\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
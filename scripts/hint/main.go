@@ -0,0 +1,180 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"exercise/hints"
+	"exercise/progress"
+)
+
+// Command-line flags
+var (
+	verbose     bool
+	noColor     bool
+	runTests    bool
+	unlockRuns  int
+	unlockWait  time.Duration
+	repoRoot    string
+	historyFile string
+)
+
+// printLevel shows one hint level with its heading.
+func printLevel(level hints.Level, total int, isNew bool) {
+	heading := fmt.Sprintf("Level %d/%d", level.Number, total)
+	if level.Title != "" {
+		heading += ": " + level.Title
+	}
+	if isNew {
+		heading = "💡 " + heading
+	}
+	fmt.Println(console.Title.Render("── " + heading + " ──"))
+	fmt.Println()
+	fmt.Println(level.Body)
+	fmt.Println()
+}
+
+// formatWait renders the time until a hint unlocks, rounded up to a minute.
+func formatWait(d time.Duration) string {
+	minutes := int((d + time.Minute - 1) / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// plural returns "" for one and "s" otherwise.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "Show every revealed level, not only the latest")
+	flag.BoolVar(&verbose, "verbose", false, "Show every revealed level, not only the latest")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.BoolVar(&runTests, "test", false, "Run the exercise's tests first; the run counts towards unlocking")
+	flag.IntVar(&unlockRuns, "runs", 3, "Test runs after a hint that unlock the next level")
+	flag.DurationVar(&unlockWait, "wait", 10*time.Minute, "Time after a hint that unlocks the next level")
+	flag.StringVar(&repoRoot, "root", filepath.Join("..", ".."), "Repository root")
+	flag.StringVar(&historyFile, "history", "", "Progress history file (default: "+progress.DefaultFile+" in the repo root)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: hint [flags] <exercise>\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Reveals the next level of an exercise's %s once it is unlocked.\n\n", hints.File)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if unlockRuns < 0 || unlockWait < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -runs and -wait must not be negative")
+		os.Exit(1)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	ex, err := exercise.Open(repoRoot, flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if historyFile == "" {
+		historyFile = filepath.Join(repoRoot, progress.DefaultFile)
+	}
+
+	levels, err := hints.Load(ex.Dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading hints: %v\n", err)
+		os.Exit(1)
+	}
+	if len(levels) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %s/%s has no hint sections\n", ex.Path, hints.File)
+		os.Exit(1)
+	}
+
+	history, err := progress.Load(historyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(console.Title.Render("💡 Hints for " + ex.Path + " - " + ex.Title))
+	fmt.Println()
+
+	if runTests {
+		fmt.Println(console.Dim.Render("🔄 Running tests..."))
+		run, err := progress.Test(ex)
+		attempt := progress.NewAttempt(time.Now(), run, err)
+		history.Record(ex.Path, attempt)
+		if attempt.Status == "pass" {
+			fmt.Println(console.Success.Render(fmt.Sprintf("✅ All %d tests pass, no hint needed", attempt.Total)))
+		} else {
+			fmt.Println(console.Fail.Render(fmt.Sprintf("❌ %d/%d tests pass", attempt.Passed, attempt.Total)))
+		}
+		fmt.Println()
+	}
+
+	// Levels removed from HINTS.md since they were revealed are not shown
+	now := time.Now()
+	policy := progress.HintPolicy{Runs: unlockRuns, Wait: unlockWait}
+	eh := history.Exercises[ex.Path]
+	revealed := min(eh.HintsRevealed(), len(levels))
+	unlocked, runsLeft, wait := eh.HintUnlocked(policy, now)
+
+	newLevel := 0
+	if revealed < len(levels) && unlocked {
+		newLevel = history.RevealHint(ex.Path, now)
+	}
+	if newLevel > 0 || runTests {
+		if err := history.Save(historyFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving history: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Earlier levels are shown again in verbose mode; without a new level,
+	// the latest one is
+	first := revealed
+	if verbose {
+		first = 1
+	} else if newLevel > 0 {
+		first = newLevel
+	}
+	for n := max(first, 1); n <= revealed; n++ {
+		printLevel(levels[n-1], len(levels), false)
+	}
+	if newLevel > 0 {
+		printLevel(levels[newLevel-1], len(levels), true)
+		revealed = newLevel
+	}
+
+	switch {
+	case revealed == len(levels):
+		fmt.Println(console.Dim.Render(fmt.Sprintf("All %d hint levels revealed", len(levels))))
+	case newLevel > 0 && (unlockRuns == 0 || unlockWait == 0):
+		fmt.Println(console.Dim.Render(fmt.Sprintf("Level %d is unlocked", revealed+1)))
+	case newLevel > 0:
+		fmt.Println(console.Dim.Render(fmt.Sprintf("Level %d unlocks after %d test run%s or in %s",
+			revealed+1, unlockRuns, plural(unlockRuns), formatWait(unlockWait))))
+	default:
+		fmt.Println(console.Warn.Render(fmt.Sprintf("🔒 Level %d unlocks after %d more test run%s or in %s",
+			revealed+1, runsLeft, plural(runsLeft), formatWait(wait))))
+		fmt.Println(console.Dim.Render("   Test runs count when made with -test, or the watch, progress or tui command"))
+	}
+	fmt.Println()
+}
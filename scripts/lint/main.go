@@ -0,0 +1,315 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"lint/passes/ctxfirst"
+	"lint/passes/errcheck"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/analysis/passes/copylock"
+	"golang.org/x/tools/go/packages"
+)
+
+// Command-line flags
+var (
+	verbose    bool
+	outputFile string
+	noColor    bool
+	parallel   int
+	solutions  bool
+	repoRoot   string
+)
+
+// analyzers are the checks exercises can enable, by name.
+var analyzers = map[string]*analysis.Analyzer{
+	copylock.Analyzer.Name: copylock.Analyzer,
+	ctxfirst.Analyzer.Name: ctxfirst.Analyzer,
+	errcheck.Analyzer.Name: errcheck.Analyzer,
+}
+
+// categoryAnalyzers run on every exercise of a category. Exercises add their
+// own with the "analyzers" manifest field.
+var categoryAnalyzers = map[string][]string{
+	"concurrency": {copylock.Analyzer.Name},
+	"advanced":    {ctxfirst.Analyzer.Name},
+}
+
+// exerciseAnalyzers returns the analyzers enabled for an exercise.
+func exerciseAnalyzers(ex exercise.Exercise) ([]*analysis.Analyzer, error) {
+	names := append(slices.Clone(categoryAnalyzers[ex.Category]), ex.Analyzers...)
+	slices.Sort(names)
+
+	enabled := make([]*analysis.Analyzer, 0, len(names))
+	for _, name := range slices.Compact(names) {
+		a, ok := analyzers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown analyzer %q in %s", name, exercise.ManifestFile)
+		}
+		enabled = append(enabled, a)
+	}
+	return enabled, nil
+}
+
+type LintResult struct {
+	console.Result
+	Analyzers []string
+	Issues    []string // "file.go:line:col: analyzer: message", relative to the exercise
+}
+
+type Linter struct {
+	printer console.Printer
+}
+
+func NewLinter(verbose bool) *Linter {
+	return &Linter{printer: console.Printer{Verbose: verbose}}
+}
+
+func (l *Linter) printResult(result LintResult) {
+	// Issues are listed between the logs and the message of a failure
+	printed := result.Result
+	for _, issue := range result.Issues {
+		printed.Logs = append(slices.Clip(printed.Logs), "     "+issue)
+	}
+	l.printer.Print(printed, "("+strings.Join(result.Analyzers, ", ")+")")
+}
+
+// packagesToLint returns the directory to load packages from and the
+// patterns to load: the student packages, or the reference solution.
+func packagesToLint(ex exercise.Exercise) (string, []string, error) {
+	if !solutions {
+		patterns, err := ex.StudentPackages()
+		return ex.Dir, patterns, err
+	}
+	if ex.HasSolutionModule() {
+		return ex.SolutionDir(), []string{"./..."}, nil
+	}
+	return ex.Dir, []string{"./solution/..."}, nil
+}
+
+func (l *Linter) lintExercise(ex exercise.Exercise) LintResult {
+	start := time.Now()
+	result := LintResult{
+		Result: console.Result{
+			Exercise: ex.Path,
+			Status:   "pass",
+			Logs:     make([]string, 0),
+		},
+		Issues: make([]string, 0),
+	}
+	fail := func(message string, err error) LintResult {
+		result.Status = "fail"
+		result.Message = message
+		result.Logs = append(result.Logs, "  ❌ "+message, "", err.Error())
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Log progress (verbose, will be cleared on success)
+	result.Logs = append(result.Logs, fmt.Sprintf("🔄 Linting %s...", ex.Path))
+
+	enabled, err := exerciseAnalyzers(ex)
+	if err != nil {
+		return fail("Invalid analyzers", err)
+	}
+	for _, a := range enabled {
+		result.Analyzers = append(result.Analyzers, a.Name)
+	}
+
+	result.Logs = append(result.Logs, "  🔍 Loading packages...")
+	dir, patterns, err := packagesToLint(ex)
+	if err != nil {
+		return fail("Loading packages failed", err)
+	}
+	if len(patterns) == 0 {
+		result.Logs = append(result.Logs, "  ⏭️  No Go packages")
+		result.Duration = time.Since(start)
+		return result
+	}
+	cfg := &packages.Config{
+		Mode: packages.LoadAllSyntax | packages.NeedModule,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return fail("Loading packages failed", err)
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return fail("Type checking failed", fmt.Errorf("%s: %v", pkg.PkgPath, pkg.Errors[0]))
+		}
+	}
+
+	result.Logs = append(result.Logs, "  🔬 Running "+strings.Join(result.Analyzers, ", ")+"...")
+	graph, err := checker.Analyze(enabled, pkgs, nil)
+	if err != nil {
+		return fail("Analysis failed", err)
+	}
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return fail("Analysis failed", fmt.Errorf("%s: %s: %w", act.Analyzer.Name, act.Package.PkgPath, act.Err))
+		}
+		generated := generatedFiles(act.Package)
+		for _, d := range act.Diagnostics {
+			pos := act.Package.Fset.Position(d.Pos)
+			if generated[pos.Filename] {
+				continue
+			}
+			file := pos.Filename
+			if rel, err := filepath.Rel(ex.Dir, file); err == nil {
+				file = filepath.ToSlash(rel)
+			}
+			result.Issues = append(result.Issues, fmt.Sprintf("%s:%d:%d: %s: %s",
+				file, pos.Line, pos.Column, act.Analyzer.Name, d.Message))
+		}
+	}
+
+	if len(result.Issues) > 0 {
+		sort.Strings(result.Issues)
+		result.Issues = slices.Compact(result.Issues)
+		result.Status = "fail"
+		result.Message = fmt.Sprintf("%d issue%s", len(result.Issues), plural(len(result.Issues)))
+	}
+
+	result.Duration = time.Since(start)
+	return result
+}
+
+// generatedFiles returns the files of pkg marked as generated, such as
+// protoc output, which students do not write and are not linted.
+func generatedFiles(pkg *packages.Package) map[string]bool {
+	generated := make(map[string]bool)
+	for _, f := range pkg.Syntax {
+		if ast.IsGenerated(f) {
+			generated[pkg.Fset.File(f.FileStart).Name()] = true
+		}
+	}
+	return generated
+}
+
+// plural returns "" for one and "s" otherwise.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "Verbose output")
+	flag.BoolVar(&verbose, "verbose", false, "Verbose output")
+	flag.StringVar(&outputFile, "output", "", "Write the issues found to file")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.IntVar(&parallel, "parallel", 4, "Number of exercises to lint in parallel")
+	flag.BoolVar(&solutions, "solutions", false, "Lint the reference solutions instead of the student code")
+	flag.StringVar(&repoRoot, "root", filepath.Join("..", ".."), "Repository root")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: lint [flags] [exercise...]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Runs the curriculum's static checks on the given exercises, or on all of them.\n\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(flag.CommandLine.Output(), "\nAnalyzers:\n")
+		names := make([]string, 0, len(analyzers))
+		for name := range analyzers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			doc, _, _ := strings.Cut(analyzers[name].Doc, "\n")
+			fmt.Fprintf(flag.CommandLine.Output(), "  %-10s %s\n", name, doc)
+		}
+	}
+	flag.Parse()
+
+	if parallel < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -parallel must be at least 1")
+		os.Exit(1)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving repo root: %v\n", err)
+		os.Exit(1)
+	}
+
+	console.Banner("Curriculum Static Checks (Go Edition)")
+
+	// Find the exercises to lint, either those given or all of them
+	var candidates []exercise.Exercise
+	if flag.NArg() > 0 {
+		for _, arg := range flag.Args() {
+			ex, err := exercise.Open(absRoot, arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			candidates = append(candidates, ex)
+		}
+	} else {
+		fmt.Println(console.Dim.Render("🔍 Finding exercises..."))
+		candidates, err = exercise.Discover(absRoot)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Only exercises with analyzers enabled are linted
+	exercises := make([]exercise.Exercise, 0, len(candidates))
+	for _, ex := range candidates {
+		if len(categoryAnalyzers[ex.Category]) > 0 || len(ex.Analyzers) > 0 {
+			exercises = append(exercises, ex)
+		} else if flag.NArg() > 0 {
+			fmt.Println(console.Dim.Render("⏭️  " + ex.Path + ": no analyzers enabled"))
+		}
+	}
+
+	code := "student code"
+	if solutions {
+		code = "reference solutions"
+	}
+	fmt.Printf("\nLinting %s of %s exercises (%d concurrent)...\n",
+		code, console.Title.Render(fmt.Sprintf("%d", len(exercises))), parallel)
+	fmt.Println()
+
+	startTime := time.Now()
+	linter := NewLinter(verbose)
+
+	// Results are printed in discovery order as soon as they are available
+	allResults := exercise.RunParallel(exercises, parallel, linter.lintExercise, linter.printResult)
+
+	elapsed := time.Since(startTime)
+
+	// Print summary, with issues prefixed by their exercise in the output file
+	var summary console.Summary
+	issues := 0
+	for _, result := range allResults {
+		issues += len(result.Issues)
+		for _, issue := range result.Issues {
+			result.Details = append(result.Details, result.Exercise+"/"+issue)
+		}
+		summary.Add(result.Result)
+	}
+	summary.Print(elapsed, fmt.Sprintf("Issues:   %s", console.Warn.Render(fmt.Sprintf("%d", issues))))
+	summary.WriteOutput(outputFile, "Exercises")
+
+	if len(summary.Failed) > 0 {
+		os.Exit(1)
+	}
+}
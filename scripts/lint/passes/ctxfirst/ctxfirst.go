@@ -0,0 +1,56 @@
+// Package ctxfirst defines an Analyzer that reports context.Context
+// parameters that are not the first parameter of their function.
+package ctxfirst
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const Doc = `report context.Context parameters that are not first
+
+By convention, a function taking a context.Context takes it as its first
+parameter, usually named ctx. This applies to function declarations and
+literals, function types and interface methods alike.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "ctxfirst",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.FuncType)(nil)}, func(n ast.Node) {
+		params := n.(*ast.FuncType).Params
+		if params == nil {
+			return
+		}
+
+		// Fields group parameters sharing a type, as in (a, b int)
+		i := 0
+		for _, field := range params.List {
+			if i > 0 && isContext(pass.TypesInfo.TypeOf(field.Type)) {
+				pass.ReportRangef(field, "context.Context should be the first parameter")
+				return
+			}
+			i += max(len(field.Names), 1)
+		}
+	})
+	return nil, nil
+}
+
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context"
+}
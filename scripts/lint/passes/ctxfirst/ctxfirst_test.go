@@ -0,0 +1,13 @@
+package ctxfirst_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"lint/passes/ctxfirst"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), ctxfirst.Analyzer, "a")
+}
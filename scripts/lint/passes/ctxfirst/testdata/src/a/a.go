@@ -0,0 +1,26 @@
+package a
+
+import (
+	"context"
+	"time"
+)
+
+func first(ctx context.Context, d time.Duration) {}
+
+func second(d time.Duration, ctx context.Context) {} // want `context.Context should be the first parameter`
+
+func grouped(a, b int, ctx context.Context) {} // want `context.Context should be the first parameter`
+
+func both(ctx, other context.Context) {}
+
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) error
+	Retry(url string, ctx context.Context) error // want `context.Context should be the first parameter`
+}
+
+type handler func(string, context.Context) // want `context.Context should be the first parameter`
+
+func literal() {
+	_ = func(id int, ctx context.Context) {} // want `context.Context should be the first parameter`
+	_ = func(ctx context.Context, id int) {}
+}
@@ -0,0 +1,239 @@
+// Package errcheck defines an Analyzer that reports ignored errors.
+package errcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const Doc = `report calls whose error result is ignored
+
+An error is ignored when a call returning one is used as a statement, or
+when its error result is assigned to the blank identifier, or when a call
+returning one is deferred. Deferring Close on a value that is only read, such
+as a file opened with os.Open or a value that cannot be written to, is
+idiomatic and not reported: the data read was already checked. fmt.Print
+functions and writes to in-memory buffers, which do not fail in practice,
+are not reported either.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "errcheck",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+// excluded lists functions whose errors may be ignored, by full name.
+var excluded = map[string]bool{
+	"fmt.Print":   true,
+	"fmt.Printf":  true,
+	"fmt.Println": true,
+}
+
+// bufferTypes cannot fail to be written to.
+var bufferTypes = []string{"*bytes.Buffer", "*strings.Builder"}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.ExprStmt)(nil), (*ast.AssignStmt)(nil), (*ast.DeferStmt)(nil)}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			call, ok := ast.Unparen(n.X).(*ast.CallExpr)
+			if ok && !ignorable(pass, call) && returnsError(pass, call) {
+				pass.ReportRangef(call, "error returned by %s is not checked", callName(pass, call))
+			}
+
+		case *ast.DeferStmt:
+			if !ignorable(pass, n.Call) && !readOnlyClose(pass, n.Call, stack) && returnsError(pass, n.Call) {
+				pass.ReportRangef(n.Call, "error returned by deferred %s is not checked", callName(pass, n.Call))
+			}
+
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); !ok || id.Name != "_" {
+					continue
+				}
+				call, t := assigned(pass, n, i)
+				if call == nil || !types.Identical(t, errorType) || ignorable(pass, call) {
+					continue
+				}
+				pass.ReportRangef(lhs, "error returned by %s is assigned to _", callName(pass, call))
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// returnsError reports whether one of the results of call is an error.
+func returnsError(pass *analysis.Pass, call *ast.CallExpr) bool {
+	for _, t := range results(pass, call) {
+		if types.Identical(t, errorType) {
+			return true
+		}
+	}
+	return false
+}
+
+// readOnlyClose reports whether call closes a value that is only read: one
+// that cannot be written to, or a variable only ever assigned a file opened
+// with os.Open in the function enclosing the call. stack holds the nodes
+// enclosing the call.
+func readOnlyClose(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Close" || len(call.Args) > 0 {
+		return false
+	}
+	if !isWriter(pass.TypesInfo.TypeOf(sel.X)) {
+		return true
+	}
+
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok {
+		return false
+	}
+	var body ast.Node
+	for i := len(stack) - 1; i >= 0 && body == nil; i-- {
+		switch f := stack[i].(type) {
+		case *ast.FuncDecl:
+			body = f.Body
+		case *ast.FuncLit:
+			body = f.Body
+		}
+	}
+	if body == nil {
+		return false
+	}
+
+	opened := false
+	readOnly := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || !readOnly {
+			return readOnly
+		}
+		for i, lhs := range assign.Lhs {
+			id, ok := lhs.(*ast.Ident)
+			if !ok || (pass.TypesInfo.Defs[id] != v && pass.TypesInfo.Uses[id] != v) {
+				continue
+			}
+			if call, _ := assigned(pass, assign, i); call != nil && isFunc(pass, call, "os.Open") {
+				opened = true
+			} else {
+				readOnly = false
+			}
+		}
+		return true
+	})
+	return opened && readOnly
+}
+
+// isWriter reports whether values of type t have a Write method.
+func isWriter(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Write")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// isFunc reports whether call calls the package-level function with the
+// given full name, e.g. "os.Open".
+func isFunc(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && fn.FullName() == name
+}
+
+// results returns the result types of a call.
+func results(pass *analysis.Pass, call *ast.CallExpr) []types.Type {
+	switch t := pass.TypesInfo.TypeOf(call).(type) {
+	case nil:
+		return nil
+	case *types.Tuple:
+		ts := make([]types.Type, t.Len())
+		for i := range ts {
+			ts[i] = t.At(i).Type()
+		}
+		return ts
+	default:
+		return []types.Type{t}
+	}
+}
+
+// assigned returns the call whose result the i-th left-hand side of an
+// assignment receives, and the type of that result.
+func assigned(pass *analysis.Pass, assign *ast.AssignStmt, i int) (*ast.CallExpr, types.Type) {
+	if len(assign.Lhs) == len(assign.Rhs) {
+		call, ok := ast.Unparen(assign.Rhs[i]).(*ast.CallExpr)
+		if !ok {
+			return nil, nil
+		}
+		return call, pass.TypesInfo.TypeOf(call)
+	}
+	if len(assign.Rhs) != 1 {
+		return nil, nil
+	}
+	call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok {
+		return nil, nil
+	}
+	if ts := results(pass, call); i < len(ts) {
+		return call, ts[i]
+	}
+	return nil, nil
+}
+
+// ignorable reports whether the errors of call may be ignored.
+func ignorable(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return false
+	}
+	if excluded[fn.FullName()] {
+		return true
+	}
+
+	// Writes to buffers, directly or through fmt.Fprint*
+	if recv := fn.Signature().Recv(); recv != nil {
+		return strings.HasPrefix(fn.Name(), "Write") && isBuffer(recv.Type())
+	}
+	if fn.Pkg() != nil && fn.Pkg().Path() == "fmt" && strings.HasPrefix(fn.Name(), "Fprint") && len(call.Args) > 0 {
+		return isBuffer(pass.TypesInfo.TypeOf(call.Args[0]))
+	}
+	return false
+}
+
+func isBuffer(t types.Type) bool {
+	for _, name := range bufferTypes {
+		if types.TypeString(t, nil) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// callName names the function called, e.g. "os.Remove" or "f.Close".
+func callName(pass *analysis.Pass, call *ast.CallExpr) string {
+	if fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok && fn.Signature().Recv() == nil && fn.Pkg() != nil {
+		return fn.Pkg().Name() + "." + fn.Name()
+	}
+	return types.ExprString(call.Fun)
+}
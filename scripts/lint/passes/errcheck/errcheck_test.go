@@ -0,0 +1,13 @@
+package errcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"lint/passes/errcheck"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), errcheck.Analyzer, "a")
+}
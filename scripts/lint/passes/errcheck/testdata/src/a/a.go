@@ -0,0 +1,66 @@
+package a
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+func write(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close() // want `error returned by deferred f.Close is not checked`
+
+	f.WriteString("data")    // want `error returned by f.WriteString is not checked`
+	_, _ = f.Write(nil)      // want `error returned by f.Write is assigned to _`
+	n, _ := f.Write(nil)     // want `error returned by f.Write is assigned to _`
+	_ = os.Remove(name)      // want `error returned by os.Remove is assigned to _`
+	os.Remove(name)          // want `error returned by os.Remove is not checked`
+	fmt.Fprintln(f, n)       // want `error returned by fmt.Fprintln is not checked`
+	_, _ = fmt.Sscan("", &n) // want `error returned by fmt.Sscan is assigned to _`
+	_ = n
+
+	// Errors that may be ignored
+	fmt.Println("done")
+	var buf bytes.Buffer
+	buf.WriteString("x")
+	fmt.Fprintf(&buf, "%d", n)
+	var sb strings.Builder
+	sb.WriteByte('x')
+	_, err = f.Write(nil)
+	go os.Remove(name)
+	return err
+}
+
+func read(name string, rc io.ReadCloser) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	defer rc.Close()
+
+	resp, err := http.Get(name)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()       // want `error returned by deferred w.Flush is not checked`
+	defer os.Remove(name) // want `error returned by deferred os.Remove is not checked`
+
+	g, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer g.Close() // want `error returned by deferred g.Close is not checked`
+	g, err = os.OpenFile(name, os.O_RDWR, 0)
+	return err
+}
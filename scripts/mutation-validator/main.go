@@ -0,0 +1,304 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"exercise/gotest"
+	"exercise/mutation"
+	"exercise/report"
+	"exercise/sandbox"
+)
+
+// Command-line flags
+var (
+	verbose      bool
+	outputFile   string
+	noColor      bool
+	parallel     int
+	reportFormat string
+	reportFile   string
+	testTimeout  time.Duration
+	maxMutants   int
+	minScore     float64
+)
+
+// minMutantTimeout is the least time a mutant's tests get, however fast the
+// unmutated tests ran.
+const minMutantTimeout = 5 * time.Second
+
+// buildGrace is the time go test may spend building a mutant before the
+// tests' own -timeout starts counting.
+const buildGrace = 60 * time.Second
+
+// mutatedFile is the solution file mutants are made of.
+const mutatedFile = "main.go"
+
+type TestResult struct {
+	console.Result
+	Score     *float64 // nil when no mutant could be scored
+	Survivors []string // surviving mutants, "main.go:12:5: changed < to <="
+}
+
+type MutationTester struct {
+	printer console.Printer
+	timeout time.Duration
+}
+
+func NewMutationTester(verbose bool, timeout time.Duration) *MutationTester {
+	return &MutationTester{printer: console.Printer{Verbose: verbose}, timeout: timeout}
+}
+
+func (mt *MutationTester) printResult(result TestResult) {
+	mt.printer.Print(result.Result, result.Message)
+}
+
+// testArgs returns the go test flags for an exercise's tests.
+func testArgs(ex exercise.Exercise, timeout time.Duration) []string {
+	args := []string{"-timeout", timeout.String(), "-failfast"}
+	if ex.Race {
+		args = append(args, "-race")
+	}
+	return append(args, "./...")
+}
+
+// testExercise runs the exercise's tests against every mutant of the
+// solution's main.go and scores how many of them the tests catch.
+func (mt *MutationTester) testExercise(ex exercise.Exercise) TestResult {
+	start := time.Now()
+	result := TestResult{Result: console.Result{
+		Exercise: ex.Path,
+		Logs:     []string{fmt.Sprintf("🔄 Mutating %s/solution/%s...", ex.Path, mutatedFile)},
+	}}
+	fail := func(message string, logs ...string) TestResult {
+		result.Status = "fail"
+		result.Message = message
+		result.Logs = append(result.Logs, "  ❌ "+message)
+		result.Logs = append(result.Logs, logs...)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	src, err := os.ReadFile(filepath.Join(ex.SolutionDir(), mutatedFile))
+	if err != nil {
+		result.Status = "warn"
+		result.Message = "No solution/" + mutatedFile + " to mutate"
+		result.Logs = append(result.Logs, "  ⚠️  "+result.Message)
+		result.Duration = time.Since(start)
+		return result
+	}
+	mutants, err := mutation.Generate(mutatedFile, src)
+	if err != nil {
+		return fail("Cannot parse solution/"+mutatedFile, err.Error())
+	}
+	if len(mutants) == 0 {
+		result.Status = "pass"
+		result.Message = "No mutants in solution/" + mutatedFile
+		result.Duration = time.Since(start)
+		return result
+	}
+	total := len(mutants)
+	mutants = mutation.Sample(mutants, maxMutants)
+
+	// Mutants replace the solution's main.go in a staged copy of the exercise
+	tmpDir, err := os.MkdirTemp("", "mutation-validator")
+	if err != nil {
+		return fail("Staging failed", err.Error())
+	}
+	defer os.RemoveAll(tmpDir)
+	staged := filepath.Join(tmpDir, filepath.Base(ex.Dir))
+	if err := ex.StageSolution(staged); err != nil {
+		return fail("Staging failed", err.Error())
+	}
+	target := filepath.Join(staged, mutatedFile)
+
+	// The unmutated solution must pass, and bounds the time mutants get
+	timeout := ex.TestTimeout(mt.timeout)
+	result.Logs = append(result.Logs, "  🧪 Running exercise tests against the solution...")
+	baselineStart := time.Now()
+	run, err := gotest.TestWithLimits(staged, sandbox.Limits{Timeout: timeout + buildGrace}, testArgs(ex, timeout)...)
+	if err != nil {
+		return fail("Solution fails exercise tests, mutants cannot be scored", run.Output)
+	}
+	mutantTimeout := min(timeout, max(minMutantTimeout, 10*time.Since(baselineStart)))
+
+	if len(mutants) < total {
+		result.Logs = append(result.Logs, fmt.Sprintf("  🧬 Testing %d of %d mutants...", len(mutants), total))
+	} else {
+		result.Logs = append(result.Logs, fmt.Sprintf("  🧬 Testing %d mutants...", total))
+	}
+	killed, invalid := 0, 0
+	for _, m := range mutants {
+		if err := os.WriteFile(target, m.Apply(src), 0o644); err != nil {
+			return fail("Cannot write mutant", err.Error())
+		}
+		limits := sandbox.Limits{Timeout: mutantTimeout + buildGrace}
+		run, err := gotest.TestWithLimits(staged, limits, testArgs(ex, mutantTimeout)...)
+
+		name := mutatedFile + ":" + m.String()
+		switch {
+		case errors.Is(err, sandbox.ErrTimeout) || run.TimedOut:
+			killed++
+			if mt.printer.Verbose {
+				result.Logs = append(result.Logs, "  ⏱️  "+name+" (timed out)")
+			}
+		case err == nil:
+			result.Survivors = append(result.Survivors, name)
+			result.Logs = append(result.Logs, "  🧟 "+name)
+		case strings.Contains(run.Output, "[build failed]") || strings.Contains(run.Output, "[setup failed]"):
+			invalid++
+			if mt.printer.Verbose {
+				result.Logs = append(result.Logs, "  🚧 "+name+" (does not build)")
+			}
+		default:
+			killed++
+			if mt.printer.Verbose {
+				result.Logs = append(result.Logs, "  🎯 "+name)
+			}
+		}
+	}
+
+	survived := len(result.Survivors)
+	score := mutation.Score(killed, survived)
+	if killed+survived > 0 {
+		result.Score = &score
+	}
+	result.Status = "pass"
+	result.Message = fmt.Sprintf("%.0f%% (%d killed, %d survived", score, killed, survived)
+	if invalid > 0 {
+		result.Message += fmt.Sprintf(", %d did not build", invalid)
+	}
+	result.Message += ")"
+	if score < minScore {
+		result.Status = "warn"
+		result.Message = fmt.Sprintf("Weak tests: mutation score %s, below %.0f%%", result.Message, minScore)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// buildReport converts results into a machine-readable report.
+func buildReport(results []TestResult, elapsed time.Duration) *report.Report {
+	exercises := make([]report.Exercise, 0, len(results))
+	for _, result := range results {
+		exercises = append(exercises, report.Exercise{
+			Name:             result.Exercise,
+			Status:           result.Status,
+			Message:          result.Message,
+			Duration:         result.Duration.Seconds(),
+			MutationScore:    result.Score,
+			SurvivingMutants: result.Survivors,
+		})
+	}
+	return report.New("mutation", elapsed, exercises)
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "Show every mutant tested (verbose mode)")
+	flag.BoolVar(&verbose, "verbose", false, "Show every mutant tested (verbose mode)")
+	flag.StringVar(&outputFile, "output", "", "Write failed exercises to file")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.IntVar(&parallel, "parallel", 10, "Number of exercises to mutate concurrently")
+	flag.StringVar(&reportFormat, "format", "text", "Report format: text, json, or junit")
+	flag.StringVar(&reportFile, "report", "", "Write the json/junit report to file (default: report.json or report.xml)")
+	flag.DurationVar(&testTimeout, "timeout", 30*time.Second, "Test timeout for exercises whose manifest sets none")
+	flag.IntVar(&maxMutants, "max-mutants", 100, "Mutants tested per exercise, spread over the file (0 for all)")
+	flag.Float64Var(&minScore, "min-score", 60, "Mutation score in percent below which an exercise's tests are flagged as weak")
+	flag.Parse()
+
+	if parallel < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -parallel must be at least 1")
+		os.Exit(1)
+	}
+	if reportFormat != "text" && !slices.Contains(report.Formats, reportFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unknown -format %q (want text, json, or junit)\n", reportFormat)
+		os.Exit(1)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	// When running from scripts/mutation-validator, go up two levels to project root
+	repoRoot := filepath.Join("..", "..")
+	if flag.NArg() > 0 {
+		repoRoot = flag.Arg(0)
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving repo root: %v\n", err)
+		os.Exit(1)
+	}
+
+	console.Banner("CI Test Suite Mutation Validation (Go Edition)")
+
+	// Find exercises that ship a reference solution
+	fmt.Println(console.Dim.Render("🔍 Finding exercises..."))
+	discovered, err := exercise.Discover(absRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+		os.Exit(1)
+	}
+	exercises := make([]exercise.Exercise, 0, len(discovered))
+	for _, ex := range discovered {
+		if info, err := os.Stat(ex.SolutionDir()); err == nil && info.IsDir() {
+			exercises = append(exercises, ex)
+		}
+	}
+
+	fmt.Printf("\nMutating %s solutions in parallel (%d concurrent)...\n",
+		console.Title.Render(fmt.Sprintf("%d", len(exercises))), parallel)
+	fmt.Println(console.Dim.Render("Each exercise: run tests against the solution → run them against each mutant of solution/" + mutatedFile))
+	fmt.Println()
+
+	startTime := time.Now()
+	tester := NewMutationTester(verbose, testTimeout)
+
+	// Results are printed in discovery order as soon as they are available
+	allResults := exercise.RunParallel(exercises, parallel, tester.testExercise, tester.printResult)
+
+	elapsed := time.Since(startTime)
+
+	// Print summary
+	var summary console.Summary
+	scoreSum, scored := 0.0, 0
+	for _, result := range allResults {
+		result.Details = result.Survivors
+		summary.Add(result.Result)
+		if result.Score != nil {
+			scoreSum += *result.Score
+			scored++
+		}
+	}
+	var extra []string
+	if scored > 0 {
+		extra = append(extra, fmt.Sprintf("Score:    %s", console.Title.Render(fmt.Sprintf("%.0f%% average", scoreSum/float64(scored)))))
+	}
+	summary.Print(elapsed, extra...)
+	summary.WriteOutput(outputFile, "Exercises")
+
+	// Write machine-readable report if requested
+	if err := console.WriteReport(buildReport(allResults, elapsed), reportFormat, reportFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(summary.Failed) == 0 {
+		fmt.Println(console.Success.Render("✅ All mutation validations passed"))
+		os.Exit(0)
+	} else {
+		fmt.Println(console.Fail.Render("❌ Some mutation validations failed"))
+		os.Exit(1)
+	}
+}
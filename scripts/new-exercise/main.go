@@ -0,0 +1,156 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"exercise/scaffold"
+)
+
+// Command-line flags
+var (
+	noColor       bool
+	dryRun        bool
+	repoRoot      string
+	title         string
+	difficulty    int
+	estimatedTime time.Duration
+	timeout       time.Duration
+	race          bool
+	prerequisites string
+)
+
+// titleCase turns an exercise name such as "worker-pools" into "Worker Pools".
+func titleCase(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// parseCategory accepts a category name or its directory, "basics" or "01-basics".
+func parseCategory(arg string) (string, error) {
+	for _, c := range exercise.Categories {
+		if arg == c || arg == exercise.CategoryDir(c) {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("unknown category %q (one of %s)", arg, strings.Join(exercise.Categories, ", "))
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.BoolVar(&dryRun, "dry-run", false, "Show the changes without making them")
+	flag.StringVar(&repoRoot, "root", filepath.Join("..", ".."), "Repository root")
+	flag.StringVar(&title, "title", "", "Exercise title (default: derived from the name)")
+	flag.IntVar(&difficulty, "difficulty", 2, "Difficulty, from 1 to 5 stars")
+	flag.DurationVar(&estimatedTime, "time", 45*time.Minute, "Estimated time to complete the exercise")
+	flag.DurationVar(&timeout, "timeout", 30*time.Second, "Test timeout written to the manifest")
+	flag.BoolVar(&race, "race", false, "Run the tests with the race detector (default: true for concurrency)")
+	flag.StringVar(&prerequisites, "prerequisites", "", "Comma-separated prerequisite exercises (default: none)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: new-exercise [flags] <category> <number> <name>\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Creates an exercise from templates, e.g. new-exercise concurrency 16 worker-pools.\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Exercises from <number> on are renumbered to make room.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 3 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	category, err := parseCategory(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	number, err := strconv.Atoi(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid exercise number %q\n", flag.Arg(1))
+		os.Exit(1)
+	}
+	name := flag.Arg(2)
+
+	spec := scaffold.Spec{
+		Number: number,
+		Name:   name,
+		Manifest: exercise.Manifest{
+			Title:         title,
+			Category:      category,
+			Difficulty:    difficulty,
+			EstimatedTime: exercise.Duration{Duration: estimatedTime},
+			Timeout:       exercise.Duration{Duration: timeout},
+			Race:          race,
+		},
+	}
+	if spec.Title == "" && name != "" {
+		spec.Title = titleCase(name)
+	}
+	for _, p := range strings.Split(prerequisites, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			spec.Prerequisites = append(spec.Prerequisites, p)
+		}
+	}
+	if !isSet("race") {
+		spec.Race = category == "concurrency"
+	}
+
+	plan, err := scaffold.NewPlan(repoRoot, spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(console.Title.Render("🏗️  " + plan.Path + " - " + spec.Title))
+	for _, r := range plan.Renames {
+		fmt.Printf("  ↪ %s → %s\n", r.From, r.To)
+	}
+	for _, f := range plan.Updated {
+		fmt.Printf("  ✏️  %s\n", f.Path)
+	}
+	for _, f := range plan.Created {
+		fmt.Printf("  ➕ %s\n", f.Path)
+	}
+	fmt.Println()
+
+	if dryRun {
+		fmt.Println(console.Dim.Render("Dry run: nothing was changed"))
+		return
+	}
+	if err := plan.Apply(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(console.Success.Render("✅ Created " + plan.Path))
+	fmt.Println(console.Dim.Render("   Replace the TODOs and the Solve placeholder, then run the validators"))
+}
+
+// isSet reports whether the named flag was given on the command line.
+func isSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/apidiff"
+	"exercise/console"
+	"exercise/gotest"
+	"exercise/report"
+	"exercise/sandbox"
+	"exercise/testdiff"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Command-line flags
+var (
+	verbose      bool
+	outputFile   string
+	noColor      bool
+	parallel     int
+	reportFormat string
+	reportFile   string
+	runTests     bool
+	testTimeout  time.Duration
+	memoryLimit  string
+	cpuLimit     time.Duration
+)
+
+// buildGrace is the time go test may spend building the staged exercise
+// before the tests' own -timeout starts counting.
+const buildGrace = 60 * time.Second
+
+type TestResult struct {
+	console.Result
+	Tests []gotest.TestCase // exercise tests run against the solution (-run-tests)
+}
+
+// severity orders result statuses.
+var severity = map[string]int{"pass": 0, "warn": 1, "fail": 2}
+
+// raise records a problem: the result takes the more severe status, and
+// messages of the same severity are combined.
+func (r *TestResult) raise(status, message string) {
+	switch {
+	case severity[status] > severity[r.Status]:
+		r.Status = status
+		r.Message = message
+	case severity[status] == severity[r.Status]:
+		r.Message += "; " + message
+	}
+}
+
+type ParityTester struct {
+	printer console.Printer
+
+	timeout time.Duration  // test timeout for exercises whose manifest sets none
+	limits  sandbox.Limits // memory and CPU limits for every test process (-run-tests)
+}
+
+func NewParityTester(verbose bool) *ParityTester {
+	return &ParityTester{printer: console.Printer{Verbose: verbose}}
+}
+
+func (pt *ParityTester) printResult(result TestResult) {
+	pt.printer.Print(result.Result, "")
+}
+
+// loadPackages type-checks the packages matching patterns in dir, keyed by
+// their directory relative to dir ("." for dir itself).
+func loadPackages(dir string, patterns ...string) (map[string]*types.Package, error) {
+	result := make(map[string]*types.Package)
+	if len(patterns) == 0 {
+		return result, nil
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("%s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+		if len(pkg.GoFiles) == 0 {
+			continue
+		}
+		rel, err := filepath.Rel(dir, filepath.Dir(pkg.GoFiles[0]))
+		if err != nil {
+			return nil, err
+		}
+		result[filepath.ToSlash(rel)] = pkg.Types
+	}
+	return result, nil
+}
+
+func (pt *ParityTester) testExercise(ex exercise.Exercise) TestResult {
+	start := time.Now()
+	result := TestResult{Result: console.Result{
+		Exercise: ex.Path,
+		Status:   "pass",
+		Logs:     make([]string, 0),
+	}}
+
+	// Log progress (verbose, will be cleared on success)
+	result.Logs = append(result.Logs, fmt.Sprintf("🔄 Checking %s...", ex.Path))
+
+	// Load starter API
+	result.Logs = append(result.Logs, "  🔍 Loading starter packages...")
+	patterns, err := ex.StudentPackages()
+	if err != nil {
+		return typeCheckFailed(result, start, err)
+	}
+	starter, err := loadPackages(ex.Dir, patterns...)
+	if err != nil {
+		return typeCheckFailed(result, start, err)
+	}
+
+	// Load solution API
+	result.Logs = append(result.Logs, "  🔍 Loading solution packages...")
+	solution, err := loadPackages(ex.SolutionDir(), "./...")
+	if err != nil {
+		return typeCheckFailed(result, start, err)
+	}
+
+	pt.compareAPI(&result, starter, solution)
+	pt.compareTests(&result, ex)
+	if runTests {
+		pt.runExerciseTests(&result, ex)
+	}
+
+	result.Duration = time.Since(start)
+	return result
+}
+
+// typeCheckFailed fails a result whose packages could not be loaded.
+func typeCheckFailed(result TestResult, start time.Time, err error) TestResult {
+	result.raise("fail", "Type checking failed")
+	result.Logs = append(result.Logs, "  ❌ Type checking failed", "", err.Error())
+	result.Duration = time.Since(start)
+	return result
+}
+
+// compareAPI sets the result from the differences between both APIs.
+func (pt *ParityTester) compareAPI(result *TestResult, starter, solution map[string]*types.Package) {
+	result.Logs = append(result.Logs, "  🔗 Comparing exported API...")
+	diffs := apidiff.Compare(starter, solution)
+	if len(diffs) == 0 {
+		return
+	}
+
+	result.raise("fail", fmt.Sprintf("API differs between starter and solution (%d differences)", len(diffs)))
+	result.Logs = append(result.Logs, "  ❌ API differences:")
+	for _, d := range diffs {
+		result.Logs = append(result.Logs, "     - "+strings.ReplaceAll(d.String(), "\n", "\n       "))
+	}
+}
+
+// compareTests diffs the exercise's tests with the copy kept under solution/.
+// Declarations missing from one copy fail the exercise; declarations that
+// differ are a warning.
+func (pt *ParityTester) compareTests(result *TestResult, ex exercise.Exercise) {
+	result.Logs = append(result.Logs, "  🧾 Comparing test suites...")
+	parseFailed := func(err error) {
+		result.raise("fail", "Parsing tests failed")
+		result.Logs = append(result.Logs, "  ❌ Parsing tests failed", "", err.Error())
+	}
+
+	starter, err := testdiff.Load(ex.Dir)
+	if err != nil {
+		parseFailed(err)
+		return
+	}
+	solution, err := testdiff.Load(ex.SolutionDir())
+	if err != nil {
+		parseFailed(err)
+		return
+	}
+	if len(solution) == 0 {
+		return // no copy of the tests under solution/
+	}
+	reportTestDiffs(result, testdiff.Compare(starter, solution))
+}
+
+// reportTestDiffs records the differences between the two test suites.
+func reportTestDiffs(result *TestResult, diffs []testdiff.Difference) {
+	if len(diffs) == 0 {
+		return
+	}
+
+	missing := 0
+	for _, d := range diffs {
+		if !d.InStarter || !d.InSolution {
+			missing++
+		}
+	}
+	if missing > 0 {
+		result.raise("fail", fmt.Sprintf("%d test declarations exist in only one test suite", missing))
+	}
+	if changed := len(diffs) - missing; changed > 0 {
+		result.raise("warn", fmt.Sprintf("%d test declarations differ between the exercise and solution test suites", changed))
+	}
+
+	result.Logs = append(result.Logs, "  ⚠️  Test suite differences:")
+	for _, d := range diffs {
+		result.Logs = append(result.Logs, "     - "+d.String())
+	}
+}
+
+// runExerciseTests runs the exercise's tests against the reference solution,
+// proving the student-facing suite can be satisfied.
+func (pt *ParityTester) runExerciseTests(result *TestResult, ex exercise.Exercise) {
+	result.Logs = append(result.Logs, "  🧪 Running exercise tests against the solution...")
+
+	tmpDir, err := os.MkdirTemp("", "parity-validator")
+	if err != nil {
+		result.raise("fail", "Staging failed")
+		result.Logs = append(result.Logs, "  ❌ Staging failed: "+err.Error())
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+
+	staged := filepath.Join(tmpDir, filepath.Base(ex.Dir))
+	if err := ex.StageSolution(staged); err != nil {
+		result.raise("fail", "Staging failed")
+		result.Logs = append(result.Logs, "  ❌ Staging failed: "+err.Error())
+		return
+	}
+
+	timeout := ex.TestTimeout(pt.timeout)
+	args := []string{"-timeout", timeout.String()}
+	if ex.Race {
+		args = append(args, "-race")
+	}
+	limits := pt.limits
+	limits.Timeout = timeout + buildGrace
+	run, err := gotest.TestWithLimits(staged, limits, append(args, "./...")...)
+	result.Tests = run.Tests
+	switch {
+	case err == nil:
+		return
+	case errors.Is(err, sandbox.ErrTimeout) || run.TimedOut:
+		message := console.TimeoutMessage(run, timeout)
+		result.raise("fail", message)
+		result.Logs = append(result.Logs, "  ❌ "+message)
+		return
+	case run.OutOfMemory:
+		result.raise("fail", "Exercise tests ran out of memory (see -memory-limit)")
+		result.Logs = append(result.Logs, "  ❌ Exercise tests ran out of memory (see -memory-limit)")
+		return
+	}
+
+	failed := run.Failed()
+	if len(failed) == 0 {
+		result.raise("fail", "Exercise tests do not build against the solution")
+		result.Logs = append(result.Logs, "  ❌ Exercise tests do not build against the solution", "", run.Output)
+		return
+	}
+	names := make([]string, 0, len(failed))
+	for _, tc := range failed {
+		names = append(names, tc.Name)
+	}
+	result.raise("fail", "Solution fails exercise tests: "+strings.Join(names, ", "))
+	result.Logs = append(result.Logs, "  ❌ Solution fails exercise tests:")
+	for _, tc := range failed {
+		result.Logs = append(result.Logs, fmt.Sprintf("--- FAIL: %s (%.2fs)", tc.Name, tc.Elapsed))
+		if tc.Output != "" {
+			result.Logs = append(result.Logs, strings.TrimRight(tc.Output, "\n"))
+		}
+	}
+}
+
+// buildReport converts results into a machine-readable report.
+func buildReport(results []TestResult, elapsed time.Duration) *report.Report {
+	exercises := make([]report.Exercise, 0, len(results))
+	for _, result := range results {
+		exercises = append(exercises, report.Exercise{
+			Name:     result.Exercise,
+			Status:   result.Status,
+			Message:  result.Message,
+			Duration: result.Duration.Seconds(),
+			Tests:    result.Tests,
+		})
+	}
+	return report.New("parity", elapsed, exercises)
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "Show detailed output (verbose mode)")
+	flag.BoolVar(&verbose, "verbose", false, "Show detailed output (verbose mode)")
+	flag.StringVar(&outputFile, "output", "", "Write failed exercises to file")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.IntVar(&parallel, "parallel", 10, "Number of exercises to check concurrently")
+	flag.StringVar(&reportFormat, "format", "text", "Report format: text, json, or junit")
+	flag.StringVar(&reportFile, "report", "", "Write the json/junit report to file (default: report.json or report.xml)")
+	flag.BoolVar(&runTests, "run-tests", false, "Also run each exercise's tests against its solution")
+	flag.DurationVar(&testTimeout, "timeout", 30*time.Second, "Test timeout for exercises whose manifest sets none (-run-tests)")
+	flag.StringVar(&memoryLimit, "memory-limit", "", "Address space limit per test process, e.g. 2GiB (default: none)")
+	flag.DurationVar(&cpuLimit, "cpu-limit", 0, "CPU time limit per test process, e.g. 2m (default: none)")
+	flag.Parse()
+
+	if parallel < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -parallel must be at least 1")
+		os.Exit(1)
+	}
+	memory, err := sandbox.ParseSize(memoryLimit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -memory-limit: %v\n", err)
+		os.Exit(1)
+	}
+	if reportFormat != "text" && !slices.Contains(report.Formats, reportFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unknown -format %q (want text, json, or junit)\n", reportFormat)
+		os.Exit(1)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	// When running from scripts/parity-validator, go up two levels to project root
+	repoRoot := filepath.Join("..", "..")
+	if flag.NArg() > 0 {
+		repoRoot = flag.Arg(0)
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving repo root: %v\n", err)
+		os.Exit(1)
+	}
+
+	console.Banner("CI Starter/Solution Parity Validation (Go Edition)")
+
+	// Find exercises that ship a reference solution
+	fmt.Println(console.Dim.Render("🔍 Finding exercises..."))
+	discovered, err := exercise.Discover(absRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+		os.Exit(1)
+	}
+	exercises := make([]exercise.Exercise, 0, len(discovered))
+	for _, ex := range discovered {
+		if info, err := os.Stat(ex.SolutionDir()); err == nil && info.IsDir() {
+			exercises = append(exercises, ex)
+		}
+	}
+
+	fmt.Printf("\nChecking %s exercises in parallel (%d concurrent)...\n",
+		console.Title.Render(fmt.Sprintf("%d", len(exercises))), parallel)
+	if runTests {
+		fmt.Println(console.Dim.Render("Each exercise: compare exported API → compare test suites → run exercise tests against solution"))
+	} else {
+		fmt.Println(console.Dim.Render("Each exercise: compare exported API → compare test suites"))
+	}
+	fmt.Println()
+
+	startTime := time.Now()
+	tester := NewParityTester(verbose)
+	tester.timeout = testTimeout
+	tester.limits = sandbox.Limits{Memory: memory, CPU: cpuLimit}
+
+	// Results are printed in discovery order as soon as they are available
+	allResults := exercise.RunParallel(exercises, parallel, tester.testExercise, tester.printResult)
+
+	elapsed := time.Since(startTime)
+
+	// Print summary
+	var summary console.Summary
+	for _, result := range allResults {
+		summary.Add(result.Result)
+	}
+	summary.Print(elapsed)
+	summary.WriteOutput(outputFile, "Exercises")
+
+	// Write machine-readable report if requested
+	if err := console.WriteReport(buildReport(allResults, elapsed), reportFormat, reportFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(summary.Failed) == 0 {
+		fmt.Println(console.Success.Render("✅ All parity validations passed"))
+		os.Exit(0)
+	} else {
+		fmt.Println(console.Fail.Render("❌ Some parity validations failed"))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"exercise/progress"
+)
+
+// Command-line flags
+var (
+	verbose     bool
+	noColor     bool
+	noRun       bool
+	parallel    int
+	historyFile string
+)
+
+// TestResult is the outcome of running the student's tests for one exercise.
+type TestResult struct {
+	Exercise exercise.Exercise
+	Attempt  progress.Attempt
+}
+
+// testExercise runs the tests of the student's code, leaving out the
+// reference solution.
+func testExercise(ex exercise.Exercise) TestResult {
+	run, err := progress.Test(ex)
+	return TestResult{
+		Exercise: ex,
+		Attempt:  progress.NewAttempt(time.Now(), run, err),
+	}
+}
+
+// stars renders a difficulty as five stars, like the exercise READMEs.
+func stars(difficulty int) string {
+	return strings.Repeat("★", difficulty) + strings.Repeat("☆", 5-difficulty)
+}
+
+// bar renders a completion bar of the given width.
+func bar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return console.Success.Render(strings.Repeat("█", filled)) + console.Dim.Render(strings.Repeat("░", width-filled))
+}
+
+// formatMinutes renders an estimated time such as "1h30m" or "45m".
+func formatMinutes(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
+
+// printExercise shows the recorded state of one exercise.
+func printExercise(ex exercise.Exercise, history *progress.History) {
+	eh := history.Exercises[ex.Path]
+	last, ok := eh.Last()
+	switch {
+	case !ok:
+		fmt.Printf("  %s %s\n", console.Dim.Render("·"), console.Dim.Render(ex.Path))
+	case last.Status == "pass":
+		fmt.Printf("  %s %s\n", console.Success.Render("✅"), ex.Path)
+	default:
+		fmt.Printf("  %s %s %s\n", console.Fail.Render("❌"), ex.Path,
+			console.Dim.Render(fmt.Sprintf("(%d/%d tests, %d runs)", last.Passed, last.Total, eh.Runs)))
+	}
+}
+
+// printSummary renders per-category completion, streaks and the next exercise.
+func printSummary(exercises []exercise.Exercise, history *progress.History) {
+	console.Heading("PROGRESS")
+
+	completed := 0
+	for _, category := range exercise.Categories {
+		done, total := 0, 0
+		for _, ex := range exercises {
+			if ex.Category != category {
+				continue
+			}
+			total++
+			if history.Completed(ex.Path) {
+				done++
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		completed += done
+
+		percent := done * 100 / total
+		fmt.Printf("%-14s %s %3d/%-3d %3d%%\n", category, bar(done, total, 20), done, total, percent)
+		if verbose {
+			for _, ex := range exercises {
+				if ex.Category == category {
+					printExercise(ex, history)
+				}
+			}
+			fmt.Println()
+		}
+	}
+
+	percent := 0
+	if len(exercises) > 0 {
+		percent = completed * 100 / len(exercises)
+	}
+	fmt.Println()
+	fmt.Printf("Completed: %s\n", console.Success.Render(fmt.Sprintf("%d/%d (%d%%)", completed, len(exercises), percent)))
+
+	current, longest := history.Streak(time.Now())
+	fmt.Printf("Streak:    %s %s\n", console.Warn.Render(fmt.Sprintf("%d days", current)),
+		console.Dim.Render(fmt.Sprintf("(longest %d)", longest)))
+	fmt.Println()
+
+	next, ok := history.Next(exercises)
+	if !ok {
+		fmt.Println(console.Success.Render("🎉 All exercises completed!"))
+		return
+	}
+	fmt.Printf("Next:      %s - %s\n", console.Title.Render(next.Path), next.Title)
+	fmt.Printf("           %s", stars(next.Difficulty))
+	if next.EstimatedTime.Duration > 0 {
+		fmt.Printf(" %s", console.Dim.Render("~"+formatMinutes(next.EstimatedTime.Duration)))
+	}
+	fmt.Println()
+	fmt.Println(console.Dim.Render("           cd " + next.Path + " && cat README.md"))
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "List every exercise with its status")
+	flag.BoolVar(&verbose, "verbose", false, "List every exercise with its status")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.BoolVar(&noRun, "no-run", false, "Show recorded progress without running tests")
+	flag.IntVar(&parallel, "parallel", 10, "Number of exercises to test concurrently")
+	flag.StringVar(&historyFile, "history", "", "History file (default: "+progress.DefaultFile+" in the repo root)")
+	flag.Parse()
+
+	if parallel < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -parallel must be at least 1")
+		os.Exit(1)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	// When running from scripts/progress, go up two levels to project root
+	repoRoot := filepath.Join("..", "..")
+	if flag.NArg() > 0 {
+		repoRoot = flag.Arg(0)
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving repo root: %v\n", err)
+		os.Exit(1)
+	}
+	if historyFile == "" {
+		historyFile = filepath.Join(absRoot, progress.DefaultFile)
+	}
+
+	exercises, err := exercise.Discover(absRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+		os.Exit(1)
+	}
+
+	history, err := progress.Load(historyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	console.Banner("Go Training Progress")
+
+	if !noRun {
+		newlyCompleted := make([]string, 0)
+		done := 0
+
+		exercise.RunParallel(exercises, parallel, testExercise, func(result TestResult) {
+			done++
+			fmt.Printf("\r%s Running tests... %d/%d exercises", console.Dim.Render("🔄"), done, len(exercises))
+
+			wasCompleted := history.Completed(result.Exercise.Path)
+			history.Record(result.Exercise.Path, result.Attempt)
+			if !wasCompleted && history.Completed(result.Exercise.Path) {
+				newlyCompleted = append(newlyCompleted, result.Exercise.Path)
+			}
+		})
+		fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
+
+		if err := history.Save(historyFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving history: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, path := range newlyCompleted {
+			fmt.Println(console.Success.Render("🎉 Completed " + path))
+		}
+		if len(newlyCompleted) > 0 {
+			fmt.Println()
+		}
+	}
+
+	printSummary(exercises, history)
+	fmt.Println()
+}
@@ -1,98 +1,99 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
+	"slices"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/charmbracelet/lipgloss"
-)
-
-var (
-	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-	failStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
-	warnStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
-	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	titleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+	"exercise"
+	"exercise/console"
+	"exercise/coverage"
+	"exercise/gotest"
+	"exercise/modcache"
+	"exercise/report"
+	"exercise/sandbox"
 )
 
 // Command-line flags
 var (
-	verbose      bool
-	outputFile   string
-	noColor      bool
-	showProgress bool
-	showCoverage bool
+	verbose         bool
+	outputFile      string
+	noColor         bool
+	showProgress    bool
+	parallel        int
+	reportFormat    string
+	reportFile      string
+	collectCoverage bool
+	minCoverage     string
+	coverageReport  string
+	testTimeout     time.Duration
+	memoryLimit     string
+	cpuLimit        time.Duration
+	offline         bool
+	modCache        string
 )
 
+// buildGrace is the time go test may spend building a solution before the
+// tests' own -timeout starts counting.
+const buildGrace = 60 * time.Second
+
 type TestResult struct {
-	Exercise string
-	Status   string // "pass", "fail", "warn"
-	Message  string
-	Logs     []string
-	Duration time.Duration
+	console.Result
+	Tests        []gotest.TestCase // per-test results from go test -json
+	RaceDetected bool
+	Coverage     *coverage.ExerciseCoverage // nil unless -coverage is set
+
+	MissingModules []string // modules absent from the cache in offline mode
 }
 
 type SolutionTester struct {
 	repoRoot string
-	printMu  sync.Mutex
-	verbose  bool
+	printer  console.Printer
+
+	coverage   bool                // collect a coverage profile per solution
+	thresholds coverage.Thresholds // minimum coverage per category
+
+	timeout time.Duration  // test timeout for exercises whose manifest sets none
+	limits  sandbox.Limits // memory and CPU limits for every test process
+
+	offline  bool   // use only vendored modules and the module cache
+	modCache string // module cache checked in offline mode
 }
 
 func NewSolutionTester(repoRoot string, verbose bool) *SolutionTester {
 	return &SolutionTester{
 		repoRoot: repoRoot,
-		verbose:  verbose,
+		printer:  console.Printer{Verbose: verbose},
 	}
 }
 
 func (st *SolutionTester) printResult(result TestResult) {
-	st.printMu.Lock()
-	defer st.printMu.Unlock()
-
-	if result.Status == "pass" {
-		// Success: single line (unless verbose)
-		if st.verbose {
-			for _, log := range result.Logs {
-				fmt.Println(log)
-			}
-		}
-		fmt.Println(successStyle.Render("✅") + " " + result.Exercise)
-		if st.verbose {
-			fmt.Println()
-		}
-	} else {
-		// Failure/Warning: show all logs
-		for _, log := range result.Logs {
-			fmt.Println(log)
-		}
-		if result.Status == "fail" {
-			fmt.Println(failStyle.Render(fmt.Sprintf("  ❌ %s: %s", result.Exercise, result.Message)))
-		} else if result.Status == "warn" {
-			fmt.Println(warnStyle.Render(fmt.Sprintf("  ⚠️  %s: %s", result.Exercise, result.Message)))
-		}
-		fmt.Println()
+	note := ""
+	if result.Coverage != nil {
+		note = fmt.Sprintf("(%.1f%% coverage)", result.Coverage.Total.Percent())
 	}
+	st.printer.Print(result.Result, note)
 }
 
-func (st *SolutionTester) testSolution(exercise string) TestResult {
+func (st *SolutionTester) testSolution(ex exercise.Exercise) TestResult {
 	start := time.Now()
-	result := TestResult{
-		Exercise: exercise,
+	result := TestResult{Result: console.Result{
+		Exercise: ex.Path,
 		Logs:     make([]string, 0),
-	}
+	}}
 
 	// Solution is in exercise/solution/
-	solutionPath := filepath.Join(st.repoRoot, exercise, "solution")
+	solutionPath := ex.SolutionDir()
 
 	// Log progress
-	result.Logs = append(result.Logs, fmt.Sprintf("🔄 Testing %s/solution...", exercise))
+	result.Logs = append(result.Logs, fmt.Sprintf("🔄 Testing %s/solution...", ex.Path))
 
 	// Check solution directory exists
 	if _, err := os.Stat(solutionPath); os.IsNotExist(err) {
@@ -103,15 +104,6 @@ func (st *SolutionTester) testSolution(exercise string) TestResult {
 		return result
 	}
 
-	// Check go.mod exists
-	if _, err := os.Stat(filepath.Join(solutionPath, "go.mod")); os.IsNotExist(err) {
-		result.Status = "fail"
-		result.Message = "Missing go.mod in solution"
-		result.Logs = append(result.Logs, "  ❌ Missing go.mod")
-		result.Duration = time.Since(start)
-		return result
-	}
-
 	// Check EXPLANATION.md exists
 	if _, err := os.Stat(filepath.Join(solutionPath, "EXPLANATION.md")); os.IsNotExist(err) {
 		result.Status = "warn"
@@ -120,33 +112,55 @@ func (st *SolutionTester) testSolution(exercise string) TestResult {
 		// Continue validation even if EXPLANATION.md is missing
 	}
 
-	// Download dependencies
-	result.Logs = append(result.Logs, "  📥 Downloading dependencies...")
-	cmd := exec.Command("go", "mod", "download")
-	cmd.Dir = solutionPath
-	if err := cmd.Run(); err != nil {
-		result.Status = "fail"
-		result.Message = "Dependency download failed"
-		result.Logs = append(result.Logs, "  ❌ Dependency download failed")
+	// Solutions inside the exercise module are built and tested against the
+	// exercise by the other validators; here they are only covered
+	if !ex.HasSolutionModule() {
+		if result.Status == "" {
+			result.Status = "pass"
+		}
+		st.checkCoverage(&result, ex)
 		result.Duration = time.Since(start)
 		return result
 	}
 
-	// Verify dependencies
-	result.Logs = append(result.Logs, "  🔍 Verifying dependencies...")
-	cmd = exec.Command("go", "mod", "verify")
-	cmd.Dir = solutionPath
-	if err := cmd.Run(); err != nil {
-		result.Status = "fail"
-		result.Message = "Dependency verification failed"
-		result.Logs = append(result.Logs, "  ❌ Dependency verification failed")
-		result.Duration = time.Since(start)
-		return result
+	if st.offline {
+		// Dependencies must already be vendored or in the module cache
+		var ok bool
+		if result.MissingModules, ok = console.CheckModules(&result.Result, solutionPath, st.modCache); !ok {
+			result.Duration = time.Since(start)
+			return result
+		}
+	} else {
+		// Download dependencies
+		result.Logs = append(result.Logs, "  📥 Downloading dependencies...")
+		cmd := exec.Command("go", "mod", "download")
+		cmd.Dir = solutionPath
+		if err := cmd.Run(); err != nil {
+			result.Status = "fail"
+			result.Message = "Dependency download failed"
+			result.Logs = append(result.Logs, "  ❌ Dependency download failed")
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	// Verify dependencies (vendored ones are checked by go build itself)
+	if !st.offline || !modcache.Vendored(solutionPath) {
+		result.Logs = append(result.Logs, "  🔍 Verifying dependencies...")
+		cmd := exec.Command("go", "mod", "verify")
+		cmd.Dir = solutionPath
+		if err := cmd.Run(); err != nil {
+			result.Status = "fail"
+			result.Message = "Dependency verification failed"
+			result.Logs = append(result.Logs, "  ❌ Dependency verification failed")
+			result.Duration = time.Since(start)
+			return result
+		}
 	}
 
 	// Compile solution code
 	result.Logs = append(result.Logs, "  🔨 Compiling solution code...")
-	cmd = exec.Command("go", "build", "-v", "./...")
+	cmd := exec.Command("go", "build", "-v", "./...")
 	cmd.Dir = solutionPath
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -162,150 +176,257 @@ func (st *SolutionTester) testSolution(exercise string) TestResult {
 		return result
 	}
 
-	// Determine if we need race detection (concurrency exercises)
-	isConcurrencyExercise := strings.Contains(exercise, "concurrency")
-	testArgs := []string{"test", "-v"}
+	// Race detection is declared by the exercise manifest
+	isConcurrencyExercise := ex.Race
+	timeout := ex.TestTimeout(st.timeout)
+	testArgs := []string{"-timeout", timeout.String()}
 	if isConcurrencyExercise {
 		testArgs = append(testArgs, "-race")
 	}
 	testArgs = append(testArgs, "./...")
 
-	// Run tests with timeout (with race detection for concurrency if needed)
+	// Run tests with the manifest's timeout (with race detection for
+	// concurrency if needed). go test stops hanging tests itself; the whole
+	// process group is killed if even that does not happen
 	if isConcurrencyExercise {
-		result.Logs = append(result.Logs, "  🧪 Running tests with race detector (30s timeout)...")
+		result.Logs = append(result.Logs, fmt.Sprintf("  🧪 Running tests with race detector (%s timeout)...", timeout))
 	} else {
-		result.Logs = append(result.Logs, "  🧪 Running tests (30s timeout)...")
+		result.Logs = append(result.Logs, fmt.Sprintf("  🧪 Running tests (%s timeout)...", timeout))
 	}
-
-	cmd = exec.Command("go", testArgs...)
-	cmd.Dir = solutionPath
-
-	// Set timeout
-	type testOutput struct {
-		output []byte
-		err    error
-	}
-	done := make(chan testOutput, 1)
-	go func() {
-		output, err := cmd.CombinedOutput()
-		// If test output shows race condition, mark as error
-		if err == nil && isConcurrencyExercise && strings.Contains(string(output), "race detected") {
-			done <- testOutput{output, fmt.Errorf("race condition detected")}
-		} else {
-			done <- testOutput{output, err}
-		}
-	}()
-
-	select {
-	case testOut := <-done:
-		if st.verbose && len(testOut.output) > 0 {
-			result.Logs = append(result.Logs, "")
-			result.Logs = append(result.Logs, "Test output:")
-			result.Logs = append(result.Logs, string(testOut.output))
-		}
-		if testOut.err == nil {
-			// Tests passed - required for solution
-			result.Status = "pass"
-			result.Message = ""
-		} else {
-			// Tests failed - this is a failure for solution
-			result.Status = "fail"
-			result.Message = "Tests failed"
-			if strings.Contains(testOut.err.Error(), "race") {
-				result.Message = "Race condition detected"
-			}
-			result.Logs = append(result.Logs, fmt.Sprintf("  ❌ %s", result.Message))
-			// Show test output on failure even if not verbose
-			if !st.verbose && len(testOut.output) > 0 {
-				result.Logs = append(result.Logs, "")
-				result.Logs = append(result.Logs, "Test output:")
-				result.Logs = append(result.Logs, string(testOut.output))
-			}
-		}
-	case <-time.After(30 * time.Second):
-		cmd.Process.Kill()
+	limits := st.limits
+	limits.Timeout = timeout + buildGrace
+	run, err := gotest.TestWithLimits(solutionPath, limits, testArgs...)
+
+	// Per-test results come from the JSON event stream
+	result.Tests = run.Tests
+	// If test output shows race condition, mark as error
+	result.RaceDetected = run.RaceDetected
+	if st.printer.Verbose && len(run.Output) > 0 {
+		result.Logs = append(result.Logs, "")
+		result.Logs = append(result.Logs, "Test output:")
+		result.Logs = append(result.Logs, run.Output)
+	}
+	switch {
+	case errors.Is(err, sandbox.ErrTimeout) || run.TimedOut:
 		result.Status = "fail"
-		result.Message = "Tests timed out after 30s"
+		result.Message = console.TimeoutMessage(run, timeout)
 		result.Logs = append(result.Logs, "  ❌ Tests timed out (likely hanging/infinite loop)")
+	case err == nil && !result.RaceDetected:
+		// Tests passed - required for solution
+		result.Status = "pass"
+		result.Message = ""
+		if st.coverage {
+			st.checkCoverage(&result, ex)
+		}
+	default:
+		// Tests failed - this is a failure for solution
+		result.Status = "fail"
+		result.Message = failureMessage(run)
+		result.Logs = append(result.Logs, fmt.Sprintf("  ❌ %s", result.Message))
+		// Show test output on failure even if not verbose
+		if !st.printer.Verbose {
+			result.Logs = append(result.Logs, failureLogs(run)...)
+		}
 	}
 
 	result.Duration = time.Since(start)
 	return result
 }
 
-func findSolutions(repoRoot string) ([]string, error) {
-	solutions := make([]string, 0)
+// checkCoverage runs the exercise's tests against the solution, staged into a
+// copy of the exercise, with a coverage profile, and fails the result when
+// the coverage is below the minimum for the exercise's category. Exercises
+// without tests are not covered.
+func (st *SolutionTester) checkCoverage(result *TestResult, ex exercise.Exercise) {
+	if ex.StarterExpect == "no-tests" {
+		result.Logs = append(result.Logs, "  📊 Coverage: no exercise tests to measure it with")
+		return
+	}
+
+	tmpDir, err := os.MkdirTemp("", "solution-validator")
+	if err != nil {
+		result.Status = "fail"
+		result.Message = "Staging failed"
+		result.Logs = append(result.Logs, "  ❌ Staging failed: "+err.Error())
+		return
+	}
+	defer os.RemoveAll(tmpDir)
 
-	// Expected top-level directories for exercises
-	validPrefixes := []string{"basics", "intermediate", "advanced", "concurrency", "projects"}
+	staged := filepath.Join(tmpDir, filepath.Base(ex.Dir))
+	if err := ex.StageSolution(staged); err != nil {
+		result.Status = "fail"
+		result.Message = "Staging failed"
+		result.Logs = append(result.Logs, "  ❌ Staging failed: "+err.Error())
+		return
+	}
 
-	err := filepath.Walk(repoRoot, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	// Cover every package, including those only exercised by tests in other
+	// packages
+	profilePath := filepath.Join(tmpDir, "coverage.out")
+	timeout := ex.TestTimeout(st.timeout)
+	limits := st.limits
+	limits.Timeout = timeout + buildGrace
+	result.Logs = append(result.Logs, "  📊 Measuring coverage of the exercise tests...")
+	run, err := gotest.TestWithLimits(staged, limits, "-timeout", timeout.String(),
+		"-coverprofile="+profilePath, "-coverpkg=./...", "./...")
+	if err != nil {
+		// The parity validator reports solutions failing the exercise tests
+		if result.Status != "fail" {
+			result.Status = "warn"
+			result.Message = "Coverage not measured: " + failureMessage(run) + " (see parity-validator -run-tests)"
 		}
+		result.Logs = append(result.Logs, "  ⚠️  Coverage not measured, the exercise tests fail against the solution")
+		return
+	}
 
-		// Skip unwanted directories
-		if info.IsDir() {
-			name := info.Name()
-			relPath, _ := filepath.Rel(repoRoot, path)
+	cov, err := readCoverage(ex, staged, profilePath)
+	if err != nil {
+		result.Status = "fail"
+		result.Message = "Coverage profile unreadable"
+		result.Logs = append(result.Logs, fmt.Sprintf("  ❌ Coverage profile unreadable: %v", err))
+		return
+	}
+	if minimum, ok := st.thresholds.For(ex.Category); ok {
+		cov.Minimum = minimum
+	}
+	result.Coverage = cov
 
-			// Skip backup, vendor, hidden dirs, scripts, bin, etc.
-			if strings.Contains(name, "backup") || name == "vendor" ||
-				name == ".git" || strings.HasPrefix(name, ".") ||
-				name == "bin" || name == "scripts" || name == "claudedocs" {
-				return filepath.SkipDir
-			}
+	percent := cov.Total.Percent()
+	result.Logs = append(result.Logs, fmt.Sprintf("  📊 Coverage: %.1f%% (%d/%d statements)", percent, cov.Total.Covered, cov.Total.Statements))
+	if !cov.BelowMinimum() {
+		return
+	}
 
-			// Skip if not under a valid prefix
-			if relPath != "." {
-				parts := strings.Split(relPath, string(filepath.Separator))
-				if len(parts) > 0 {
-					isValid := false
-					for _, prefix := range validPrefixes {
-						if parts[0] == prefix {
-							isValid = true
-							break
-						}
-					}
-					if !isValid {
-						return filepath.SkipDir
-					}
-				}
-			}
+	result.Status = "fail"
+	result.Message = fmt.Sprintf("Coverage %.1f%% below %.0f%% minimum for %s", percent, cov.Minimum, ex.Category)
+	result.Logs = append(result.Logs, fmt.Sprintf("  ❌ %s", result.Message))
+	for _, f := range cov.Files {
+		if f.Percent() < cov.Minimum {
+			result.Logs = append(result.Logs, fmt.Sprintf("     %s: %.1f%%", f.Name, f.Percent()))
 		}
+	}
+}
 
-		if info.Name() == "go.mod" {
-			relPath, err := filepath.Rel(repoRoot, filepath.Dir(path))
-			if err != nil {
-				return err
-			}
+// readCoverage summarizes the coverage profile of a solution staged in dir.
+// Code in func main is left out: solutions use it for demos that tests are
+// not meant to run.
+func readCoverage(ex exercise.Exercise, dir, profilePath string) (*coverage.ExerciseCoverage, error) {
+	f, err := os.Open(profilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
 
-			// Only include SOLUTION directories (opposite of starter validator)
-			if !strings.Contains(relPath, "solution") {
-				return nil
-			}
+	profile, err := coverage.ParseProfile(f)
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.ExcludeMain(dir); err != nil {
+		return nil, err
+	}
+	return &coverage.ExerciseCoverage{
+		Path:     ex.Path,
+		Category: ex.Category,
+		Total:    profile.Total(),
+		Files:    profile.ByFile(),
+	}, nil
+}
 
-			// Extract exercise path (remove /solution suffix)
-			exercisePath := filepath.Dir(relPath)
-			if filepath.Base(exercisePath) == "solution" {
-				exercisePath = filepath.Dir(exercisePath)
-			}
+// coverageResults collects the coverage of the solutions whose tests passed.
+func coverageResults(results []TestResult) []coverage.ExerciseCoverage {
+	var exercises []coverage.ExerciseCoverage
+	for _, result := range results {
+		if result.Coverage != nil {
+			exercises = append(exercises, *result.Coverage)
+		}
+	}
+	return exercises
+}
 
-			// Only include paths with numbers (exercise directories)
-			parts := strings.Split(exercisePath, string(filepath.Separator))
-			for _, part := range parts {
-				if len(part) > 0 && part[0] >= '0' && part[0] <= '9' {
-					solutions = append(solutions, exercisePath)
-					break
-				}
-			}
+// writeCoverageReport writes the coverage of all solutions as HTML when path
+// ends in .html, and as plain text otherwise.
+func writeCoverageReport(path string, exercises []coverage.ExerciseCoverage) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(path, ".html") {
+		err = coverage.WriteHTML(f, exercises)
+	} else {
+		err = coverage.WriteText(f, exercises)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// failureMessage summarizes why a test run failed, naming the tests involved.
+func failureMessage(run *gotest.Run) string {
+	if run.OutOfMemory {
+		// Checked first: the race detector cannot start without memory
+		return "Tests ran out of memory (see -memory-limit)"
+	}
+	if run.RaceDetected {
+		if racy := run.Racy(); len(racy) > 0 {
+			return "Race condition detected in " + console.Names(racy)
+		}
+		return "Race condition detected"
+	}
+
+	failed := run.Failed()
+	if len(failed) == 0 {
+		return "Tests failed"
+	}
+	names := make([]string, 0, len(failed))
+	for _, tc := range failed {
+		names = append(names, tc.Name)
+	}
+	return "Tests failed: " + console.Names(names)
+}
+
+// failureLogs shows the output of each failing test, or the whole output when
+// no test failed on its own (build errors, package-level panics).
+func failureLogs(run *gotest.Run) []string {
+	failed := run.Failed()
+	if len(failed) == 0 {
+		if run.Output == "" {
+			return nil
 		}
+		return []string{"", "Test output:", run.Output}
+	}
 
-		return nil
-	})
+	logs := make([]string, 0, 2*len(failed)+1)
+	logs = append(logs, "")
+	for _, tc := range failed {
+		logs = append(logs, fmt.Sprintf("--- FAIL: %s (%.2fs)", tc.Name, tc.Elapsed))
+		logs = append(logs, strings.TrimRight(tc.Output, "\n"))
+	}
+	return logs
+}
 
-	sort.Strings(solutions)
-	return solutions, err
+// buildReport converts results into a machine-readable report.
+func buildReport(results []TestResult, elapsed time.Duration) *report.Report {
+	exercises := make([]report.Exercise, 0, len(results))
+	for _, result := range results {
+		ex := report.Exercise{
+			Name:         result.Exercise,
+			Status:       result.Status,
+			Message:      result.Message,
+			Duration:     result.Duration.Seconds(),
+			RaceDetected: result.RaceDetected,
+			Tests:        result.Tests,
+
+			MissingModules: result.MissingModules,
+		}
+		if result.Coverage != nil {
+			percent := result.Coverage.Total.Percent()
+			ex.Coverage = &percent
+		}
+		exercises = append(exercises, ex)
+	}
+	return report.New("solution", elapsed, exercises)
 }
 
 func main() {
@@ -315,16 +436,45 @@ func main() {
 	flag.StringVar(&outputFile, "output", "", "Write failed exercises to file")
 	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
 	flag.BoolVar(&showProgress, "progress", true, "Show live progress updates")
-	flag.BoolVar(&showCoverage, "coverage", false, "Show test coverage percentages")
+	flag.IntVar(&parallel, "parallel", 10, "Number of exercises to test concurrently")
+	flag.StringVar(&reportFormat, "format", "text", "Report format: text, json, or junit")
+	flag.StringVar(&reportFile, "report", "", "Write the json/junit report to file (default: report.json or report.xml)")
+	flag.BoolVar(&collectCoverage, "coverage", false, "Collect test coverage of each solution")
+	flag.StringVar(&minCoverage, "min-coverage", "", "Minimum coverage, e.g. 70 or basics=80,*=60 (implies -coverage)")
+	flag.StringVar(&coverageReport, "coverage-report", "", "Write a coverage report for all solutions to file (.html or text; implies -coverage)")
+	flag.DurationVar(&testTimeout, "timeout", 30*time.Second, "Test timeout for exercises whose manifest sets none")
+	flag.StringVar(&memoryLimit, "memory-limit", "", "Address space limit per test process, e.g. 2GiB (default: none)")
+	flag.DurationVar(&cpuLimit, "cpu-limit", 0, "CPU time limit per test process, e.g. 2m (default: none)")
+	flag.BoolVar(&offline, "offline", false, "Use only vendored modules and the module cache (no network)")
+	flag.StringVar(&modCache, "modcache", "", "Module cache for -offline (default: <repo>/"+modcache.LocalDir+" if present, else GOMODCACHE)")
 	flag.Parse()
 
+	thresholds, err := coverage.ParseThresholds(minCoverage)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -min-coverage: %v\n", err)
+		os.Exit(1)
+	}
+	if minCoverage != "" || coverageReport != "" {
+		collectCoverage = true
+	}
+	memory, err := sandbox.ParseSize(memoryLimit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -memory-limit: %v\n", err)
+		os.Exit(1)
+	}
+
+	if parallel < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -parallel must be at least 1")
+		os.Exit(1)
+	}
+	if reportFormat != "text" && !slices.Contains(report.Formats, reportFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unknown -format %q (want text, json, or junit)\n", reportFormat)
+		os.Exit(1)
+	}
+
 	// Disable colors if requested
 	if noColor {
-		successStyle = lipgloss.NewStyle()
-		failStyle = lipgloss.NewStyle()
-		warnStyle = lipgloss.NewStyle()
-		dimStyle = lipgloss.NewStyle()
-		titleStyle = lipgloss.NewStyle()
+		console.DisableColor()
 	}
 
 	// When running from scripts/solution-validator, go up two levels to project root
@@ -339,35 +489,64 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Println()
-	fmt.Println(titleStyle.Render("═══════════════════════════════════════════════════════"))
-	fmt.Println(titleStyle.Render("  CI Solution Code Validation (Go Edition)"))
-	fmt.Println(titleStyle.Render("═══════════════════════════════════════════════════════"))
-	fmt.Println()
+	// Offline, every go command run below uses only the module cache
+	if offline {
+		if modCache == "" {
+			modCache, err = modcache.Find(absRoot)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error finding module cache: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		// The go command only accepts an absolute GOMODCACHE
+		if modCache, err = filepath.Abs(modCache); err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving module cache: %v\n", err)
+			os.Exit(1)
+		}
+		for _, kv := range modcache.Env(modCache) {
+			key, value, _ := strings.Cut(kv, "=")
+			os.Setenv(key, value)
+		}
+	}
+
+	console.Banner("CI Solution Code Validation (Go Edition)")
 
 	// Find solutions
-	fmt.Println(dimStyle.Render("🔍 Finding solutions..."))
-	solutions, err := findSolutions(absRoot)
+	fmt.Println(console.Dim.Render("🔍 Finding solutions..."))
+	exercises, err := exercise.Discover(absRoot)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error finding solutions: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("\nTesting %s solutions in parallel (10 concurrent)...\n",
-		titleStyle.Render(fmt.Sprintf("%d", len(solutions))))
-	fmt.Println(dimStyle.Render("Each solution: download deps → verify → compile → test (+ race check for concurrency)"))
+	// Only standalone solution modules can be built and tested on their own;
+	// coverage is measured for every solution
+	solutions := make([]exercise.Exercise, 0, len(exercises))
+	for _, ex := range exercises {
+		if ex.HasSolutionModule() || collectCoverage {
+			solutions = append(solutions, ex)
+		}
+	}
+
+	fmt.Printf("\nTesting %s solutions in parallel (%d concurrent)...\n",
+		console.Title.Render(fmt.Sprintf("%d", len(solutions))), parallel)
+	fmt.Println(console.Dim.Render("Each solution: download deps → verify → compile → test (+ race check for concurrency)"))
 	fmt.Println()
 
 	startTime := time.Now()
 
 	// Run tests concurrently
 	tester := NewSolutionTester(absRoot, verbose)
-	results := make(chan TestResult, len(solutions))
-	semaphore := make(chan struct{}, 10) // 10 concurrent workers
+	tester.coverage = collectCoverage
+	tester.thresholds = thresholds
+	tester.timeout = testTimeout
+	tester.limits = sandbox.Limits{Memory: memory, CPU: cpuLimit}
+	tester.offline = offline
+	tester.modCache = modCache
 
 	// Progress tracking
-	var completed, total int32 = 0, int32(len(solutions))
-	var progressMu sync.Mutex
+	var completed atomic.Int32
+	total := len(solutions)
 	stopProgress := make(chan bool)
 
 	// Start progress monitor if enabled
@@ -380,142 +559,65 @@ func main() {
 				case <-stopProgress:
 					return
 				case <-ticker.C:
-					progressMu.Lock()
-					current := completed
-					progressMu.Unlock()
-					fmt.Printf("\r%s Testing... %d/%d solutions completed",
-						dimStyle.Render("🔄"),
-						current,
-						total)
+					tester.printer.Progress(fmt.Sprintf("%s Testing... %d/%d solutions completed",
+						console.Dim.Render("🔄"),
+						completed.Load(),
+						total))
 				}
 			}
 		}()
 	}
 
-	var wg sync.WaitGroup
-	for _, solution := range solutions {
-		wg.Add(1)
-		go func(sol string) {
-			defer wg.Done()
-			semaphore <- struct{}{}        // Acquire
-			defer func() { <-semaphore }() // Release
-
-			result := tester.testSolution(sol)
-			results <- result
-
-			// Print result immediately (synchronized)
-			tester.printResult(result)
-
-			// Update progress
-			progressMu.Lock()
-			completed++
-			progressMu.Unlock()
-		}(solution)
-	}
-
-	// Wait for all tests to complete
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	// Collect results
-	allResults := make([]TestResult, 0, len(solutions))
-	for result := range results {
-		allResults = append(allResults, result)
-	}
+	// Results are printed in discovery order as soon as they are available,
+	// so output from concurrent solutions never interleaves
+	allResults := exercise.RunParallel(solutions, parallel, func(ex exercise.Exercise) TestResult {
+		result := tester.testSolution(ex)
+		completed.Add(1)
+		return result
+	}, tester.printResult)
 
 	// Stop progress monitor
 	if showProgress && !verbose {
 		stopProgress <- true
-		fmt.Print("\r" + strings.Repeat(" ", 80) + "\r") // Clear progress line
+		tester.printer.ClearProgress()
 	}
 
 	elapsed := time.Since(startTime)
 
 	// Print summary
-	fmt.Println()
-	fmt.Println(titleStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Println(titleStyle.Render("SUMMARY"))
-	fmt.Println(titleStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Println()
-
-	passed := 0
-	failed := 0
-	warned := 0
-	failedExercises := make([]TestResult, 0)
-	warnedExercises := make([]TestResult, 0)
-
+	var summary console.Summary
 	for _, result := range allResults {
-		switch result.Status {
-		case "pass":
-			passed++
-		case "fail":
-			failed++
-			failedExercises = append(failedExercises, result)
-		case "warn":
-			warned++
-			warnedExercises = append(warnedExercises, result)
-		}
+		summary.Add(result.Result)
 	}
-
-	// Show failures
-	if failed > 0 {
-		fmt.Println(failStyle.Render(fmt.Sprintf("❌ FAILED (%d):", failed)))
-		for _, result := range failedExercises {
-			fmt.Printf("  - %s - %s\n", result.Exercise, result.Message)
-		}
-		fmt.Println()
+	var extra []string
+	covered := coverageResults(allResults)
+	if len(covered) > 0 {
+		extra = append(extra, fmt.Sprintf("Coverage: %s", console.Dim.Render(fmt.Sprintf("%.1f%%", coverage.Summary(covered).Percent()))))
 	}
+	summary.Print(elapsed, extra...)
 
-	// Show warnings
-	if warned > 0 {
-		fmt.Println(warnStyle.Render(fmt.Sprintf("⚠️  WARNINGS (%d):", warned)))
-		for _, result := range warnedExercises {
-			fmt.Printf("  - %s - %s\n", result.Exercise, result.Message)
+	if coverageReport != "" {
+		if err := writeCoverageReport(coverageReport, covered); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing coverage report: %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("Coverage report written to: %s\n", coverageReport)
 		fmt.Println()
 	}
 
-	fmt.Printf("Total:    %d\n", len(solutions))
-	fmt.Printf("Passed:   %s\n", successStyle.Render(fmt.Sprintf("%d", passed)))
-	fmt.Printf("Failed:   %s\n", failStyle.Render(fmt.Sprintf("%d", failed)))
-	fmt.Printf("Warnings: %s\n", warnStyle.Render(fmt.Sprintf("%d", warned)))
-	fmt.Printf("Time:     %s\n", dimStyle.Render(elapsed.Round(time.Second).String()))
-	fmt.Println()
+	summary.WriteOutput(outputFile, "Solutions")
 
-	// Write output file if requested
-	if outputFile != "" && (failed > 0 || warned > 0) {
-		f, err := os.Create(outputFile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
-		} else {
-			defer f.Close()
-			if failed > 0 {
-				fmt.Fprintln(f, "# Failed Solutions")
-				for _, result := range failedExercises {
-					fmt.Fprintf(f, "%s - %s\n", result.Exercise, result.Message)
-				}
-			}
-			if warned > 0 {
-				if failed > 0 {
-					fmt.Fprintln(f, "")
-				}
-				fmt.Fprintln(f, "# Warned Solutions")
-				for _, result := range warnedExercises {
-					fmt.Fprintf(f, "%s - %s\n", result.Exercise, result.Message)
-				}
-			}
-			fmt.Printf("Output written to: %s\n", outputFile)
-			fmt.Println()
-		}
+	// Write machine-readable report if requested
+	if err := console.WriteReport(buildReport(allResults, elapsed), reportFormat, reportFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
 	}
 
-	if failed == 0 {
-		fmt.Println(successStyle.Render("✅ All solution validations passed"))
+	if len(summary.Failed) == 0 {
+		fmt.Println(console.Success.Render("✅ All solution validations passed"))
 		os.Exit(0)
 	} else {
-		fmt.Println(failStyle.Render("❌ Some solution validations failed"))
+		fmt.Println(console.Fail.Render("❌ Some solution validations failed"))
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"exercise"
+	"exercise/console"
+	"exercise/starter"
+)
+
+// Command-line flags
+var (
+	verbose bool
+	noColor bool
+	check   bool
+)
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "List every generated file, not only the changed ones")
+	flag.BoolVar(&verbose, "verbose", false, "List every generated file, not only the changed ones")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.BoolVar(&check, "check", false, "Report out-of-date starter files instead of writing them")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: starter-gen [flags] [repo-root]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Regenerates starter files from solution functions marked with %s or %s.\n\n",
+			starter.TodoDirective, starter.ZeroDirective)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	// When running from scripts/starter-gen, go up two levels to project root
+	repoRoot := filepath.Join("..", "..")
+	if flag.NArg() > 0 {
+		repoRoot = flag.Arg(0)
+	}
+
+	exercises, err := exercise.Discover(repoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(console.Title.Render("🧩 Generating starter code from marked solutions"))
+	fmt.Println()
+
+	generated, changed, failed := 0, 0, 0
+	for _, ex := range exercises {
+		if _, err := os.Stat(ex.SolutionDir()); err != nil {
+			continue
+		}
+		files, err := starter.Generate(ex.SolutionDir())
+		if err != nil {
+			fmt.Println(console.Fail.Render(fmt.Sprintf("❌ %s: %v", ex.Path, err)))
+			failed++
+			continue
+		}
+
+		for _, f := range files {
+			generated++
+			path := filepath.Join(ex.Dir, filepath.FromSlash(f.Path))
+			name := ex.Path + "/" + f.Path
+			current, err := os.ReadFile(path)
+			if err == nil && bytes.Equal(current, f.Data) {
+				if verbose {
+					fmt.Println(console.Dim.Render("   " + name + " is up to date"))
+				}
+				continue
+			}
+
+			changed++
+			if check {
+				fmt.Println(console.Fail.Render("❌ " + name + " is out of date"))
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				fmt.Println(console.Fail.Render(fmt.Sprintf("❌ %s: %v", name, err)))
+				failed++
+				continue
+			}
+			if err := os.WriteFile(path, f.Data, 0o644); err != nil {
+				fmt.Println(console.Fail.Render(fmt.Sprintf("❌ %s: %v", name, err)))
+				failed++
+				continue
+			}
+			fmt.Println(console.Success.Render("✅ " + name))
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case failed > 0:
+		fmt.Println(console.Fail.Render(fmt.Sprintf("%d errors", failed)))
+		os.Exit(1)
+	case check && changed > 0:
+		fmt.Println(console.Fail.Render(fmt.Sprintf("%d of %d generated starter files are out of date", changed, generated)))
+		fmt.Println(console.Dim.Render("Run: cd scripts/starter-gen && go run . ../../"))
+		os.Exit(1)
+	case check:
+		fmt.Println(console.Success.Render(fmt.Sprintf("All %d generated starter files are up to date", generated)))
+	default:
+		fmt.Println(console.Dim.Render(fmt.Sprintf("%d generated starter files, %d updated", generated, changed)))
+	}
+}
@@ -1,96 +1,86 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
+	"slices"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/charmbracelet/lipgloss"
-)
-
-var (
-	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-	failStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
-	warnStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
-	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	titleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+	"exercise"
+	"exercise/console"
+	"exercise/gotest"
+	"exercise/modcache"
+	"exercise/report"
+	"exercise/sandbox"
 )
 
 // Command-line flags
 var (
-	verbose    bool
-	outputFile string
-	noColor    bool
+	verbose      bool
+	outputFile   string
+	noColor      bool
 	showProgress bool
+	parallel     int
+	reportFormat string
+	reportFile   string
+	testTimeout  time.Duration
+	memoryLimit  string
+	cpuLimit     time.Duration
+	offline      bool
+	modCache     string
 )
 
+// buildGrace is the time go test may spend building an exercise before the
+// tests' own -timeout starts counting.
+const buildGrace = 60 * time.Second
+
 type TestResult struct {
-	Exercise string
-	Status   string // "pass", "fail", "warn"
-	Message  string
-	Logs     []string
-	Duration time.Duration
+	console.Result
+	Tests []gotest.TestCase // per-test results from go test -json
+
+	UnexpectedPasses []string // must-fail tests that passed against the starter
+	MissingModules   []string // modules absent from the cache in offline mode
 }
 
 type ExerciseTester struct {
 	repoRoot string
-	printMu  sync.Mutex
-	verbose  bool
+	printer  console.Printer
+
+	timeout time.Duration  // test timeout for exercises whose manifest sets none
+	limits  sandbox.Limits // memory and CPU limits for every test process
+
+	offline  bool   // use only vendored modules and the module cache
+	modCache string // module cache checked in offline mode
 }
 
 func NewExerciseTester(repoRoot string, verbose bool) *ExerciseTester {
 	return &ExerciseTester{
 		repoRoot: repoRoot,
-		verbose:  verbose,
+		printer:  console.Printer{Verbose: verbose},
 	}
 }
 
 func (et *ExerciseTester) printResult(result TestResult) {
-	et.printMu.Lock()
-	defer et.printMu.Unlock()
-
-	if result.Status == "pass" {
-		// Success: single line (unless verbose)
-		if et.verbose {
-			for _, log := range result.Logs {
-				fmt.Println(log)
-			}
-		}
-		fmt.Println(successStyle.Render("✅") + " " + result.Exercise)
-		if et.verbose {
-			fmt.Println()
-		}
-	} else {
-		// Failure/Warning: show all logs
-		for _, log := range result.Logs {
-			fmt.Println(log)
-		}
-		if result.Status == "fail" {
-			fmt.Println(failStyle.Render(fmt.Sprintf("  ❌ %s: %s", result.Exercise, result.Message)))
-		} else if result.Status == "warn" {
-			fmt.Println(warnStyle.Render(fmt.Sprintf("  ⚠️  %s: %s", result.Exercise, result.Message)))
-		}
-		fmt.Println()
-	}
+	et.printer.Print(result.Result, "")
 }
 
-func (et *ExerciseTester) testExercise(exercise string) TestResult {
+func (et *ExerciseTester) testExercise(ex exercise.Exercise) TestResult {
 	start := time.Now()
-	result := TestResult{
-		Exercise: exercise,
+	result := TestResult{Result: console.Result{
+		Exercise: ex.Path,
 		Logs:     make([]string, 0),
-	}
+	}}
 
-	exercisePath := filepath.Join(et.repoRoot, exercise)
+	exercisePath := ex.Dir
 
 	// Log progress (verbose, will be cleared on success)
-	result.Logs = append(result.Logs, fmt.Sprintf("🔄 Testing %s...", exercise))
+	result.Logs = append(result.Logs, fmt.Sprintf("🔄 Testing %s...", ex.Path))
 
 	// Check go.mod exists
 	if _, err := os.Stat(filepath.Join(exercisePath, "go.mod")); os.IsNotExist(err) {
@@ -101,33 +91,44 @@ func (et *ExerciseTester) testExercise(exercise string) TestResult {
 		return result
 	}
 
-	// Download dependencies
-	result.Logs = append(result.Logs, "  📥 Downloading dependencies...")
-	cmd := exec.Command("go", "mod", "download")
-	cmd.Dir = exercisePath
-	if err := cmd.Run(); err != nil {
-		result.Status = "fail"
-		result.Message = "Dependency download failed"
-		result.Logs = append(result.Logs, "  ❌ Dependency download failed")
-		result.Duration = time.Since(start)
-		return result
+	if et.offline {
+		// Dependencies must already be vendored or in the module cache
+		var ok bool
+		if result.MissingModules, ok = console.CheckModules(&result.Result, exercisePath, et.modCache); !ok {
+			result.Duration = time.Since(start)
+			return result
+		}
+	} else {
+		// Download dependencies
+		result.Logs = append(result.Logs, "  📥 Downloading dependencies...")
+		cmd := exec.Command("go", "mod", "download")
+		cmd.Dir = exercisePath
+		if err := cmd.Run(); err != nil {
+			result.Status = "fail"
+			result.Message = "Dependency download failed"
+			result.Logs = append(result.Logs, "  ❌ Dependency download failed")
+			result.Duration = time.Since(start)
+			return result
+		}
 	}
 
-	// Verify dependencies
-	result.Logs = append(result.Logs, "  🔍 Verifying dependencies...")
-	cmd = exec.Command("go", "mod", "verify")
-	cmd.Dir = exercisePath
-	if err := cmd.Run(); err != nil {
-		result.Status = "fail"
-		result.Message = "Dependency verification failed"
-		result.Logs = append(result.Logs, "  ❌ Dependency verification failed")
-		result.Duration = time.Since(start)
-		return result
+	// Verify dependencies (vendored ones are checked by go build itself)
+	if !et.offline || !modcache.Vendored(exercisePath) {
+		result.Logs = append(result.Logs, "  🔍 Verifying dependencies...")
+		cmd := exec.Command("go", "mod", "verify")
+		cmd.Dir = exercisePath
+		if err := cmd.Run(); err != nil {
+			result.Status = "fail"
+			result.Message = "Dependency verification failed"
+			result.Logs = append(result.Logs, "  ❌ Dependency verification failed")
+			result.Duration = time.Since(start)
+			return result
+		}
 	}
 
 	// Compile starter code
 	result.Logs = append(result.Logs, "  🔨 Compiling starter code...")
-	cmd = exec.Command("go", "build", "-v", "./...")
+	cmd := exec.Command("go", "build", "-v", "./...")
 	cmd.Dir = exercisePath
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -143,116 +144,133 @@ func (et *ExerciseTester) testExercise(exercise string) TestResult {
 		return result
 	}
 
-	// Run tests with timeout
-	result.Logs = append(result.Logs, "  🧪 Running tests (30s timeout)...")
-	cmd = exec.Command("go", "test", "-v", "./...")
-	cmd.Dir = exercisePath
+	// Only the student packages are tested: a solution package inside the
+	// exercise module would pass the tests the starter must fail
+	packages, err := ex.StudentPackages()
+	if err != nil {
+		result.Status = "fail"
+		result.Message = "Cannot list packages"
+		result.Logs = append(result.Logs, fmt.Sprintf("  ❌ Cannot list packages: %v", err))
+		result.Duration = time.Since(start)
+		return result
+	}
 
-	// Set timeout
-	type testOutput struct {
-		output []byte
-		err    error
+	// Run tests with the manifest's timeout. go test stops hanging tests
+	// itself; the whole process group is killed if even that does not happen
+	timeout := ex.TestTimeout(et.timeout)
+	result.Logs = append(result.Logs, fmt.Sprintf("  🧪 Running tests (%s timeout)...", timeout))
+	limits := et.limits
+	limits.Timeout = timeout + buildGrace
+	run, err := gotest.TestWithLimits(exercisePath, limits, append([]string{"-timeout", timeout.String()}, packages...)...)
+
+	// Per-test results come from the JSON event stream
+	result.Tests = run.Tests
+	if et.printer.Verbose && len(run.Output) > 0 {
+		result.Logs = append(result.Logs, "")
+		result.Logs = append(result.Logs, "Test output:")
+		result.Logs = append(result.Logs, run.Output)
 	}
-	done := make(chan testOutput, 1)
-	go func() {
-		output, err := cmd.CombinedOutput()
-		done <- testOutput{output, err}
-	}()
-
-	select {
-	case testOut := <-done:
-		if et.verbose && len(testOut.output) > 0 {
-			result.Logs = append(result.Logs, "")
-			result.Logs = append(result.Logs, "Test output:")
-			result.Logs = append(result.Logs, string(testOut.output))
-		}
-		if testOut.err == nil {
-			// Tests passed - this is good
-			result.Status = "pass"
-			result.Message = ""
-		} else {
-			// Tests failed - this is expected for starter code
-			result.Status = "pass"
-			result.Message = ""
-		}
-	case <-time.After(30 * time.Second):
-		cmd.Process.Kill()
+	switch {
+	case errors.Is(err, sandbox.ErrTimeout) || run.TimedOut:
 		result.Status = "warn"
-		result.Message = "Tests timed out after 30s"
+		result.Message = console.TimeoutMessage(run, timeout)
 		result.Logs = append(result.Logs, "  ⏱️  Tests timed out (likely hanging/infinite loop)")
+	case run.OutOfMemory:
+		result.Status = "warn"
+		result.Message = "Tests ran out of memory (see -memory-limit)"
+		result.Logs = append(result.Logs, "  ⚠️  Tests ran out of memory")
+	default:
+		et.checkStarterFails(&result, ex, run, err)
 	}
 
 	result.Duration = time.Since(start)
 	return result
 }
 
-func findExercises(repoRoot string) ([]string, error) {
-	exercises := make([]string, 0)
-
-	// Expected top-level directories for exercises
-	validPrefixes := []string{"basics", "intermediate", "advanced", "concurrency", "projects"}
-
-	err := filepath.Walk(repoRoot, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+// checkStarterFails sets the result status from the test run. Starter code must
+// fail every test listed in the manifest's starter_must_fail, or at least one
+// test when none are listed; passing them means a solution has leaked into
+// the student-facing files. A manifest's starter_expect accepts tests that do
+// not compile, or no tests at all, instead.
+func (et *ExerciseTester) checkStarterFails(result *TestResult, ex exercise.Exercise, run *gotest.Run, err error) {
+	total := run.Count("pass") + run.Count("fail") + run.Count("skip")
+	if total == 0 {
+		// The manifest may declare why no test runs against this starter
+		switch {
+		case ex.StarterExpect == "build-failure" && run.BuildFailed:
+			result.Status = "pass"
+			result.Logs = append(result.Logs, "  ✅ Tests do not compile until students define their types, as the manifest expects")
+			return
+		case ex.StarterExpect == "no-tests" && err == nil:
+			result.Status = "pass"
+			result.Logs = append(result.Logs, "  ✅ No starter tests, as the manifest declares")
+			return
 		}
-
-		// Skip unwanted directories
-		if info.IsDir() {
-			name := info.Name()
-			relPath, _ := filepath.Rel(repoRoot, path)
-
-			// Skip backup, vendor, hidden dirs, scripts, bin, etc.
-			if strings.Contains(name, "backup") || name == "vendor" ||
-				name == ".git" || strings.HasPrefix(name, ".") ||
-				name == "bin" || name == "scripts" || name == "claudedocs" {
-				return filepath.SkipDir
-			}
-
-			// Skip if not under a valid prefix
-			if relPath != "." {
-				parts := strings.Split(relPath, string(filepath.Separator))
-				if len(parts) > 0 {
-					isValid := false
-					for _, prefix := range validPrefixes {
-						if parts[0] == prefix {
-							isValid = true
-							break
-						}
-					}
-					if !isValid {
-						return filepath.SkipDir
-					}
-				}
+		if err != nil {
+			// Test files failed to build, or the package panicked before any test ran
+			result.Status = "fail"
+			result.Message = "Tests did not run"
+			result.Logs = append(result.Logs, "  ❌ Tests did not run")
+			if !et.printer.Verbose && len(run.Output) > 0 {
+				result.Logs = append(result.Logs, "", "Test output:", run.Output)
 			}
+			return
 		}
+		result.Status = "warn"
+		result.Message = "No tests found"
+		result.Logs = append(result.Logs, "  ⚠️  No tests found")
+		return
+	}
 
-		if info.Name() == "go.mod" {
-			relPath, err := filepath.Rel(repoRoot, filepath.Dir(path))
-			if err != nil {
-				return err
-			}
-
-			// Skip solution directories - they should pass all tests
-			if strings.Contains(relPath, "solution") {
-				return nil
+	if len(ex.StarterMustFail) > 0 {
+		result.UnexpectedPasses = run.Passed(ex.StarterMustFail)
+		if len(result.UnexpectedPasses) > 0 {
+			result.Status = "fail"
+			result.Message = "Starter passes tests it must fail: " + console.Names(result.UnexpectedPasses)
+			result.Logs = append(result.Logs, "  ❌ Tests unexpectedly passing:")
+			for _, name := range result.UnexpectedPasses {
+				result.Logs = append(result.Logs, "     - "+name)
 			}
-
-			// Only include paths with numbers (exercise directories)
-			parts := strings.Split(relPath, string(filepath.Separator))
-			for _, part := range parts {
-				if len(part) > 0 && part[0] >= '0' && part[0] <= '9' {
-					exercises = append(exercises, relPath)
-					break
-				}
+			return
+		}
+		// A name that matches no test, misspelt or renamed, checks nothing
+		if missing := run.Missing(ex.StarterMustFail); len(missing) > 0 {
+			result.Status = "fail"
+			result.Message = "starter_must_fail names tests that did not run: " + console.Names(missing)
+			result.Logs = append(result.Logs, "  ❌ starter_must_fail names tests that did not run:")
+			for _, name := range missing {
+				result.Logs = append(result.Logs, "     - "+name)
 			}
+			return
 		}
+	} else if err == nil && run.Count("fail") == 0 {
+		result.Status = "fail"
+		result.Message = "Starter passes all tests (solution leaked?)"
+		result.Logs = append(result.Logs, "  ❌ All tests pass against the starter code")
+		return
+	}
 
-		return nil
-	})
+	// Failing tests are expected for starter code
+	result.Status = "pass"
+	result.Message = ""
+}
 
-	sort.Strings(exercises)
-	return exercises, err
+// buildReport converts results into a machine-readable report.
+func buildReport(results []TestResult, elapsed time.Duration) *report.Report {
+	exercises := make([]report.Exercise, 0, len(results))
+	for _, result := range results {
+		exercises = append(exercises, report.Exercise{
+			Name:     result.Exercise,
+			Status:   result.Status,
+			Message:  result.Message,
+			Duration: result.Duration.Seconds(),
+			Tests:    result.Tests,
+
+			UnexpectedPasses: result.UnexpectedPasses,
+			MissingModules:   result.MissingModules,
+		})
+	}
+	return report.New("starter", elapsed, exercises)
 }
 
 func main() {
@@ -262,15 +280,33 @@ func main() {
 	flag.StringVar(&outputFile, "output", "", "Write failed exercises to file")
 	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
 	flag.BoolVar(&showProgress, "progress", true, "Show live progress updates")
+	flag.IntVar(&parallel, "parallel", 10, "Number of exercises to test concurrently")
+	flag.StringVar(&reportFormat, "format", "text", "Report format: text, json, or junit")
+	flag.StringVar(&reportFile, "report", "", "Write the json/junit report to file (default: report.json or report.xml)")
+	flag.DurationVar(&testTimeout, "timeout", 30*time.Second, "Test timeout for exercises whose manifest sets none")
+	flag.StringVar(&memoryLimit, "memory-limit", "", "Address space limit per test process, e.g. 2GiB (default: none)")
+	flag.DurationVar(&cpuLimit, "cpu-limit", 0, "CPU time limit per test process, e.g. 2m (default: none)")
+	flag.BoolVar(&offline, "offline", false, "Use only vendored modules and the module cache (no network)")
+	flag.StringVar(&modCache, "modcache", "", "Module cache for -offline (default: <repo>/"+modcache.LocalDir+" if present, else GOMODCACHE)")
 	flag.Parse()
 
+	if parallel < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -parallel must be at least 1")
+		os.Exit(1)
+	}
+	if reportFormat != "text" && !slices.Contains(report.Formats, reportFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unknown -format %q (want text, json, or junit)\n", reportFormat)
+		os.Exit(1)
+	}
+	memory, err := sandbox.ParseSize(memoryLimit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -memory-limit: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Disable colors if requested
 	if noColor {
-		successStyle = lipgloss.NewStyle()
-		failStyle = lipgloss.NewStyle()
-		warnStyle = lipgloss.NewStyle()
-		dimStyle = lipgloss.NewStyle()
-		titleStyle = lipgloss.NewStyle()
+		console.DisableColor()
 	}
 
 	// When running from scripts/starter-validator, go up two levels to project root
@@ -285,35 +321,53 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Println()
-	fmt.Println(titleStyle.Render("═══════════════════════════════════════════════════════"))
-	fmt.Println(titleStyle.Render("  CI Starter Code Validation (Go Edition)"))
-	fmt.Println(titleStyle.Render("═══════════════════════════════════════════════════════"))
-	fmt.Println()
+	// Offline, every go command run below uses only the module cache
+	if offline {
+		if modCache == "" {
+			modCache, err = modcache.Find(absRoot)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error finding module cache: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		// The go command only accepts an absolute GOMODCACHE
+		if modCache, err = filepath.Abs(modCache); err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving module cache: %v\n", err)
+			os.Exit(1)
+		}
+		for _, kv := range modcache.Env(modCache) {
+			key, value, _ := strings.Cut(kv, "=")
+			os.Setenv(key, value)
+		}
+	}
+
+	console.Banner("CI Starter Code Validation (Go Edition)")
 
 	// Find exercises
-	fmt.Println(dimStyle.Render("🔍 Finding exercises..."))
-	exercises, err := findExercises(absRoot)
+	fmt.Println(console.Dim.Render("🔍 Finding exercises..."))
+	exercises, err := exercise.Discover(absRoot)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("\nTesting %s exercises in parallel (10 concurrent)...\n",
-		titleStyle.Render(fmt.Sprintf("%d", len(exercises))))
-	fmt.Println(dimStyle.Render("Each exercise: download deps → verify → compile → test"))
+	fmt.Printf("\nTesting %s exercises in parallel (%d concurrent)...\n",
+		console.Title.Render(fmt.Sprintf("%d", len(exercises))), parallel)
+	fmt.Println(console.Dim.Render("Each exercise: download deps → verify → compile → test"))
 	fmt.Println()
 
 	startTime := time.Now()
 
 	// Run tests concurrently
 	tester := NewExerciseTester(absRoot, verbose)
-	results := make(chan TestResult, len(exercises))
-	semaphore := make(chan struct{}, 10) // 10 concurrent workers
+	tester.timeout = testTimeout
+	tester.limits = sandbox.Limits{Memory: memory, CPU: cpuLimit}
+	tester.offline = offline
+	tester.modCache = modCache
 
 	// Progress tracking
-	var completed, total int32 = 0, int32(len(exercises))
-	var progressMu sync.Mutex
+	var completed atomic.Int32
+	total := len(exercises)
 	stopProgress := make(chan bool)
 
 	// Start progress monitor if enabled
@@ -326,142 +380,50 @@ func main() {
 				case <-stopProgress:
 					return
 				case <-ticker.C:
-					progressMu.Lock()
-					current := completed
-					progressMu.Unlock()
-					fmt.Printf("\r%s Testing... %d/%d exercises completed",
-						dimStyle.Render("🔄"),
-						current,
-						total)
+					tester.printer.Progress(fmt.Sprintf("%s Testing... %d/%d exercises completed",
+						console.Dim.Render("🔄"),
+						completed.Load(),
+						total))
 				}
 			}
 		}()
 	}
 
-	var wg sync.WaitGroup
-	for _, exercise := range exercises {
-		wg.Add(1)
-		go func(ex string) {
-			defer wg.Done()
-			semaphore <- struct{}{}        // Acquire
-			defer func() { <-semaphore }() // Release
-
-			result := tester.testExercise(ex)
-			results <- result
-
-			// Print result immediately (synchronized)
-			tester.printResult(result)
-
-			// Update progress
-			progressMu.Lock()
-			completed++
-			progressMu.Unlock()
-		}(exercise)
-	}
-
-	// Wait for all tests to complete
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	// Collect results
-	allResults := make([]TestResult, 0, len(exercises))
-	for result := range results {
-		allResults = append(allResults, result)
-	}
+	// Results are printed in discovery order as soon as they are available,
+	// so output from concurrent exercises never interleaves
+	allResults := exercise.RunParallel(exercises, parallel, func(ex exercise.Exercise) TestResult {
+		result := tester.testExercise(ex)
+		completed.Add(1)
+		return result
+	}, tester.printResult)
 
 	// Stop progress monitor
 	if showProgress && !verbose {
 		stopProgress <- true
-		fmt.Print("\r" + strings.Repeat(" ", 80) + "\r") // Clear progress line
+		tester.printer.ClearProgress()
 	}
 
 	elapsed := time.Since(startTime)
 
 	// Print summary
-	fmt.Println()
-	fmt.Println(titleStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Println(titleStyle.Render("SUMMARY"))
-	fmt.Println(titleStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Println()
-
-	passed := 0
-	failed := 0
-	warned := 0
-	failedExercises := make([]TestResult, 0)
-	warnedExercises := make([]TestResult, 0)
-
+	var summary console.Summary
 	for _, result := range allResults {
-		switch result.Status {
-		case "pass":
-			passed++
-		case "fail":
-			failed++
-			failedExercises = append(failedExercises, result)
-		case "warn":
-			warned++
-			warnedExercises = append(warnedExercises, result)
-		}
-	}
-
-	// Show failures
-	if failed > 0 {
-		fmt.Println(failStyle.Render(fmt.Sprintf("❌ FAILED (%d):", failed)))
-		for _, result := range failedExercises {
-			fmt.Printf("  - %s - %s\n", result.Exercise, result.Message)
-		}
-		fmt.Println()
+		summary.Add(result.Result)
 	}
+	summary.Print(elapsed)
+	summary.WriteOutput(outputFile, "Exercises")
 
-	// Show warnings
-	if warned > 0 {
-		fmt.Println(warnStyle.Render(fmt.Sprintf("⚠️  WARNINGS (%d):", warned)))
-		for _, result := range warnedExercises {
-			fmt.Printf("  - %s - %s\n", result.Exercise, result.Message)
-		}
-		fmt.Println()
-	}
-
-	fmt.Printf("Total:    %d\n", len(exercises))
-	fmt.Printf("Passed:   %s\n", successStyle.Render(fmt.Sprintf("%d", passed)))
-	fmt.Printf("Failed:   %s\n", failStyle.Render(fmt.Sprintf("%d", failed)))
-	fmt.Printf("Warnings: %s\n", warnStyle.Render(fmt.Sprintf("%d", warned)))
-	fmt.Printf("Time:     %s\n", dimStyle.Render(elapsed.Round(time.Second).String()))
-	fmt.Println()
-
-	// Write output file if requested
-	if outputFile != "" && (failed > 0 || warned > 0) {
-		f, err := os.Create(outputFile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
-		} else {
-			defer f.Close()
-			if failed > 0 {
-				fmt.Fprintln(f, "# Failed Exercises")
-				for _, result := range failedExercises {
-					fmt.Fprintf(f, "%s - %s\n", result.Exercise, result.Message)
-				}
-			}
-			if warned > 0 {
-				if failed > 0 {
-					fmt.Fprintln(f, "")
-				}
-				fmt.Fprintln(f, "# Warned Exercises")
-				for _, result := range warnedExercises {
-					fmt.Fprintf(f, "%s - %s\n", result.Exercise, result.Message)
-				}
-			}
-			fmt.Printf("Output written to: %s\n", outputFile)
-			fmt.Println()
-		}
+	// Write machine-readable report if requested
+	if err := console.WriteReport(buildReport(allResults, elapsed), reportFormat, reportFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
 	}
 
-	if failed == 0 {
-		fmt.Println(successStyle.Render("✅ All starter code validations passed"))
+	if len(summary.Failed) == 0 {
+		fmt.Println(console.Success.Render("✅ All starter code validations passed"))
 		os.Exit(0)
 	} else {
-		fmt.Println(failStyle.Render("❌ Some starter validations failed"))
+		fmt.Println(console.Fail.Render("❌ Some starter validations failed"))
 		os.Exit(1)
 	}
 }
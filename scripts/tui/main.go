@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"exercise/progress"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var selectedStyle = lipgloss.NewStyle().Reverse(true)
+
+// Command-line flags
+var (
+	noColor     bool
+	unlockRuns  int
+	unlockWait  time.Duration
+	repoRoot    string
+	historyFile string
+)
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.IntVar(&unlockRuns, "runs", 3, "Test runs after a hint that unlock the next level")
+	flag.DurationVar(&unlockWait, "wait", 10*time.Minute, "Time after a hint that unlocks the next level")
+	flag.StringVar(&repoRoot, "root", filepath.Join("..", ".."), "Repository root")
+	flag.StringVar(&historyFile, "history", "", "Progress history file (default: "+progress.DefaultFile+" in the repo root)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: tui [flags]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Browses the exercises with their README and hints, and runs their tests.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if unlockRuns < 0 || unlockWait < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -runs and -wait must not be negative")
+		os.Exit(1)
+	}
+
+	// Disable colors if requested; the selection stays visible in reverse
+	if noColor {
+		console.DisableColor()
+		codeStyle = lipgloss.NewStyle()
+	}
+
+	absRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving repo root: %v\n", err)
+		os.Exit(1)
+	}
+	if historyFile == "" {
+		historyFile = filepath.Join(absRoot, progress.DefaultFile)
+	}
+
+	exercises, err := exercise.Discover(absRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+		os.Exit(1)
+	}
+	if len(exercises) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no exercises found in %s\n", absRoot)
+		os.Exit(1)
+	}
+	history, err := progress.Load(historyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	m := newModel(exercises, history, historyFile, progress.HintPolicy{Runs: unlockRuns, Wait: unlockWait})
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
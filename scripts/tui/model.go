@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"exercise/gotest"
+	"exercise/hints"
+	"exercise/progress"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tab is a view of the selected exercise.
+type tab int
+
+const (
+	readmeTab tab = iota
+	hintsTab
+	testsTab
+)
+
+var tabNames = []string{"README", "Hints", "Tests"}
+
+// outputMsg is a line of output of a test run.
+type outputMsg struct {
+	path string // exercise path
+	line string
+}
+
+// testDoneMsg ends a test run.
+type testDoneMsg struct {
+	path string
+	run  *gotest.Run
+	err  error
+}
+
+type model struct {
+	exercises   []exercise.Exercise
+	history     *progress.History
+	historyFile string
+	policy      progress.HintPolicy
+
+	cursor   int // index of the selected exercise
+	tab      tab
+	viewport viewport.Model
+	width    int
+	height   int
+	status   string // shown above the key help
+
+	// Test output per exercise path, kept when moving to another exercise.
+	// Only one exercise is tested at a time.
+	outputs map[string][]string
+	running string // path of the exercise being tested, if any
+	events  chan tea.Msg
+	cancel  context.CancelFunc
+}
+
+func newModel(exercises []exercise.Exercise, history *progress.History, historyFile string, policy progress.HintPolicy) *model {
+	m := &model{
+		exercises:   exercises,
+		history:     history,
+		historyFile: historyFile,
+		policy:      policy,
+		viewport:    viewport.New(0, 0),
+		outputs:     make(map[string][]string),
+	}
+
+	// Arrow keys move through the list, so the viewport only pages
+	m.viewport.KeyMap = viewport.KeyMap{
+		PageDown:     key.NewBinding(key.WithKeys("pgdown", " ", "f")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup", "b")),
+		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u")),
+		Down:         key.NewBinding(key.WithKeys("J")),
+		Up:           key.NewBinding(key.WithKeys("K")),
+	}
+
+	// Start where the student left off
+	if next, ok := history.Next(exercises); ok {
+		m.cursor = m.index(next.Path)
+	}
+	return m
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *model) selected() exercise.Exercise {
+	return m.exercises[m.cursor]
+}
+
+// index returns the position of an exercise in the list.
+func (m *model) index(path string) int {
+	return max(slices.IndexFunc(m.exercises, func(ex exercise.Exercise) bool { return ex.Path == path }), 0)
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width = max(m.width-listWidth(m.width)-3, 10)
+		m.viewport.Height = max(m.height-5, 3)
+		m.refresh(false)
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case outputMsg:
+		m.outputs[msg.path] = append(m.outputs[msg.path], msg.line)
+		if m.tab == testsTab && msg.path == m.selected().Path {
+			m.refresh(false)
+		}
+		return m, m.wait()
+
+	case testDoneMsg:
+		m.finishTest(msg)
+	}
+	return m, nil
+}
+
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		if m.cancel != nil {
+			m.cancel()
+		}
+		return m, tea.Quit
+	case "up", "k":
+		m.move(m.cursor - 1)
+	case "down", "j":
+		m.move(m.cursor + 1)
+	case "home":
+		m.move(0)
+	case "end":
+		m.move(len(m.exercises) - 1)
+	case "tab", "right", "l":
+		m.show((m.tab + 1) % tab(len(tabNames)))
+	case "shift+tab", "left":
+		m.show((m.tab + tab(len(tabNames)) - 1) % tab(len(tabNames)))
+	case "1", "2", "3":
+		m.show(tab(msg.String()[0] - '1'))
+	case "t", "enter":
+		return m, m.startTest()
+	case "h":
+		m.revealHint()
+	case "n":
+		m.next()
+	default:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// move selects the exercise at index i.
+func (m *model) move(i int) {
+	i = min(max(i, 0), len(m.exercises)-1)
+	if i != m.cursor {
+		m.cursor = i
+		m.status = ""
+		m.refresh(true)
+	}
+}
+
+// show switches to a tab.
+func (m *model) show(t tab) {
+	m.tab = t
+	m.refresh(true)
+}
+
+// next selects the exercise the progress command would recommend.
+func (m *model) next() {
+	ex, ok := m.history.Next(m.exercises)
+	if !ok {
+		m.status = console.Success.Render("🎉 All exercises are completed")
+		return
+	}
+	m.move(m.index(ex.Path))
+	m.show(readmeTab)
+	m.status = "Next: " + ex.Path + " - " + ex.Title
+}
+
+// startTest runs the tests of the selected exercise in the background,
+// streaming its output into the Tests tab.
+func (m *model) startTest() tea.Cmd {
+	if m.running != "" {
+		m.status = console.Warn.Render("Tests of " + m.running + " are still running")
+		return nil
+	}
+	ex := m.selected()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.running, m.cancel = ex.Path, cancel
+	m.outputs[ex.Path] = nil
+	m.events = make(chan tea.Msg, 64)
+	m.status = "🔄 Running tests of " + ex.Path + "..."
+	m.show(testsTab)
+
+	events := m.events
+	go func() {
+		run, err := progress.Stream(ctx, ex, func(line string) {
+			events <- outputMsg{path: ex.Path, line: line}
+		})
+		events <- testDoneMsg{path: ex.Path, run: run, err: err}
+	}()
+	return m.wait()
+}
+
+// wait delivers the next message of the running test.
+func (m *model) wait() tea.Cmd {
+	events := m.events
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+// finishTest records a finished test run in the history.
+func (m *model) finishTest(msg testDoneMsg) {
+	m.cancel()
+	m.running, m.cancel = "", nil
+
+	attempt := progress.NewAttempt(time.Now(), msg.run, msg.err)
+	m.history.Record(msg.path, attempt)
+	switch {
+	case attempt.Status == "pass":
+		m.status = console.Success.Render(fmt.Sprintf("✅ All %d tests pass", attempt.Total)) +
+			console.Dim.Render(" - press n for the next exercise")
+	case attempt.Total == 0:
+		m.status = console.Fail.Render("❌ No tests ran: " + msg.path + " does not build")
+	default:
+		m.status = console.Fail.Render(fmt.Sprintf("❌ %d/%d tests pass", attempt.Passed, attempt.Total))
+	}
+	if err := m.history.Save(m.historyFile); err != nil {
+		m.status = console.Fail.Render("Error saving history: " + err.Error())
+	}
+	m.refresh(false)
+}
+
+// revealHint reveals the next hint level of the selected exercise, if it is
+// unlocked.
+func (m *model) revealHint() {
+	ex := m.selected()
+	m.show(hintsTab)
+
+	levels, err := hints.Load(ex.Dir)
+	if err != nil {
+		m.status = console.Fail.Render("Error reading hints: " + err.Error())
+		return
+	}
+	eh := m.history.Exercises[ex.Path]
+	if eh.HintsRevealed() >= len(levels) {
+		m.status = console.Dim.Render("No more hints")
+		return
+	}
+	if ok, _, _ := eh.HintUnlocked(m.policy, time.Now()); !ok {
+		m.status = console.Warn.Render("🔒 The next level is still locked")
+		return
+	}
+
+	level := m.history.RevealHint(ex.Path, time.Now())
+	m.status = "💡 Revealed level " + fmt.Sprint(level)
+	if err := m.history.Save(m.historyFile); err != nil {
+		m.status = console.Fail.Render("Error saving history: " + err.Error())
+	}
+	m.refresh(false)
+	m.viewport.GotoBottom()
+}
+
+// refresh renders the current tab into the viewport. The view starts at the
+// top on reset, and test output follows new lines while at the bottom.
+func (m *model) refresh(reset bool) {
+	follow := m.tab == testsTab && m.viewport.AtBottom()
+	m.viewport.SetContent(m.content())
+	switch {
+	case reset && m.tab == testsTab:
+		m.viewport.GotoBottom()
+	case reset:
+		m.viewport.GotoTop()
+	case follow:
+		m.viewport.GotoBottom()
+	}
+}
+
+// content renders the current tab of the selected exercise.
+func (m *model) content() string {
+	ex := m.selected()
+	width := m.viewport.Width
+
+	switch m.tab {
+	case hintsTab:
+		return m.hintsContent(ex, width)
+
+	case testsTab:
+		if lines := m.outputs[ex.Path]; len(lines) > 0 {
+			return strings.Join(lines, "\n")
+		}
+		if m.running == ex.Path {
+			return console.Dim.Render("Waiting for go test...")
+		}
+		text := "Press t to run the tests."
+		if last, ok := m.history.Exercises[ex.Path].Last(); ok {
+			text = fmt.Sprintf("Last run %s: %d/%d tests passed.\n\n%s",
+				last.Time.Format("Jan 2 15:04"), last.Passed, last.Total, text)
+		}
+		return console.Dim.Render(text)
+
+	default:
+		data, err := os.ReadFile(filepath.Join(ex.Dir, "README.md"))
+		if err != nil {
+			return console.Dim.Render("No README.md")
+		}
+		return renderMarkdown(string(data), width)
+	}
+}
+
+// hintsContent renders the revealed hint levels and when the next unlocks.
+func (m *model) hintsContent(ex exercise.Exercise, width int) string {
+	levels, err := hints.Load(ex.Dir)
+	if err != nil {
+		return console.Fail.Render("Error reading hints: " + err.Error())
+	}
+	if len(levels) == 0 {
+		return console.Dim.Render("No " + hints.File)
+	}
+
+	var b strings.Builder
+	eh := m.history.Exercises[ex.Path]
+	revealed := min(eh.HintsRevealed(), len(levels))
+	for _, level := range levels[:revealed] {
+		heading := fmt.Sprintf("Level %d/%d", level.Number, len(levels))
+		if level.Title != "" {
+			heading += ": " + level.Title
+		}
+		b.WriteString(console.Title.Render("── "+heading+" ──") + "\n\n")
+		b.WriteString(renderMarkdown(level.Body, width) + "\n\n")
+	}
+
+	unlocked, runsLeft, wait := eh.HintUnlocked(m.policy, time.Now())
+	switch {
+	case revealed == len(levels):
+		b.WriteString(console.Dim.Render(fmt.Sprintf("All %d hint levels revealed", len(levels))))
+	case unlocked:
+		b.WriteString(console.Dim.Render(fmt.Sprintf("Press h to reveal level %d of %d", revealed+1, len(levels))))
+	default:
+		b.WriteString(console.Warn.Render(fmt.Sprintf("🔒 Level %d unlocks after %d more test run%s or in %s",
+			revealed+1, runsLeft, plural(runsLeft), formatWait(wait))))
+	}
+	return b.String()
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/console"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyHelp lists the keys, below the status line.
+const keyHelp = "↑/↓ select • ←/→ tab • t test • h hint • n next • pgup/pgdn scroll • q quit"
+
+// listWidth returns the width of the exercise list for a terminal width.
+func listWidth(width int) int {
+	return min(max(width/3, 24), 40)
+}
+
+func (m *model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	completed := 0
+	for _, ex := range m.exercises {
+		if m.history.Completed(ex.Path) {
+			completed++
+		}
+	}
+	header := console.Title.Render("🐹 Go Training") + "  " +
+		console.Dim.Render(fmt.Sprintf("%d/%d exercises completed", completed, len(m.exercises)))
+
+	lw := listWidth(m.width)
+	list := lipgloss.NewStyle().
+		Width(lw).
+		Height(m.viewport.Height + 1).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderRight(true).
+		BorderForeground(lipgloss.Color("8")).
+		Render(m.renderList(lw, m.viewport.Height+1))
+	right := lipgloss.JoinVertical(lipgloss.Left, m.renderTabs(), m.viewport.View())
+	body := lipgloss.JoinHorizontal(lipgloss.Top, list, " ", right)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", body, m.status, console.Dim.Render(keyHelp))
+}
+
+// renderTabs shows the tab names, the current one highlighted.
+func (m *model) renderTabs() string {
+	names := make([]string, len(tabNames))
+	for i, name := range tabNames {
+		if tab(i) == m.tab {
+			names[i] = selectedStyle.Render(" " + name + " ")
+		} else {
+			names[i] = console.Dim.Render(" " + name + " ")
+		}
+	}
+	ex := m.selected()
+	return strings.Join(names, " ") + "  " + console.Title.Render(ex.Title)
+}
+
+// renderList shows the exercises grouped by category, scrolled to keep the
+// selected one in view.
+func (m *model) renderList(width, height int) string {
+	var rows []string
+	selectedRow := 0
+	category := ""
+	for i, ex := range m.exercises {
+		if ex.Category != category {
+			category = ex.Category
+			done, total := m.categoryProgress(category)
+			rows = append(rows, console.Title.Render(fmt.Sprintf("%s %d/%d", categoryTitle(category), done, total)))
+		}
+
+		name := fit(path.Base(ex.Path), width-4)
+		if i == m.cursor {
+			selectedRow = len(rows)
+			rows = append(rows, m.icon(ex)+" "+selectedStyle.Render(name+" "))
+		} else {
+			rows = append(rows, m.icon(ex)+" "+name)
+		}
+	}
+
+	offset := min(max(selectedRow-height/2, 0), max(len(rows)-height, 0))
+	return strings.Join(rows[offset:min(offset+height, len(rows))], "\n")
+}
+
+// icon shows the state of an exercise: tested now, completed, attempted or
+// not started.
+func (m *model) icon(ex exercise.Exercise) string {
+	if m.running == ex.Path {
+		return console.Warn.Render("⟳")
+	}
+	last, ok := m.history.Exercises[ex.Path].Last()
+	switch {
+	case !ok:
+		return console.Dim.Render("○")
+	case last.Status == "pass":
+		return console.Success.Render("✓")
+	default:
+		return console.Warn.Render("◐")
+	}
+}
+
+// categoryProgress counts the completed exercises of a category.
+func (m *model) categoryProgress(category string) (done, total int) {
+	for _, ex := range m.exercises {
+		if ex.Category == category {
+			total++
+			if m.history.Completed(ex.Path) {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// categoryTitle capitalizes a category, e.g. "Basics".
+func categoryTitle(category string) string {
+	if category == "" {
+		return category
+	}
+	return strings.ToUpper(category[:1]) + category[1:]
+}
+
+// fit truncates s to n columns.
+func fit(s string, n int) string {
+	if lipgloss.Width(s) <= n || n < 1 {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes)) > n-1 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+var (
+	headingStyle = lipgloss.NewStyle().Bold(true)
+	codeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+)
+
+// renderMarkdown lays out Markdown for the terminal: text is wrapped to
+// width, headings are bold and code blocks are indented and truncated rather
+// than wrapped.
+func renderMarkdown(md string, width int) string {
+	wrap := lipgloss.NewStyle().Width(width)
+	lines := make([]string, 0)
+	inCode := false
+	for _, line := range strings.Split(strings.TrimRight(md, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			inCode = !inCode
+		case inCode:
+			line = "  " + strings.ReplaceAll(line, "\t", "    ")
+			lines = append(lines, codeStyle.Render(fit(line, width)))
+		case strings.HasPrefix(trimmed, "#"):
+			lines = append(lines, headingStyle.Render(wrap.Render(strings.TrimLeft(trimmed, "# "))))
+		case trimmed == "":
+			lines = append(lines, "")
+		default:
+			lines = append(lines, wrap.Render(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatWait renders the time until a hint unlocks, rounded up to a minute.
+func formatWait(d time.Duration) string {
+	minutes := int((d + time.Minute - 1) / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// plural returns "" for one and "s" otherwise.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
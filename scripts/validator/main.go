@@ -6,40 +6,83 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/charmbracelet/lipgloss"
-)
-
-var (
-	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-	failStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
-	warnStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
-	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	titleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true)
+	"exercise"
+	"exercise/console"
+	"exercise/modcache"
+	"exercise/report"
 )
 
 // Command-line flags
 var (
 	runStarter   bool
 	runSolutions bool
+	runParity    bool
+	runMutation  bool
+	runBench     bool
 	verbose      bool
 	outputFile   string
 	noColor      bool
+	seedCache    bool
+	parallel     int
+	reportFormat string
+	reportFile   string
+
+	// Passed through to the starter and solution validators, the timeout
+	// to the mutation validator as well
+	testTimeout time.Duration
+	memoryLimit string
+	cpuLimit    time.Duration
+	offline     bool
+	modCache    string
+
+	// Passed through to the solution validator
+	collectCoverage bool
+	minCoverage     string
+	coverageReport  string
+
+	// Passed through to the mutation validator
+	maxMutants int
+	minScore   float64
 )
 
 func main() {
 	// Parse flags
 	flag.BoolVar(&runStarter, "starter", false, "Validate starter code only")
 	flag.BoolVar(&runSolutions, "solutions", false, "Validate solutions only")
+	flag.BoolVar(&runParity, "parity", false, "Check that starter and solution APIs and test suites match")
+	flag.BoolVar(&runMutation, "mutation", false, "Score exercise test suites by the solution mutants they catch (slow)")
+	flag.BoolVar(&runBench, "bench", false, "Check that optimized solution benchmarks beat their baselines")
 	flag.BoolVar(&verbose, "v", false, "Show detailed test output (verbose mode)")
 	flag.BoolVar(&verbose, "verbose", false, "Show detailed test output (verbose mode)")
 	flag.StringVar(&outputFile, "output", "", "Write failed exercises to file")
 	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.IntVar(&parallel, "parallel", 10, "Number of exercises each validator tests concurrently")
+	flag.StringVar(&reportFormat, "format", "text", "Report format: text, json, or junit")
+	flag.StringVar(&reportFile, "report", "", "Write the merged json/junit report to file (default: report.json or report.xml)")
+	flag.DurationVar(&testTimeout, "timeout", 30*time.Second, "Test timeout for exercises whose manifest sets none")
+	flag.StringVar(&memoryLimit, "memory-limit", "", "Address space limit per test process, e.g. 2GiB (default: none)")
+	flag.DurationVar(&cpuLimit, "cpu-limit", 0, "CPU time limit per test process, e.g. 2m (default: none)")
+	flag.BoolVar(&offline, "offline", false, "Use only vendored modules and the module cache (no network)")
+	flag.StringVar(&modCache, "modcache", "", "Module cache for -offline and -seed-cache (default: <repo>/"+modcache.LocalDir+" if present, else GOMODCACHE)")
+	flag.BoolVar(&seedCache, "seed-cache", false, "Download the modules of all exercises, solutions and validators into the module cache, then exit")
+	flag.BoolVar(&collectCoverage, "coverage", false, "Collect test coverage of each solution")
+	flag.StringVar(&minCoverage, "min-coverage", "", "Minimum solution coverage, e.g. 70 or basics=80,*=60 (implies -coverage)")
+	flag.StringVar(&coverageReport, "coverage-report", "", "Write a solution coverage report to file (.html or text; implies -coverage)")
+	flag.IntVar(&maxMutants, "max-mutants", 100, "Mutants tested per exercise by -mutation (0 for all)")
+	flag.Float64Var(&minScore, "min-score", 60, "Mutation score in percent below which -mutation flags an exercise's tests as weak")
 	flag.Parse()
 
-	// If neither specified, run both
-	runAll := !runStarter && !runSolutions
+	if reportFormat != "text" && !slices.Contains(report.Formats, reportFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unknown -format %q (want text, json, or junit)\n", reportFormat)
+		os.Exit(1)
+	}
+
+	// If no validator is selected, run starter and solution validation
+	runAll := !runStarter && !runSolutions && !runParity && !runMutation && !runBench
 	if runAll {
 		runStarter = true
 		runSolutions = true
@@ -47,11 +90,7 @@ func main() {
 
 	// Disable colors if requested
 	if noColor {
-		successStyle = lipgloss.NewStyle()
-		failStyle = lipgloss.NewStyle()
-		warnStyle = lipgloss.NewStyle()
-		dimStyle = lipgloss.NewStyle()
-		titleStyle = lipgloss.NewStyle()
+		console.DisableColor()
 	}
 
 	// Get repo root
@@ -66,70 +105,264 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Println()
-	fmt.Println(titleStyle.Render("═══════════════════════════════════════════════════════"))
-	fmt.Println(titleStyle.Render("  Go Training Unified Validator"))
-	fmt.Println(titleStyle.Render("═══════════════════════════════════════════════════════"))
-	fmt.Println()
+	if offline || seedCache {
+		if modCache == "" {
+			modCache, err = modcache.Find(absRoot)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error finding module cache: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		// The go command only accepts an absolute GOMODCACHE
+		if modCache, err = filepath.Abs(modCache); err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving module cache: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if seedCache {
+		os.Exit(seedModuleCache(absRoot))
+	}
+	// Offline, the validators themselves are built from the module cache too
+	if offline {
+		for _, kv := range modcache.Env(modCache) {
+			key, value, _ := strings.Cut(kv, "=")
+			os.Setenv(key, value)
+		}
+	}
+
+	console.Banner("Go Training Unified Validator")
+	if offline {
+		fmt.Println(console.Dim.Render("📦 Offline: using module cache " + modCache))
+		fmt.Println()
+	}
+
+	// Each validator writes a JSON report here; they are merged at the end
+	reportDir := ""
+	if reportFormat != "text" {
+		reportDir, err = os.MkdirTemp("", "validator-reports")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating report directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	startTime := time.Now()
-	var starterExitCode, solutionExitCode int
+	var starterExitCode, solutionExitCode, parityExitCode, mutationExitCode, benchExitCode int
 
 	// Run starter validation
 	if runStarter {
-		fmt.Println(titleStyle.Render("🔄 Running Starter Code Validation..."))
+		fmt.Println(console.Title.Render("🔄 Running Starter Code Validation..."))
 		fmt.Println()
-		starterExitCode = runValidator("starter-validator", absRoot)
+		starterExitCode = runValidator("starter-validator", absRoot, reportDir)
 		fmt.Println()
 	}
 
 	// Run solution validation
 	if runSolutions {
-		fmt.Println(titleStyle.Render("🔄 Running Solution Code Validation..."))
+		fmt.Println(console.Title.Render("🔄 Running Solution Code Validation..."))
 		fmt.Println()
-		solutionExitCode = runValidator("solution-validator", absRoot)
+		solutionExitCode = runValidator("solution-validator", absRoot, reportDir)
+		fmt.Println()
+	}
+
+	// Run starter/solution parity validation
+	if runParity {
+		fmt.Println(console.Title.Render("🔄 Running Starter/Solution Parity Validation..."))
+		fmt.Println()
+		parityExitCode = runValidator("parity-validator", absRoot, reportDir)
+		fmt.Println()
+	}
+
+	// Run test suite mutation validation
+	if runMutation {
+		fmt.Println(console.Title.Render("🔄 Running Test Suite Mutation Validation..."))
+		fmt.Println()
+		mutationExitCode = runValidator("mutation-validator", absRoot, reportDir)
+		fmt.Println()
+	}
+
+	// Run benchmark validation
+	if runBench {
+		fmt.Println(console.Title.Render("🔄 Running Benchmark Validation..."))
+		fmt.Println()
+		benchExitCode = runValidator("bench-validator", absRoot, reportDir)
 		fmt.Println()
 	}
 
 	elapsed := time.Since(startTime)
 
 	// Print unified summary
-	fmt.Println(titleStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Println(titleStyle.Render("UNIFIED SUMMARY"))
-	fmt.Println(titleStyle.Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Println()
+	console.Heading("UNIFIED SUMMARY")
 
 	if runStarter {
 		if starterExitCode == 0 {
-			fmt.Println(successStyle.Render("✅") + " Starter Code: PASSED")
+			fmt.Println(console.Success.Render("✅") + " Starter Code: PASSED")
 		} else {
-			fmt.Println(failStyle.Render("❌") + " Starter Code: FAILED")
+			fmt.Println(console.Fail.Render("❌") + " Starter Code: FAILED")
 		}
 	}
 
 	if runSolutions {
 		if solutionExitCode == 0 {
-			fmt.Println(successStyle.Render("✅") + " Solutions: PASSED")
+			fmt.Println(console.Success.Render("✅") + " Solutions: PASSED")
 		} else {
-			fmt.Println(failStyle.Render("❌") + " Solutions: FAILED")
+			fmt.Println(console.Fail.Render("❌") + " Solutions: FAILED")
+		}
+	}
+
+	if runParity {
+		if parityExitCode == 0 {
+			fmt.Println(console.Success.Render("✅") + " Parity: PASSED")
+		} else {
+			fmt.Println(console.Fail.Render("❌") + " Parity: FAILED")
+		}
+	}
+
+	if runMutation {
+		if mutationExitCode == 0 {
+			fmt.Println(console.Success.Render("✅") + " Mutation: PASSED")
+		} else {
+			fmt.Println(console.Fail.Render("❌") + " Mutation: FAILED")
+		}
+	}
+
+	if runBench {
+		if benchExitCode == 0 {
+			fmt.Println(console.Success.Render("✅") + " Benchmarks: PASSED")
+		} else {
+			fmt.Println(console.Fail.Render("❌") + " Benchmarks: FAILED")
 		}
 	}
 
 	fmt.Println()
-	fmt.Printf("Total Time: %s\n", dimStyle.Render(elapsed.Round(time.Second).String()))
+	fmt.Printf("Total Time: %s\n", console.Dim.Render(elapsed.Round(time.Second).String()))
 	fmt.Println()
 
+	// Merge the validators' reports into one document
+	reportErr := false
+	if reportDir != "" {
+		path := reportFile
+		if path == "" {
+			path = report.DefaultFile(reportFormat)
+		}
+		if err := mergeReports(reportDir, path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			reportErr = true
+		} else {
+			fmt.Printf("Report written to: %s\n", path)
+			fmt.Println()
+		}
+	}
+
 	// Exit with failure if any validation failed
-	if starterExitCode != 0 || solutionExitCode != 0 {
-		fmt.Println(failStyle.Render("❌ Some validations failed"))
+	if starterExitCode != 0 || solutionExitCode != 0 || parityExitCode != 0 || mutationExitCode != 0 || benchExitCode != 0 || reportErr {
+		fmt.Println(console.Fail.Render("❌ Some validations failed"))
+		os.RemoveAll(reportDir) // os.Exit skips deferred calls
 		os.Exit(1)
 	} else {
-		fmt.Println(successStyle.Render("✅ All validations passed"))
+		fmt.Println(console.Success.Render("✅ All validations passed"))
+		os.RemoveAll(reportDir)
 		os.Exit(0)
 	}
 }
 
-func runValidator(name string, repoRoot string) int {
+// seedModuleCache downloads the modules every exercise, solution and
+// validator needs into the module cache in one pass, so that later runs work
+// with -offline. It returns the exit code.
+func seedModuleCache(root string) int {
+	fmt.Println()
+	fmt.Println(console.Title.Render("📦 Seeding module cache " + modCache))
+	fmt.Println()
+
+	exercises, err := exercise.Discover(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding exercises: %v\n", err)
+		return 1
+	}
+
+	type seedResult struct {
+		name string
+		err  error
+	}
+	failed := 0
+	emit := func(results []seedResult) {
+		for _, r := range results {
+			if r.err != nil {
+				failed++
+				fmt.Println(console.Fail.Render("❌") + " " + r.name)
+				fmt.Println(console.Dim.Render("   " + strings.ReplaceAll(r.err.Error(), "\n", "\n   ")))
+			} else if verbose {
+				fmt.Println(console.Success.Render("✅") + " " + r.name)
+			}
+		}
+	}
+
+	// Exercises and their standalone solutions
+	exercise.RunParallel(exercises, parallel, func(ex exercise.Exercise) []seedResult {
+		results := []seedResult{{ex.Path, modcache.Seed(ex.Dir, modCache)}}
+		if ex.HasSolutionModule() {
+			results = append(results, seedResult{ex.Path + "/solution", modcache.Seed(ex.SolutionDir(), modCache)})
+		}
+		return results
+	}, emit)
+
+	// The validators and tools, so they can be built offline as well
+	tools, _ := filepath.Glob(filepath.Join(root, "scripts", "*", "go.mod"))
+	for _, gomod := range tools {
+		dir := filepath.Dir(gomod)
+		emit([]seedResult{{"scripts/" + filepath.Base(dir), modcache.Seed(dir, modCache)}})
+	}
+
+	total := len(exercises) + len(tools)
+	fmt.Println()
+	if failed > 0 {
+		fmt.Println(console.Fail.Render(fmt.Sprintf("❌ %d modules could not be downloaded", failed)))
+		return 1
+	}
+	fmt.Println(console.Success.Render(fmt.Sprintf("✅ Module cache seeded for %d exercises and tools", total)))
+	fmt.Println(console.Dim.Render("Run with -offline to validate without network access"))
+	return 0
+}
+
+// mergeReports combines the JSON reports found in dir into a single report at path.
+func mergeReports(dir, path string) error {
+	reports := make([]*report.Report, 0, 5)
+	for _, name := range []string{"starter-validator", "solution-validator", "parity-validator", "mutation-validator", "bench-validator"} {
+		file := filepath.Join(dir, name+".json")
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			continue // validator not run, or failed before writing a report
+		}
+		r, err := report.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, r)
+	}
+	return report.Merge(reports...).WriteFile(path, reportFormat)
+}
+
+// coverageArgs returns the coverage flags for the solution validator. The
+// report path is made absolute because the validator runs in its own
+// directory.
+func coverageArgs() []string {
+	var args []string
+	if collectCoverage {
+		args = append(args, "-coverage")
+	}
+	if minCoverage != "" {
+		args = append(args, "-min-coverage", minCoverage)
+	}
+	if coverageReport != "" {
+		path, err := filepath.Abs(coverageReport)
+		if err != nil {
+			path = coverageReport
+		}
+		args = append(args, "-coverage-report", path)
+	}
+	return args
+}
+
+func runValidator(name string, repoRoot string, reportDir string) int {
 	// Build path to validator source directory
 	validatorDir := filepath.Join("..", name)
 	if _, err := os.Stat(validatorDir); os.IsNotExist(err) {
@@ -149,6 +382,33 @@ func runValidator(name string, repoRoot string) int {
 	if noColor {
 		args = append(args, "-no-color")
 	}
+	// Benchmarks run one exercise at a time, so they do not skew each other
+	if name != "bench-validator" {
+		args = append(args, "-parallel", fmt.Sprintf("%d", parallel))
+	}
+	if reportDir != "" {
+		args = append(args, "-format", "json", "-report", filepath.Join(reportDir, name+".json"))
+	}
+	if name == "starter-validator" || name == "solution-validator" {
+		args = append(args, "-timeout", testTimeout.String())
+		if offline {
+			args = append(args, "-offline", "-modcache", modCache)
+		}
+		if memoryLimit != "" {
+			args = append(args, "-memory-limit", memoryLimit)
+		}
+		if cpuLimit > 0 {
+			args = append(args, "-cpu-limit", cpuLimit.String())
+		}
+	}
+	if name == "solution-validator" {
+		args = append(args, coverageArgs()...)
+	}
+	if name == "mutation-validator" {
+		args = append(args, "-timeout", testTimeout.String())
+		args = append(args, "-max-mutants", fmt.Sprintf("%d", maxMutants))
+		args = append(args, "-min-score", fmt.Sprintf("%g", minScore))
+	}
 	if outputFile != "" {
 		// Append validator name to output file
 		outFile := outputFile
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"exercise"
+	"exercise/console"
+	"exercise/gotest"
+	"exercise/progress"
+)
+
+// Command-line flags
+var (
+	verbose     bool
+	noColor     bool
+	interval    time.Duration
+	debounce    time.Duration
+	repoRoot    string
+	historyFile string
+)
+
+// maxOutputLines limits the failure output shown per test outside verbose mode.
+const maxOutputLines = 10
+
+// fileState identifies a version of a watched file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// snapshot records the source files of the exercise that affect its tests:
+// Go files, module files and test fixtures. The reference solution, hidden
+// directories and editor temporary files are ignored.
+func snapshot(dir string) map[string]fileState {
+	files := make(map[string]fileState)
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files may disappear while editors save them
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != dir && (name == "solution" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		inTestdata := strings.Contains(filepath.ToSlash(path), "/testdata/")
+		if strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".go") && name != "go.mod" && name != "go.sum" && !inTestdata {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	return files
+}
+
+// waitForChange polls the exercise until its files change, then waits until
+// they have been stable for the debounce period so a burst of saves triggers
+// a single run. It returns the new snapshot.
+func waitForChange(dir string, last map[string]fileState) map[string]fileState {
+	current := last
+	for maps.Equal(current, last) {
+		time.Sleep(interval)
+		current = snapshot(dir)
+	}
+	for {
+		time.Sleep(debounce)
+		next := snapshot(dir)
+		if maps.Equal(next, current) {
+			return current
+		}
+		current = next
+	}
+}
+
+// printOutput prints test output, indented and truncated outside verbose mode.
+func printOutput(output string) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if !verbose && len(lines) > maxOutputLines {
+		hidden := len(lines) - maxOutputLines
+		lines = append(lines[:maxOutputLines], console.Dim.Render(fmt.Sprintf("... %d more lines (use -v)", hidden)))
+	}
+	for _, line := range lines {
+		fmt.Println("  " + line)
+	}
+}
+
+// printRun shows the result of a run compared with the previous one: the
+// tests whose status changed, then the output of the tests still failing.
+func printRun(run, prev *gotest.Run, err error, elapsed time.Duration) {
+	timestamp := console.Dim.Render("[" + time.Now().Format("15:04:05") + "]")
+
+	if len(run.Tests) == 0 {
+		if err != nil {
+			fmt.Printf("%s %s\n", timestamp, console.Fail.Render("❌ Tests did not run"))
+			if run.Output != "" {
+				printOutput(run.Output)
+			} else {
+				printOutput(err.Error())
+			}
+		} else {
+			fmt.Printf("%s %s\n", timestamp, console.Warn.Render("⚠️  No tests found"))
+		}
+		fmt.Println()
+		return
+	}
+
+	passed, total := 0, len(run.Tests)
+	for _, tc := range run.Tests {
+		if tc.Status == "pass" {
+			passed++
+		}
+	}
+
+	changes := gotest.Diff(prev, run)
+	gained, lost := 0, 0
+	for _, c := range changes {
+		switch {
+		case c.After == "pass":
+			gained++
+		case c.Before == "pass":
+			lost++
+		}
+	}
+
+	summary := fmt.Sprintf("%d/%d tests passing", passed, total)
+	if passed == total && err == nil {
+		summary = console.Success.Render("✅ " + summary)
+	} else {
+		summary = console.Fail.Render("❌ " + summary)
+	}
+	delta := ""
+	if prev != nil {
+		delta = console.Dim.Render(fmt.Sprintf(" (+%d -%d)", gained, lost))
+	}
+	fmt.Printf("%s %s%s %s\n", timestamp, summary, delta, console.Dim.Render(elapsed.Round(10*time.Millisecond).String()))
+
+	// On the first run everything is new, so only show what fails
+	if prev != nil {
+		for _, c := range changes {
+			switch {
+			case c.After == "pass":
+				fmt.Printf("  %s %s %s\n", console.Success.Render("✅"), c.Name, console.Dim.Render("now passing"))
+			case c.After == "fail":
+				fmt.Printf("  %s %s %s\n", console.Fail.Render("❌"), c.Name, console.Dim.Render("now failing"))
+			}
+		}
+	}
+
+	for _, tc := range run.Failed() {
+		if tc.Race {
+			fmt.Printf("  %s %s\n", console.Fail.Render("🏁 data race in"), tc.Name)
+		} else {
+			fmt.Printf("  %s %s\n", console.Fail.Render("--- FAIL:"), tc.Name)
+		}
+		if tc.Output != "" {
+			printOutput(tc.Output)
+		}
+	}
+	fmt.Println()
+}
+
+func main() {
+	// Parse flags
+	flag.BoolVar(&verbose, "v", false, "Show full failure output")
+	flag.BoolVar(&verbose, "verbose", false, "Show full failure output")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output")
+	flag.DurationVar(&interval, "interval", 500*time.Millisecond, "How often to check files for changes")
+	flag.DurationVar(&debounce, "debounce", 300*time.Millisecond, "Wait for files to be unchanged this long before running tests")
+	flag.StringVar(&repoRoot, "root", filepath.Join("..", ".."), "Repository root")
+	flag.StringVar(&historyFile, "history", "", "Progress history file (default: "+progress.DefaultFile+" in the repo root)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: watch [flags] <exercise>\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Re-runs the tests of an exercise whenever its files change.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if interval <= 0 || debounce < 0 {
+		fmt.Fprintln(os.Stderr, "Error: -interval must be positive and -debounce not negative")
+		os.Exit(1)
+	}
+
+	// Disable colors if requested
+	if noColor {
+		console.DisableColor()
+	}
+
+	ex, err := exercise.Open(repoRoot, flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if historyFile == "" {
+		historyFile = filepath.Join(repoRoot, progress.DefaultFile)
+	}
+
+	fmt.Println()
+	fmt.Println(console.Title.Render("👀 Watching " + ex.Path + " - " + ex.Title))
+	if ex.Race {
+		fmt.Println(console.Dim.Render("   Tests run with the race detector"))
+	}
+	fmt.Println(console.Dim.Render("   Save a file to re-run the tests, Ctrl+C to stop"))
+	fmt.Println()
+
+	var prev *gotest.Run
+	files := snapshot(ex.Dir)
+	for {
+		start := time.Now()
+		run, err := progress.Test(ex)
+		printRun(run, prev, err, time.Since(start))
+		recordRun(ex, run, err)
+
+		// Compare with the last run that got as far as running tests, so a
+		// compile error in between does not make every test look new
+		if len(run.Tests) > 0 {
+			prev = run
+		}
+		files = waitForChange(ex.Dir, files)
+	}
+}
+
+// recordRun adds the run to the student's progress history. Failing to do so
+// must not interrupt watching, so errors are only reported.
+func recordRun(ex exercise.Exercise, run *gotest.Run, err error) {
+	history, loadErr := progress.Load(historyFile)
+	if loadErr == nil {
+		history.Record(ex.Path, progress.NewAttempt(time.Now(), run, err))
+		loadErr = history.Save(historyFile)
+	}
+	if loadErr != nil {
+		fmt.Fprintln(os.Stderr, console.Warn.Render(fmt.Sprintf("⚠️  Could not record progress: %v", loadErr)))
+	}
+}